@@ -1,20 +1,16 @@
 package main
 
 import (
-	"log"
+	"context"
+	"os"
 
 	"github.com/DeSouzaRafael/go-clean-architecture-template/config"
 	_ "github.com/DeSouzaRafael/go-clean-architecture-template/docs"
 	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/app"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/controller/cli"
 )
 
 func main() {
-	// configuration
-	cfg, err := config.NewConfig()
-	if err != nil {
-		log.Fatalf("Config error: %s", err)
-	}
-
-	// run app
-	app.Run(cfg)
+	root := cli.NewRootCommand(app.NewCommands(config.Load))
+	os.Exit(cli.Execute(context.Background(), root, os.Args[1:]))
 }
@@ -1,33 +1,55 @@
 package config
 
 import (
+	"net/url"
 	"os"
-	"strconv"
+	"slices"
 	"time"
-
-	"github.com/joho/godotenv"
 )
 
 type (
 	Config struct {
 		App
 		HTTP
+		GRPC
 		Log
 		PG
+		Idempotency
+		Outbox
+		Auth
+		RateLimit
+		Metrics
+		Tracing
+		Features
+
+		sources Sources
+		values  []Value
 	}
 
 	App struct {
 		Name    string
 		Version string
 		Env     string
+		// ShutdownTimeout bounds the shutdown of every component.
+		ShutdownTimeout time.Duration
 	}
 
 	HTTP struct {
-		Port string
+		Port            string
+		ShutdownTimeout time.Duration
+		// CORSOrigins allowed by CORS. When empty, the router allows the
+		// default origins of the environment.
+		CORSOrigins []string
+	}
+
+	GRPC struct {
+		Port            string
+		ShutdownTimeout time.Duration
 	}
 
 	Log struct {
-		Level string
+		Level  string
+		Format string
 	}
 
 	PG struct {
@@ -35,40 +57,163 @@ type (
 		MaxOpenConns    int
 		MaxIdleConns    int
 		ConnMaxLifetime time.Duration
+		TxIsolation     string
+		MigrateOnStart  bool
+	}
+
+	Idempotency struct {
+		TTL time.Duration
+	}
+
+	Outbox struct {
+		Publisher    string
+		File         string
+		PollInterval time.Duration
+		BatchSize    int
+		MaxBackoff   time.Duration
+		Retention    time.Duration
+	}
+
+	Auth struct {
+		Enabled       bool
+		Issuer        string
+		Audience      string
+		ClockSkew     time.Duration
+		HS256Secret   string
+		PublicKeyFile string
+		JWKSFile      string
+	}
+
+	RateLimit struct {
+		Enabled bool
+		Store   string
+		Read    RateLimitRule
+		Write   RateLimitRule
+		// IP limits each IP address before authentication.
+		IP RateLimitRule
+	}
+
+	// Metrics serves /metrics on the HTTP port, or on Port when set, so
+	// that it can stay off the public network.
+	Metrics struct {
+		Enabled bool
+		Port    string
+	}
+
+	// Tracing exports spans with Exporter: none, stdout or otlp, to an OTLP
+	// gRPC collector at OTLPEndpoint.
+	Tracing struct {
+		Exporter     string
+		OTLPEndpoint string
+		OTLPInsecure bool
+		SampleRatio  float64
+	}
+
+	// Features lists the feature flags turned on. They may change on
+	// reload: read them from the current configuration, or Watch them.
+	Features struct {
+		Flags []string
+	}
+
+	// RateLimitRule allows Burst requests at once, refilled at PerMinute
+	// requests per minute. A zero PerMinute or Burst disables the limit.
+	RateLimitRule struct {
+		PerMinute int
+		Burst     int
 	}
 )
 
+// NewConfig loads the configuration from the environment, a .env file in
+// the working directory and the file named by CONFIG_FILE, if any.
 func NewConfig() (*Config, error) {
-	_ = godotenv.Load()
-
-	cfg := &Config{
-		App: App{
-			Name:    os.Getenv("APP_NAME"),
-			Version: os.Getenv("APP_VERSION"),
-			Env:     os.Getenv("ENV"),
-		},
-		HTTP: HTTP{
-			Port: os.Getenv("HTTP_PORT"),
-		},
-		Log: Log{
-			Level: os.Getenv("LOG_LEVEL"),
-		},
-		PG: PG{
-			URL:             os.Getenv("PG_URL"),
-			MaxOpenConns:    getEnvInt("PG_MAX_OPEN_CONNS", 10),
-			MaxIdleConns:    getEnvInt("PG_MAX_IDLE_CONNS", 5),
-			ConnMaxLifetime: time.Duration(getEnvInt("PG_CONN_MAX_LIFETIME_SEC", 3600)) * time.Second,
-		},
+	return Load(Sources{EnvFile: ".env"})
+}
+
+// Load layers the settings of src over their defaults and validates them.
+// It returns a *ValidationError listing every invalid setting.
+func Load(src Sources) (*Config, error) {
+	if src.File == "" {
+		src.File = os.Getenv("CONFIG_FILE")
+	}
+	settings := Settings()
+
+	layers, errs, err := src.layers(settings)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make([]Value, len(settings))
+	for i, s := range settings {
+		v := Value{Key: s.Key, Value: s.Default, Source: SourceDefault}
+		for _, l := range layers {
+			if raw, ok := l.values[s.Key]; ok {
+				v.Value, v.Source = raw, l.source
+			}
+		}
+		values[i] = v
+	}
+	return build(src, values, errs)
+}
+
+// build parses values, one per setting, into a validated Config. errs are
+// problems found while reading them.
+func build(src Sources, values []Value, errs []error) (*Config, error) {
+	cfg := &Config{sources: src, values: values}
+	p := &problems{errs: errs, invalid: make(map[string]bool)}
+	for i, s := range cfg.settings() {
+		if v := values[i].Value; v != "" {
+			if err := s.set(v); err != nil {
+				p.add(s.Key, "%v", err)
+			}
+		}
 	}
+	cfg.validate(p)
 
+	if len(p.errs) > 0 {
+		return nil, &ValidationError{Errors: p.errs}
+	}
 	return cfg, nil
 }
 
-func getEnvInt(key string, defaultVal int) int {
-	if v := os.Getenv(key); v != "" {
-		if n, err := strconv.Atoi(v); err == nil {
-			return n
+// Enabled tells whether the feature flag name is turned on.
+func (f Features) Enabled(name string) bool {
+	return slices.Contains(f.Flags, name)
+}
+
+// Value is the effective value of a setting and the source it came from.
+type Value struct {
+	Key    string
+	Value  string
+	Source string
+}
+
+// Values returns the effective value of every setting, with secrets
+// redacted.
+func (c *Config) Values() []Value {
+	settings := Settings()
+	out := make([]Value, len(c.values))
+	for i, v := range c.values {
+		if settings[i].Secret {
+			v.Value = redact(v.Value)
 		}
+		out[i] = v
+	}
+	return out
+}
+
+// redact hides a secret. Only the password of a URL is hidden, so that the
+// host it points to can still be checked.
+func redact(v string) string {
+	if v == "" {
+		return ""
+	}
+	u, err := url.Parse(v)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return "[redacted]"
+	}
+	if q := u.Query(); q.Has("password") {
+		q.Set("password", "xxxxx")
+		u.RawQuery = q.Encode()
 	}
-	return defaultVal
+	return u.Redacted()
 }
@@ -1,7 +1,9 @@
 package config
 
 import (
+	"errors"
 	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -12,55 +14,299 @@ import (
 func TestNewConfig(t *testing.T) {
 	t.Setenv("APP_NAME", "test-app")
 	t.Setenv("APP_VERSION", "1.0.0")
-	t.Setenv("ENV", "local")
+	t.Setenv("ENV", "dev")
 	t.Setenv("HTTP_PORT", "8080")
+	t.Setenv("GRPC_PORT", "9191")
 	t.Setenv("LOG_LEVEL", "debug")
+	t.Setenv("LOG_FORMAT", "console")
 	t.Setenv("PG_URL", "postgres://localhost/test")
 	t.Setenv("PG_MAX_OPEN_CONNS", "20")
 	t.Setenv("PG_MAX_IDLE_CONNS", "10")
 	t.Setenv("PG_CONN_MAX_LIFETIME_SEC", "7200")
+	t.Setenv("PG_TX_ISOLATION", "serializable")
+	t.Setenv("PG_MIGRATE_ON_START", "false")
+	t.Setenv("IDEMPOTENCY_TTL_SEC", "600")
+	t.Setenv("OUTBOX_PUBLISHER", "file")
+	t.Setenv("OUTBOX_FILE", "/tmp/events.jsonl")
+	t.Setenv("OUTBOX_POLL_INTERVAL_MS", "250")
+	t.Setenv("OUTBOX_BATCH_SIZE", "20")
+	t.Setenv("OUTBOX_MAX_BACKOFF_SEC", "60")
+	t.Setenv("OUTBOX_RETENTION_SEC", "3600")
+	t.Setenv("AUTH_ENABLED", "false")
+	t.Setenv("AUTH_ISSUER", "https://issuer.example.com")
+	t.Setenv("AUTH_AUDIENCE", "users-api")
+	t.Setenv("AUTH_CLOCK_SKEW_SEC", "5")
+	t.Setenv("AUTH_HS256_SECRET", "secret")
+	t.Setenv("AUTH_PUBLIC_KEY_FILE", "/keys/public.pem")
+	t.Setenv("AUTH_JWKS_FILE", "/keys/jwks.json")
+	t.Setenv("RATE_LIMIT_ENABLED", "false")
+	t.Setenv("RATE_LIMIT_STORE", "postgres")
+	t.Setenv("RATE_LIMIT_READ_PER_MIN", "120")
+	t.Setenv("RATE_LIMIT_READ_BURST", "30")
+	t.Setenv("RATE_LIMIT_WRITE_PER_MIN", "10")
+	t.Setenv("RATE_LIMIT_WRITE_BURST", "5")
+	t.Setenv("RATE_LIMIT_IP_PER_MIN", "300")
+	t.Setenv("RATE_LIMIT_IP_BURST", "50")
+	t.Setenv("METRICS_ENABLED", "false")
+	t.Setenv("METRICS_PORT", "9100")
+	t.Setenv("TRACING_EXPORTER", "otlp")
+	t.Setenv("TRACING_OTLP_ENDPOINT", "collector:4317")
+	t.Setenv("TRACING_OTLP_INSECURE", "false")
+	t.Setenv("TRACING_SAMPLE_RATIO", "0.25")
 
 	cfg, err := NewConfig()
 	require.NoError(t, err)
 
 	assert.Equal(t, "test-app", cfg.App.Name)
 	assert.Equal(t, "1.0.0", cfg.App.Version)
-	assert.Equal(t, "local", cfg.App.Env)
+	assert.Equal(t, "dev", cfg.App.Env)
 	assert.Equal(t, "8080", cfg.HTTP.Port)
+	assert.Equal(t, "9191", cfg.GRPC.Port)
 	assert.Equal(t, "debug", cfg.Log.Level)
+	assert.Equal(t, "console", cfg.Log.Format)
 	assert.Equal(t, "postgres://localhost/test", cfg.PG.URL)
 	assert.Equal(t, 20, cfg.PG.MaxOpenConns)
 	assert.Equal(t, 10, cfg.PG.MaxIdleConns)
 	assert.Equal(t, 7200*time.Second, cfg.PG.ConnMaxLifetime)
+	assert.Equal(t, "serializable", cfg.PG.TxIsolation)
+	assert.False(t, cfg.PG.MigrateOnStart)
+	assert.Equal(t, 600*time.Second, cfg.Idempotency.TTL)
+	assert.Equal(t, Outbox{
+		Publisher:    "file",
+		File:         "/tmp/events.jsonl",
+		PollInterval: 250 * time.Millisecond,
+		BatchSize:    20,
+		MaxBackoff:   time.Minute,
+		Retention:    time.Hour,
+	}, cfg.Outbox)
+	assert.Equal(t, Auth{
+		Enabled:       false,
+		Issuer:        "https://issuer.example.com",
+		Audience:      "users-api",
+		ClockSkew:     5 * time.Second,
+		HS256Secret:   "secret",
+		PublicKeyFile: "/keys/public.pem",
+		JWKSFile:      "/keys/jwks.json",
+	}, cfg.Auth)
+	assert.Equal(t, RateLimit{
+		Enabled: false,
+		Store:   "postgres",
+		Read:    RateLimitRule{PerMinute: 120, Burst: 30},
+		Write:   RateLimitRule{PerMinute: 10, Burst: 5},
+		IP:      RateLimitRule{PerMinute: 300, Burst: 50},
+	}, cfg.RateLimit)
+	assert.Equal(t, Metrics{Enabled: false, Port: "9100"}, cfg.Metrics)
+	assert.Equal(t, Tracing{Exporter: "otlp", OTLPEndpoint: "collector:4317", OTLPInsecure: false, SampleRatio: 0.25}, cfg.Tracing)
 }
 
 func TestNewConfig_Defaults(t *testing.T) {
-	os.Unsetenv("PG_MAX_OPEN_CONNS")
-	os.Unsetenv("PG_MAX_IDLE_CONNS")
-	os.Unsetenv("PG_CONN_MAX_LIFETIME_SEC")
+	clearEnv(t)
+	t.Setenv("PG_URL", "postgres://localhost/test")
+	t.Setenv("AUTH_HS256_SECRET", "secret")
 
 	cfg, err := NewConfig()
 	require.NoError(t, err)
 
+	assert.Equal(t, App{Name: "go-clean-architecture-template", Env: "dev", ShutdownTimeout: 30 * time.Second}, cfg.App)
+	assert.Equal(t, HTTP{Port: "8080", ShutdownTimeout: 10 * time.Second}, cfg.HTTP)
+	assert.Equal(t, "info", cfg.Log.Level)
 	assert.Equal(t, 10, cfg.PG.MaxOpenConns)
 	assert.Equal(t, 5, cfg.PG.MaxIdleConns)
 	assert.Equal(t, 3600*time.Second, cfg.PG.ConnMaxLifetime)
+	assert.Equal(t, 24*time.Hour, cfg.Idempotency.TTL)
+	assert.True(t, cfg.PG.MigrateOnStart)
+	assert.Equal(t, "9090", cfg.GRPC.Port)
+	assert.Equal(t, 10*time.Second, cfg.GRPC.ShutdownTimeout)
+	assert.Equal(t, "json", cfg.Log.Format)
+	assert.Equal(t, "stdout", cfg.Outbox.Publisher)
+	assert.Equal(t, time.Second, cfg.Outbox.PollInterval)
+	assert.Equal(t, 100, cfg.Outbox.BatchSize)
+	assert.Equal(t, 5*time.Minute, cfg.Outbox.MaxBackoff)
+	assert.Equal(t, 7*24*time.Hour, cfg.Outbox.Retention)
+	assert.True(t, cfg.Auth.Enabled)
+	assert.Equal(t, 30*time.Second, cfg.Auth.ClockSkew)
+	assert.Equal(t, RateLimit{
+		Enabled: true,
+		Store:   "memory",
+		Read:    RateLimitRule{PerMinute: 600, Burst: 100},
+		Write:   RateLimitRule{PerMinute: 60, Burst: 20},
+		IP:      RateLimitRule{PerMinute: 1200, Burst: 200},
+	}, cfg.RateLimit)
+	assert.Equal(t, Metrics{Enabled: true}, cfg.Metrics)
+	assert.Equal(t, Tracing{Exporter: "none", OTLPEndpoint: "localhost:4317", OTLPInsecure: true, SampleRatio: 1}, cfg.Tracing)
+}
+
+// clearEnv hides the settings of the environment for the duration of t.
+func clearEnv(t *testing.T) {
+	t.Helper()
+	t.Setenv("CONFIG_FILE", "")
+	for _, s := range Settings() {
+		t.Setenv(s.Key, "")
+		if s.Secret {
+			t.Setenv(s.Key+fileSuffix, "")
+		}
+	}
+}
+
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+// value returns the effective value of key.
+func value(t *testing.T, cfg *Config, key string) Value {
+	t.Helper()
+	for _, v := range cfg.Values() {
+		if v.Key == key {
+			return v
+		}
+	}
+	t.Fatalf("no setting %s", key)
+	return Value{}
+}
+
+func TestLoad_Layers(t *testing.T) {
+	clearEnv(t)
+	file := writeFile(t, "config.yaml", `
+env: stg
+http:
+  port: 8081
+log_level: warn
+pg:
+  url: postgres://file/db
+  max-open-conns: 30
+auth:
+  enabled: false
+rate_limit:
+  read:
+    per_min: 100
+tracing:
+  sample_ratio: 0.5
+`)
+	envFile := writeFile(t, ".env", "LOG_LEVEL=error\nPG_MAX_OPEN_CONNS=40\nPOSTGRES_USER=ignored\n")
+	t.Setenv("PG_MAX_OPEN_CONNS", "50")
+	t.Setenv("GRPC_PORT", "9191")
+
+	cfg, err := Load(Sources{File: file, EnvFile: envFile, Flags: map[string]string{"GRPC_PORT": "9292"}})
+	require.NoError(t, err)
+
+	assert.Equal(t, "stg", cfg.App.Env)
+	assert.Equal(t, "8081", cfg.HTTP.Port)
+	assert.Equal(t, "9292", cfg.GRPC.Port)
+	assert.Equal(t, "error", cfg.Log.Level)
+	assert.Equal(t, 50, cfg.PG.MaxOpenConns)
+	assert.Equal(t, 100, cfg.RateLimit.Read.PerMinute)
+	assert.Equal(t, 0.5, cfg.Tracing.SampleRatio)
+	assert.False(t, cfg.Auth.Enabled)
+
+	assert.Equal(t, Value{Key: "ENV", Value: "stg", Source: SourceFile}, value(t, cfg, "ENV"))
+	assert.Equal(t, SourceEnvFile, value(t, cfg, "LOG_LEVEL").Source)
+	assert.Equal(t, SourceEnv, value(t, cfg, "PG_MAX_OPEN_CONNS").Source)
+	assert.Equal(t, SourceFlag, value(t, cfg, "GRPC_PORT").Source)
+	assert.Equal(t, SourceDefault, value(t, cfg, "LOG_FORMAT").Source)
+}
+
+func TestLoad_TOML(t *testing.T) {
+	clearEnv(t)
+	file := writeFile(t, "config.toml", `
+env = "prd"
+
+[pg]
+url = "postgres://toml/db"
+
+[auth]
+hs256_secret = "secret"
+
+[outbox]
+batch_size = 20
+`)
+	t.Setenv("CONFIG_FILE", file)
+
+	cfg, err := Load(Sources{})
+	require.NoError(t, err)
+
+	assert.Equal(t, "prd", cfg.App.Env)
+	assert.Equal(t, "postgres://toml/db", cfg.PG.URL)
+	assert.Equal(t, "secret", cfg.Auth.HS256Secret)
+	assert.Equal(t, 20, cfg.Outbox.BatchSize)
+}
+
+func TestLoad_SecretFiles(t *testing.T) {
+	clearEnv(t)
+	t.Setenv("PG_URL_FILE", writeFile(t, "pg_url", "postgres://app:s3cret@db:5432/app\n"))
+	t.Setenv("AUTH_HS256_SECRET_FILE", writeFile(t, "hs256", "from-file"))
+
+	cfg, err := Load(Sources{})
+	require.NoError(t, err)
+
+	assert.Equal(t, "postgres://app:s3cret@db:5432/app", cfg.PG.URL)
+	assert.Equal(t, "from-file", cfg.Auth.HS256Secret)
+	assert.Equal(t, Value{Key: "PG_URL", Value: "postgres://app:xxxxx@db:5432/app", Source: SourceEnv}, value(t, cfg, "PG_URL"))
+	assert.Equal(t, "[redacted]", value(t, cfg, "AUTH_HS256_SECRET").Value)
 }
 
-func TestGetEnvInt_InvalidValue(t *testing.T) {
-	t.Setenv("TEST_INT", "not-a-number")
-	result := getEnvInt("TEST_INT", 42)
-	assert.Equal(t, 42, result)
+func TestLoad_SecretFileConflict(t *testing.T) {
+	clearEnv(t)
+	t.Setenv("AUTH_HS256_SECRET", "secret")
+	t.Setenv("PG_URL", "postgres://localhost/db")
+	t.Setenv("PG_URL_FILE", writeFile(t, "pg_url", "postgres://file/db"))
+
+	_, err := Load(Sources{})
+
+	assert.EqualError(t, err, "invalid configuration:\n  PG_URL: set either PG_URL or PG_URL_FILE (env)")
 }
 
-func TestGetEnvInt_ValidValue(t *testing.T) {
-	t.Setenv("TEST_INT", "99")
-	result := getEnvInt("TEST_INT", 42)
-	assert.Equal(t, 99, result)
+func TestLoad_Invalid(t *testing.T) {
+	clearEnv(t)
+	t.Setenv("ENV", "local")
+	t.Setenv("HTTP_PORT", "http")
+	t.Setenv("HTTP_SHUTDOWN_TIMEOUT_SEC", "60")
+	t.Setenv("GRPC_SHUTDOWN_TIMEOUT_SEC", "0")
+	t.Setenv("LOG_LEVEL", "verbose")
+	t.Setenv("PG_MAX_IDLE_CONNS", "-1")
+	t.Setenv("OUTBOX_BATCH_SIZE", "many")
+	t.Setenv("TRACING_SAMPLE_RATIO", "2")
+
+	_, err := Load(Sources{Flags: map[string]string{"HTTP_PROT": "8080"}})
+
+	var verr *ValidationError
+	require.True(t, errors.As(err, &verr))
+	assert.Equal(t, `invalid configuration:
+  HTTP_PROT: unknown setting (flag)
+  OUTBOX_BATCH_SIZE: must be an integer, got "many"
+  ENV: must be one of dev, stg, prd, got "local"
+  HTTP_PORT: must be a port from 1 to 65535, got "http"
+  HTTP_SHUTDOWN_TIMEOUT_SEC: must be less than SHUTDOWN_TIMEOUT_SEC, which also covers the other components
+  GRPC_SHUTDOWN_TIMEOUT_SEC: must be at least 1, got 0
+  LOG_LEVEL: must be one of debug, info, warn, error, got "verbose"
+  PG_URL: required
+  PG_MAX_IDLE_CONNS: must be at least 0, got -1
+  AUTH_ENABLED: requires AUTH_HS256_SECRET, AUTH_PUBLIC_KEY_FILE or AUTH_JWKS_FILE
+  TRACING_SAMPLE_RATIO: must be from 0 to 1, got 2`, err.Error())
+}
+
+func TestLoad_FileErrors(t *testing.T) {
+	clearEnv(t)
+
+	_, err := Load(Sources{File: writeFile(t, "config.json", "{}")})
+	assert.ErrorContains(t, err, `unsupported format ".json"`)
+
+	_, err = Load(Sources{File: filepath.Join(t.TempDir(), "missing.yaml")})
+	assert.ErrorIs(t, err, os.ErrNotExist)
+
+	_, err = Load(Sources{File: writeFile(t, "config.yaml", "http:\n  port: [1, 2]\n")})
+	assert.ErrorContains(t, err, "HTTP_PORT: lists are not supported")
+
+	_, err = Load(Sources{File: writeFile(t, "config.yaml", "pg:\n  uri: postgres://localhost/db\n")})
+	assert.ErrorContains(t, err, "PG_URI: unknown setting (file)")
 }
 
-func TestGetEnvInt_MissingKey(t *testing.T) {
-	os.Unsetenv("TEST_INT_MISSING")
-	result := getEnvInt("TEST_INT_MISSING", 42)
-	assert.Equal(t, 42, result)
+func TestRedact(t *testing.T) {
+	assert.Equal(t, "", redact(""))
+	assert.Equal(t, "[redacted]", redact("secret"))
+	assert.Equal(t, "[redacted]", redact("host=db password=secret"))
+	assert.Equal(t, "postgres://db/app?password=xxxxx", redact("postgres://db/app?password=secret"))
+	assert.Equal(t, "postgres://app:xxxxx@db/app", redact("postgres://app:secret@db/app"))
 }
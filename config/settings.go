@@ -0,0 +1,163 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Setting describes a configuration key. Key is the environment variable
+// setting it, such as HTTP_PORT.
+type Setting struct {
+	Key     string
+	Default string
+	Usage   string
+	// Secret settings are redacted when printed, and may be read from the
+	// file named by Key + "_FILE".
+	Secret bool
+	// Reloadable settings take effect when the configuration is reloaded,
+	// the others on the next start.
+	Reloadable bool
+}
+
+// setting binds a Setting to the field of a Config it is parsed into.
+type setting struct {
+	Setting
+	set func(string) error
+}
+
+// Settings lists every configuration key.
+func Settings() []Setting {
+	var c Config
+	settings := c.settings()
+	out := make([]Setting, len(settings))
+	for i, s := range settings {
+		out[i] = s.Setting
+	}
+	return out
+}
+
+func (c *Config) settings() []setting {
+	return []setting{
+		{Setting{Key: "ENV", Default: "dev", Usage: "environment: dev, stg or prd"}, str(&c.App.Env)},
+		{Setting{Key: "APP_NAME", Default: "go-clean-architecture-template", Usage: "application name, reported in traces"}, str(&c.App.Name)},
+		{Setting{Key: "APP_VERSION", Usage: "application version, reported in traces"}, str(&c.App.Version)},
+		{Setting{Key: "SHUTDOWN_TIMEOUT_SEC", Default: "30", Usage: "how long stopping every component may take"}, seconds(&c.App.ShutdownTimeout)},
+		{Setting{Key: "HTTP_PORT", Default: "8080", Usage: "port of the HTTP server"}, str(&c.HTTP.Port)},
+		{Setting{Key: "HTTP_SHUTDOWN_TIMEOUT_SEC", Default: "10", Usage: "how long requests in flight may take to finish on shutdown"}, seconds(&c.HTTP.ShutdownTimeout)},
+		{Setting{Key: "CORS_ALLOW_ORIGINS", Usage: "comma-separated origins allowed by CORS, * wildcards allowed; defaults to any origin, or https://*.your.domain.com when ENV=prd", Reloadable: true}, list(&c.HTTP.CORSOrigins)},
+		{Setting{Key: "GRPC_PORT", Default: "9090", Usage: "port of the gRPC server"}, str(&c.GRPC.Port)},
+		{Setting{Key: "GRPC_SHUTDOWN_TIMEOUT_SEC", Default: "10", Usage: "how long RPCs in flight may take to finish on shutdown"}, seconds(&c.GRPC.ShutdownTimeout)},
+		{Setting{Key: "LOG_LEVEL", Default: "info", Usage: "debug, info, warn or error", Reloadable: true}, str(&c.Log.Level)},
+		{Setting{Key: "LOG_FORMAT", Default: "json", Usage: "json, or console for human-readable lines"}, str(&c.Log.Format)},
+		{Setting{Key: "PG_URL", Usage: "PostgreSQL connection URL", Secret: true}, str(&c.PG.URL)},
+		{Setting{Key: "PG_MAX_OPEN_CONNS", Default: "10", Usage: "maximum open connections, 0 for no limit"}, integer(&c.PG.MaxOpenConns)},
+		{Setting{Key: "PG_MAX_IDLE_CONNS", Default: "5", Usage: "maximum idle connections"}, integer(&c.PG.MaxIdleConns)},
+		{Setting{Key: "PG_CONN_MAX_LIFETIME_SEC", Default: "3600", Usage: "connection lifetime in seconds, 0 for no limit"}, seconds(&c.PG.ConnMaxLifetime)},
+		{Setting{Key: "PG_TX_ISOLATION", Usage: "isolation level of use case transactions: read_committed, repeatable_read or serializable"}, str(&c.PG.TxIsolation)},
+		{Setting{Key: "PG_MIGRATE_ON_START", Default: "true", Usage: "apply pending migrations at startup"}, boolean(&c.PG.MigrateOnStart)},
+		{Setting{Key: "IDEMPOTENCY_TTL_SEC", Default: "86400", Usage: "how long an Idempotency-Key and its response are kept"}, seconds(&c.Idempotency.TTL)},
+		{Setting{Key: "OUTBOX_PUBLISHER", Default: "stdout", Usage: "where domain events are published: stdout, file or bus"}, str(&c.Outbox.Publisher)},
+		{Setting{Key: "OUTBOX_FILE", Default: "outbox.jsonl", Usage: "file events are appended to with OUTBOX_PUBLISHER=file"}, str(&c.Outbox.File)},
+		{Setting{Key: "OUTBOX_POLL_INTERVAL_MS", Default: "1000", Usage: "how often the relay looks for unpublished events"}, millis(&c.Outbox.PollInterval)},
+		{Setting{Key: "OUTBOX_BATCH_SIZE", Default: "100", Usage: "events claimed per relay transaction"}, integer(&c.Outbox.BatchSize)},
+		{Setting{Key: "OUTBOX_MAX_BACKOFF_SEC", Default: "300", Usage: "upper bound of the delay between retries of a failing event"}, seconds(&c.Outbox.MaxBackoff)},
+		{Setting{Key: "OUTBOX_RETENTION_SEC", Default: "604800", Usage: "how long published events are kept"}, seconds(&c.Outbox.Retention)},
+		{Setting{Key: "AUTH_ENABLED", Default: "true", Usage: "require a bearer token or API key"}, boolean(&c.Auth.Enabled)},
+		{Setting{Key: "AUTH_ISSUER", Usage: "required iss claim"}, str(&c.Auth.Issuer)},
+		{Setting{Key: "AUTH_AUDIENCE", Usage: "required aud claim"}, str(&c.Auth.Audience)},
+		{Setting{Key: "AUTH_CLOCK_SKEW_SEC", Default: "30", Usage: "leeway when checking exp, nbf and iat"}, seconds(&c.Auth.ClockSkew)},
+		{Setting{Key: "AUTH_HS256_SECRET", Usage: "shared secret verifying HS256 tokens", Secret: true}, str(&c.Auth.HS256Secret)},
+		{Setting{Key: "AUTH_PUBLIC_KEY_FILE", Usage: "PEM public key verifying RS256 and ES256 tokens"}, str(&c.Auth.PublicKeyFile)},
+		{Setting{Key: "AUTH_JWKS_FILE", Usage: "JWKS file of RS256 and ES256 keys"}, str(&c.Auth.JWKSFile)},
+		{Setting{Key: "RATE_LIMIT_ENABLED", Default: "true", Usage: "limit the requests of each client"}, boolean(&c.RateLimit.Enabled)},
+		{Setting{Key: "RATE_LIMIT_STORE", Default: "memory", Usage: "where token buckets are kept: memory or postgres"}, str(&c.RateLimit.Store)},
+		{Setting{Key: "RATE_LIMIT_READ_PER_MIN", Default: "600", Usage: "read requests per minute and client, 0 for no limit", Reloadable: true}, integer(&c.RateLimit.Read.PerMinute)},
+		{Setting{Key: "RATE_LIMIT_READ_BURST", Default: "100", Usage: "read requests a client may send at once", Reloadable: true}, integer(&c.RateLimit.Read.Burst)},
+		{Setting{Key: "RATE_LIMIT_WRITE_PER_MIN", Default: "60", Usage: "write requests per minute and client, 0 for no limit", Reloadable: true}, integer(&c.RateLimit.Write.PerMinute)},
+		{Setting{Key: "RATE_LIMIT_WRITE_BURST", Default: "20", Usage: "write requests a client may send at once", Reloadable: true}, integer(&c.RateLimit.Write.Burst)},
+		{Setting{Key: "RATE_LIMIT_IP_PER_MIN", Default: "1200", Usage: "requests per minute and IP address, counted before authentication, 0 for no limit", Reloadable: true}, integer(&c.RateLimit.IP.PerMinute)},
+		{Setting{Key: "RATE_LIMIT_IP_BURST", Default: "200", Usage: "requests an IP address may send at once", Reloadable: true}, integer(&c.RateLimit.IP.Burst)},
+		{Setting{Key: "FEATURES", Usage: "comma-separated feature flags turned on", Reloadable: true}, list(&c.Features.Flags)},
+		{Setting{Key: "METRICS_ENABLED", Default: "true", Usage: "serve Prometheus metrics at /metrics"}, boolean(&c.Metrics.Enabled)},
+		{Setting{Key: "METRICS_PORT", Usage: "serve /metrics on this port instead of HTTP_PORT"}, str(&c.Metrics.Port)},
+		{Setting{Key: "TRACING_EXPORTER", Default: "none", Usage: "none, stdout or otlp"}, str(&c.Tracing.Exporter)},
+		{Setting{Key: "TRACING_OTLP_ENDPOINT", Default: "localhost:4317", Usage: "OTLP gRPC collector address"}, str(&c.Tracing.OTLPEndpoint)},
+		{Setting{Key: "TRACING_OTLP_INSECURE", Default: "true", Usage: "send spans to the collector without TLS"}, boolean(&c.Tracing.OTLPInsecure)},
+		{Setting{Key: "TRACING_SAMPLE_RATIO", Default: "1", Usage: "share of new traces recorded, from 0 to 1"}, float(&c.Tracing.SampleRatio)},
+	}
+}
+
+func str(p *string) func(string) error {
+	return func(v string) error {
+		*p = v
+		return nil
+	}
+}
+
+// list parses comma-separated values, dropping empty ones.
+func list(p *[]string) func(string) error {
+	return func(v string) error {
+		var out []string
+		for _, item := range strings.Split(v, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				out = append(out, item)
+			}
+		}
+		*p = out
+		return nil
+	}
+}
+
+func integer(p *int) func(string) error {
+	return func(v string) error {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return fmt.Errorf("must be an integer, got %q", v)
+		}
+		*p = n
+		return nil
+	}
+}
+
+func float(p *float64) func(string) error {
+	return func(v string) error {
+		f, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return fmt.Errorf("must be a number, got %q", v)
+		}
+		*p = f
+		return nil
+	}
+}
+
+func boolean(p *bool) func(string) error {
+	return func(v string) error {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return fmt.Errorf("must be true or false, got %q", v)
+		}
+		*p = b
+		return nil
+	}
+}
+
+func seconds(p *time.Duration) func(string) error {
+	return duration(p, time.Second)
+}
+
+func millis(p *time.Duration) func(string) error {
+	return duration(p, time.Millisecond)
+}
+
+func duration(p *time.Duration, unit time.Duration) func(string) error {
+	return func(v string) error {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return fmt.Errorf("must be an integer, got %q", v)
+		}
+		*p = time.Duration(n) * unit
+		return nil
+	}
+}
@@ -0,0 +1,184 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/joho/godotenv"
+	"gopkg.in/yaml.v3"
+)
+
+// Sources of a configuration. Settings are layered, each source overriding
+// the ones before it: defaults, File, EnvFile, the environment and Flags.
+// Empty values are ignored.
+type Sources struct {
+	// File is an optional YAML (.yaml, .yml) or TOML (.toml) file. When
+	// empty, CONFIG_FILE names it. Its keys are the settings in lower case,
+	// and may be nested by prefix: http: {port: 8080} sets HTTP_PORT.
+	File string
+	// EnvFile is a .env file, skipped when it does not exist.
+	EnvFile string
+	// Flags holds the settings given on the command line, by key.
+	Flags map[string]string
+}
+
+// Sources of a Value.
+const (
+	SourceDefault = "default"
+	SourceFile    = "file"
+	SourceEnvFile = "env_file"
+	SourceEnv     = "env"
+	SourceFlag    = "flag"
+)
+
+// fileSuffix names the file a secret setting is read from, as in
+// PG_URL_FILE=/run/secrets/pg_url.
+const fileSuffix = "_FILE"
+
+type layer struct {
+	source string
+	values map[string]string
+}
+
+// layers reads the sources, from the lowest precedence to the highest.
+// Settings unknown to a file or the flags are errors, as they are most
+// likely typos; .env files and the environment are shared with other
+// programs, so only known settings are read from them. The returned errors
+// are the problems of single settings; err is set when a source cannot be
+// read at all.
+func (s Sources) layers(settings []Setting) (layers []layer, errs []error, err error) {
+	known := make(map[string]bool, 2*len(settings))
+	for _, st := range settings {
+		known[st.Key] = true
+		if st.Secret {
+			known[st.Key+fileSuffix] = true
+		}
+	}
+
+	add := func(source string, values map[string]string, strict bool) {
+		for _, k := range slices.Sorted(maps.Keys(values)) {
+			if !known[k] {
+				if strict {
+					errs = append(errs, fmt.Errorf("%s: unknown setting (%s)", k, source))
+				}
+				delete(values, k)
+			} else if values[k] == "" {
+				delete(values, k)
+			}
+		}
+		errs = append(errs, readSecretFiles(source, values, settings)...)
+		layers = append(layers, layer{source: source, values: values})
+	}
+
+	if s.File != "" {
+		values, err := readFile(s.File)
+		if err != nil {
+			return nil, nil, err
+		}
+		add(SourceFile, values, true)
+	}
+
+	if s.EnvFile != "" {
+		values, err := godotenv.Read(s.EnvFile)
+		switch {
+		case errors.Is(err, fs.ErrNotExist):
+		case err != nil:
+			return nil, nil, fmt.Errorf("read %s: %w", s.EnvFile, err)
+		default:
+			add(SourceEnvFile, values, false)
+		}
+	}
+
+	env := make(map[string]string)
+	for k := range known {
+		if v, ok := os.LookupEnv(k); ok {
+			env[k] = v
+		}
+	}
+	add(SourceEnv, env, false)
+
+	add(SourceFlag, maps.Clone(s.Flags), true)
+
+	return layers, errs, nil
+}
+
+// readSecretFiles replaces the KEY_FILE entries of values by KEY, set to the
+// content of the file they name without its trailing newline.
+func readSecretFiles(source string, values map[string]string, settings []Setting) []error {
+	var errs []error
+	for _, s := range settings {
+		path, ok := values[s.Key+fileSuffix]
+		if !ok {
+			continue
+		}
+		delete(values, s.Key+fileSuffix)
+		if _, ok := values[s.Key]; ok {
+			errs = append(errs, fmt.Errorf("%s: set either %s or %s%s (%s)", s.Key, s.Key, s.Key, fileSuffix, source))
+			continue
+		}
+		b, err := os.ReadFile(path)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s%s: %w", s.Key, fileSuffix, err))
+			continue
+		}
+		values[s.Key] = strings.TrimRight(string(b), "\r\n")
+	}
+	return errs
+}
+
+// readFile reads a YAML or TOML file into settings by key.
+func readFile(path string) (map[string]string, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read config file: %w", err)
+	}
+
+	tree := make(map[string]any)
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(b, &tree)
+	case ".toml":
+		err = toml.Unmarshal(b, &tree)
+	default:
+		return nil, fmt.Errorf("config file %s: unsupported format %q, use .yaml, .yml or .toml", path, ext)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("config file %s: %w", path, err)
+	}
+
+	values := make(map[string]string)
+	if err := flatten("", tree, values); err != nil {
+		return nil, fmt.Errorf("config file %s: %w", path, err)
+	}
+	return values, nil
+}
+
+// flatten turns nested keys into settings: rate_limit: {read: {burst: 5}}
+// becomes RATE_LIMIT_READ_BURST=5.
+func flatten(prefix string, tree map[string]any, values map[string]string) error {
+	for k, v := range tree {
+		key := strings.ToUpper(strings.ReplaceAll(k, "-", "_"))
+		if prefix != "" {
+			key = prefix + "_" + key
+		}
+		switch v := v.(type) {
+		case map[string]any:
+			if err := flatten(key, v, values); err != nil {
+				return err
+			}
+		case []any:
+			return fmt.Errorf("%s: lists are not supported", key)
+		case nil:
+		default:
+			values[key] = fmt.Sprint(v)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,138 @@
+package config
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ValidationError lists every problem found in a configuration, so that
+// they can all be fixed at once.
+type ValidationError struct {
+	Errors []error
+}
+
+func (e *ValidationError) Error() string {
+	var b strings.Builder
+	b.WriteString("invalid configuration:")
+	for _, err := range e.Errors {
+		b.WriteString("\n  ")
+		b.WriteString(err.Error())
+	}
+	return b.String()
+}
+
+func (e *ValidationError) Unwrap() []error {
+	return e.Errors
+}
+
+// problems collects the errors of a configuration by setting. Settings that
+// could not be parsed are not validated, as their zero value would only
+// add noise.
+type problems struct {
+	errs    []error
+	invalid map[string]bool
+}
+
+func (p *problems) add(key, format string, args ...any) {
+	if p.invalid[key] {
+		return
+	}
+	p.invalid[key] = true
+	p.errs = append(p.errs, fmt.Errorf("%s: %s", key, fmt.Sprintf(format, args...)))
+}
+
+func (p *problems) required(key, v string) {
+	if v == "" {
+		p.add(key, "required")
+	}
+}
+
+func (p *problems) oneOf(key, v string, allowed ...string) {
+	if !slices.Contains(allowed, v) {
+		p.add(key, "must be one of %s, got %q", strings.Join(allowed, ", "), v)
+	}
+}
+
+func (p *problems) atLeast(key string, n, min int) {
+	if n < min {
+		p.add(key, "must be at least %d, got %d", min, n)
+	}
+}
+
+func (p *problems) port(key, v string) {
+	if n, err := strconv.Atoi(v); err != nil || n < 1 || n > 65535 {
+		p.add(key, "must be a port from 1 to 65535, got %q", v)
+	}
+}
+
+func (c *Config) validate(p *problems) {
+	p.oneOf("ENV", c.App.Env, "dev", "stg", "prd")
+	p.required("APP_NAME", c.App.Name)
+	p.atLeast("SHUTDOWN_TIMEOUT_SEC", int(c.App.ShutdownTimeout/time.Second), 1)
+	p.port("HTTP_PORT", c.HTTP.Port)
+	p.atLeast("HTTP_SHUTDOWN_TIMEOUT_SEC", int(c.HTTP.ShutdownTimeout/time.Second), 1)
+	if c.HTTP.ShutdownTimeout >= c.App.ShutdownTimeout {
+		p.add("HTTP_SHUTDOWN_TIMEOUT_SEC", "must be less than SHUTDOWN_TIMEOUT_SEC, which also covers the other components")
+	}
+	p.port("GRPC_PORT", c.GRPC.Port)
+	if c.GRPC.Port == c.HTTP.Port {
+		p.add("GRPC_PORT", "must differ from HTTP_PORT")
+	}
+	p.atLeast("GRPC_SHUTDOWN_TIMEOUT_SEC", int(c.GRPC.ShutdownTimeout/time.Second), 1)
+	if c.GRPC.ShutdownTimeout >= c.App.ShutdownTimeout {
+		p.add("GRPC_SHUTDOWN_TIMEOUT_SEC", "must be less than SHUTDOWN_TIMEOUT_SEC, which also covers the other components")
+	}
+	p.oneOf("LOG_LEVEL", c.Log.Level, "debug", "info", "warn", "error")
+	p.oneOf("LOG_FORMAT", c.Log.Format, "json", "console")
+
+	p.required("PG_URL", c.PG.URL)
+	p.atLeast("PG_MAX_OPEN_CONNS", c.PG.MaxOpenConns, 0)
+	p.atLeast("PG_MAX_IDLE_CONNS", c.PG.MaxIdleConns, 0)
+	p.atLeast("PG_CONN_MAX_LIFETIME_SEC", int(c.PG.ConnMaxLifetime/time.Second), 0)
+	isolation := strings.NewReplacer(" ", "_", "-", "_").Replace(strings.ToLower(c.PG.TxIsolation))
+	if isolation != "" && isolation != "default" {
+		p.oneOf("PG_TX_ISOLATION", isolation, "read_committed", "repeatable_read", "serializable")
+	}
+
+	p.atLeast("IDEMPOTENCY_TTL_SEC", int(c.Idempotency.TTL/time.Second), 1)
+
+	p.oneOf("OUTBOX_PUBLISHER", c.Outbox.Publisher, "stdout", "file", "bus")
+	if c.Outbox.Publisher == "file" {
+		p.required("OUTBOX_FILE", c.Outbox.File)
+	}
+	p.atLeast("OUTBOX_POLL_INTERVAL_MS", int(c.Outbox.PollInterval/time.Millisecond), 1)
+	p.atLeast("OUTBOX_BATCH_SIZE", c.Outbox.BatchSize, 1)
+	p.atLeast("OUTBOX_MAX_BACKOFF_SEC", int(c.Outbox.MaxBackoff/time.Second), 1)
+	p.atLeast("OUTBOX_RETENTION_SEC", int(c.Outbox.Retention/time.Second), 1)
+
+	p.atLeast("AUTH_CLOCK_SKEW_SEC", int(c.Auth.ClockSkew/time.Second), 0)
+	if c.Auth.Enabled && c.Auth.HS256Secret == "" && c.Auth.PublicKeyFile == "" && c.Auth.JWKSFile == "" {
+		p.add("AUTH_ENABLED", "requires AUTH_HS256_SECRET, AUTH_PUBLIC_KEY_FILE or AUTH_JWKS_FILE")
+	}
+
+	p.oneOf("RATE_LIMIT_STORE", c.RateLimit.Store, "memory", "postgres")
+	p.atLeast("RATE_LIMIT_READ_PER_MIN", c.RateLimit.Read.PerMinute, 0)
+	p.atLeast("RATE_LIMIT_READ_BURST", c.RateLimit.Read.Burst, 0)
+	p.atLeast("RATE_LIMIT_WRITE_PER_MIN", c.RateLimit.Write.PerMinute, 0)
+	p.atLeast("RATE_LIMIT_WRITE_BURST", c.RateLimit.Write.Burst, 0)
+	p.atLeast("RATE_LIMIT_IP_PER_MIN", c.RateLimit.IP.PerMinute, 0)
+	p.atLeast("RATE_LIMIT_IP_BURST", c.RateLimit.IP.Burst, 0)
+
+	if c.Metrics.Port != "" {
+		p.port("METRICS_PORT", c.Metrics.Port)
+		if c.Metrics.Port == c.HTTP.Port {
+			p.add("METRICS_PORT", "must differ from HTTP_PORT, leave it empty to serve /metrics on HTTP_PORT")
+		}
+	}
+
+	p.oneOf("TRACING_EXPORTER", c.Tracing.Exporter, "none", "stdout", "otlp")
+	if c.Tracing.Exporter == "otlp" {
+		p.required("TRACING_OTLP_ENDPOINT", c.Tracing.OTLPEndpoint)
+	}
+	if r := c.Tracing.SampleRatio; r < 0 || r > 1 {
+		p.add("TRACING_SAMPLE_RATIO", "must be from 0 to 1, got %g", r)
+	}
+}
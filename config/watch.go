@@ -0,0 +1,193 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// debounce groups the events of an editor or a deployment tool writing a
+// file in several steps into one reload.
+const debounce = 200 * time.Millisecond
+
+// Watcher holds the current configuration and reloads it from its sources.
+// Only Reloadable settings change on reload; the others keep their value
+// until the next start.
+type Watcher struct {
+	mu   sync.RWMutex
+	cfg  *Config
+	subs []subscriber
+	next int
+
+	// reload serializes reloads, so subscribers see them in order.
+	reload sync.Mutex
+}
+
+type subscriber struct {
+	id int
+	fn func(old, cur *Config)
+}
+
+// Reloaded reports the settings that changed in the sources of a reload.
+type Reloaded struct {
+	// Applied settings were changed.
+	Applied []string
+	// Restart lists the settings that only change on the next start.
+	Restart []string
+}
+
+// NewWatcher returns a Watcher of cfg, which must come from Load.
+func NewWatcher(cfg *Config) *Watcher {
+	return &Watcher{cfg: cfg}
+}
+
+// Config returns the current configuration. It must not be modified.
+func (w *Watcher) Config() *Config {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.cfg
+}
+
+// Reload loads the configuration again and applies its reloadable settings.
+// When the sources are invalid, the current configuration is kept and the
+// error is returned. Subscribers are notified before Reload returns.
+func (w *Watcher) Reload() (Reloaded, error) {
+	w.reload.Lock()
+	defer w.reload.Unlock()
+
+	old := w.Config()
+	loaded, err := Load(old.sources)
+	if err != nil {
+		return Reloaded{}, err
+	}
+
+	var r Reloaded
+	settings := Settings()
+	values := slices.Clone(old.values)
+	for i, s := range settings {
+		if loaded.values[i].Value == old.values[i].Value {
+			continue
+		}
+		if !s.Reloadable {
+			r.Restart = append(r.Restart, s.Key)
+			continue
+		}
+		values[i] = loaded.values[i]
+		r.Applied = append(r.Applied, s.Key)
+	}
+	if len(r.Applied) == 0 {
+		return r, nil
+	}
+
+	cfg, err := build(old.sources, values, nil)
+	if err != nil {
+		return Reloaded{}, err
+	}
+
+	w.mu.Lock()
+	w.cfg = cfg
+	subs := slices.Clone(w.subs)
+	w.mu.Unlock()
+
+	for _, s := range subs {
+		s.fn(old, cfg)
+	}
+	return r, nil
+}
+
+// WatchFiles reloads the configuration whenever its file or .env file
+// changes, until ctx is done, and reports every reload to onReload. It
+// watches their directories, so that files replaced rather than written,
+// such as mounted Kubernetes ConfigMaps, are seen too.
+func (w *Watcher) WatchFiles(ctx context.Context, onReload func(Reloaded, error)) error {
+	src := w.Config().sources
+	files := make(map[string]bool)
+	dirs := make(map[string]bool)
+	for _, f := range []string{src.File, src.EnvFile} {
+		if f == "" {
+			continue
+		}
+		abs, err := filepath.Abs(f)
+		if err != nil {
+			return fmt.Errorf("config - WatchFiles: %w", err)
+		}
+		files[abs] = true
+		dirs[filepath.Dir(abs)] = true
+	}
+	if len(files) == 0 {
+		return nil
+	}
+
+	fw, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("config - WatchFiles: %w", err)
+	}
+	for dir := range dirs {
+		if err := fw.Add(dir); err != nil {
+			fw.Close()
+			return fmt.Errorf("config - WatchFiles: %w", err)
+		}
+	}
+
+	go func() {
+		defer fw.Close()
+		var fire <-chan time.Time
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case ev, ok := <-fw.Events:
+				if !ok {
+					return
+				}
+				// ConfigMaps swap the ..data symlink their files point to.
+				if files[filepath.Clean(ev.Name)] || filepath.Base(ev.Name) == "..data" {
+					fire = time.After(debounce)
+				}
+			case err, ok := <-fw.Errors:
+				if !ok {
+					return
+				}
+				onReload(Reloaded{}, err)
+			case <-fire:
+				fire = nil
+				onReload(w.Reload())
+			}
+		}
+	}()
+	return nil
+}
+
+func (w *Watcher) subscribe(fn func(old, cur *Config)) (cancel func()) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.next++
+	id := w.next
+	w.subs = append(w.subs, subscriber{id: id, fn: fn})
+	return func() {
+		w.mu.Lock()
+		defer w.mu.Unlock()
+		w.subs = slices.DeleteFunc(w.subs, func(s subscriber) bool { return s.id == id })
+	}
+}
+
+// Watch calls fn with the value get reads from the configuration whenever a
+// reload changes it, until cancel is called. fn is not called with the
+// current value, which get(w.Config()) returns.
+//
+//	config.Watch(w, func(c *config.Config) string { return c.Log.Level }, func(level string) {
+//		l.Level().Set(level)
+//	})
+func Watch[T any](w *Watcher, get func(*Config) T, fn func(T)) (cancel func()) {
+	return w.subscribe(func(old, cur *Config) {
+		if v := get(cur); !reflect.DeepEqual(get(old), v) {
+			fn(v)
+		}
+	})
+}
@@ -0,0 +1,110 @@
+package config
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const watchedConfig = `
+pg_url: postgres://localhost/app
+auth_enabled: false
+`
+
+func newTestWatcher(t *testing.T) (*Watcher, string) {
+	t.Helper()
+	clearEnv(t)
+	file := writeFile(t, "config.yaml", watchedConfig)
+	cfg, err := Load(Sources{File: file})
+	require.NoError(t, err)
+	return NewWatcher(cfg), file
+}
+
+func rewrite(t *testing.T, path, content string) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+}
+
+func TestWatcher_Reload(t *testing.T) {
+	w, file := newTestWatcher(t)
+	var levels []string
+	Watch(w, func(c *Config) string { return c.Log.Level }, func(level string) { levels = append(levels, level) })
+	var origins [][]string
+	Watch(w, func(c *Config) []string { return c.HTTP.CORSOrigins }, func(o []string) { origins = append(origins, o) })
+
+	rewrite(t, file, watchedConfig+`
+log_level: debug
+http_port: 9999
+cors_allow_origins: https://a.example.com, https://b.example.com
+`)
+	r, err := w.Reload()
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"CORS_ALLOW_ORIGINS", "LOG_LEVEL"}, r.Applied)
+	assert.Equal(t, []string{"HTTP_PORT"}, r.Restart)
+	assert.Equal(t, []string{"debug"}, levels)
+	assert.Equal(t, [][]string{{"https://a.example.com", "https://b.example.com"}}, origins)
+	cfg := w.Config()
+	assert.Equal(t, "debug", cfg.Log.Level)
+	assert.Equal(t, "8080", cfg.HTTP.Port)
+	assert.Equal(t, SourceFile, value(t, cfg, "LOG_LEVEL").Source)
+}
+
+func TestWatcher_Reload_Unchanged(t *testing.T) {
+	w, _ := newTestWatcher(t)
+	calls := 0
+	Watch(w, func(c *Config) Features { return c.Features }, func(Features) { calls++ })
+
+	r, err := w.Reload()
+
+	require.NoError(t, err)
+	assert.Empty(t, r.Applied)
+	assert.Zero(t, calls)
+}
+
+func TestWatcher_Reload_Invalid(t *testing.T) {
+	w, file := newTestWatcher(t)
+	before := w.Config()
+
+	rewrite(t, file, watchedConfig+"log_level: verbose\n")
+	_, err := w.Reload()
+
+	var verr *ValidationError
+	require.ErrorAs(t, err, &verr)
+	assert.Same(t, before, w.Config())
+}
+
+func TestWatch_Cancel(t *testing.T) {
+	w, file := newTestWatcher(t)
+	calls := 0
+	cancel := Watch(w, func(c *Config) string { return c.Log.Level }, func(string) { calls++ })
+	cancel()
+
+	rewrite(t, file, watchedConfig+"log_level: warn\n")
+	_, err := w.Reload()
+
+	require.NoError(t, err)
+	assert.Zero(t, calls)
+}
+
+func TestWatcher_WatchFiles(t *testing.T) {
+	w, file := newTestWatcher(t)
+	reloads := make(chan Reloaded, 1)
+	require.NoError(t, w.WatchFiles(t.Context(), func(r Reloaded, err error) {
+		assert.NoError(t, err)
+		reloads <- r
+	}))
+
+	rewrite(t, file, watchedConfig+"features: new-signup, dark-mode\n")
+
+	select {
+	case r := <-reloads:
+		assert.Equal(t, []string{"FEATURES"}, r.Applied)
+		assert.True(t, w.Config().Features.Enabled("dark-mode"))
+	case <-time.After(5 * time.Second):
+		t.Fatal("configuration not reloaded")
+	}
+}
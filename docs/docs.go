@@ -15,9 +15,9 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/v0/user": {
-            "post": {
-                "description": "add new user",
+        "/v0/admin/log-level": {
+            "get": {
+                "description": "Shows the current log level, the configured one, and when a temporary level reverts to it.",
                 "consumes": [
                     "application/json"
                 ],
@@ -25,46 +25,130 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "admin"
                 ],
-                "summary": "Create User",
-                "operationId": "create",
+                "summary": "Get Log Level",
+                "operationId": "getLogLevel",
+                "responses": {
+                    "200": {
+                        "description": "Returns the log level",
+                        "schema": {
+                            "$ref": "#/definitions/output.LogLevelOutput"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid bearer token",
+                        "schema": {
+                            "$ref": "#/definitions/output.ResponseError"
+                        }
+                    },
+                    "403": {
+                        "description": "Not allowed for the caller's roles",
+                        "schema": {
+                            "$ref": "#/definitions/output.ResponseError"
+                        }
+                    },
+                    "429": {
+                        "description": "Rate limit exceeded, retry after Retry-After seconds",
+                        "schema": {
+                            "$ref": "#/definitions/output.ResponseError"
+                        },
+                        "headers": {
+                            "Retry-After": {
+                                "type": "integer",
+                                "description": "Seconds until the request may be retried"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/output.ResponseError"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "put": {
+                "description": "Changes the log level of this instance for ttl_seconds, at most a day, after which the configured\nlevel is restored. A new call replaces the previous temporary level.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Set Log Level",
+                "operationId": "setLogLevel",
                 "parameters": [
                     {
-                        "description": "Set up users",
-                        "name": "user",
+                        "description": "Level and how long it lasts",
+                        "name": "level",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/input.UserInput"
+                            "$ref": "#/definitions/input.LogLevelInput"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "Returns the new log level",
                         "schema": {
-                            "$ref": "#/definitions/output.UserOutput"
+                            "$ref": "#/definitions/output.LogLevelOutput"
                         }
                     },
                     "400": {
-                        "description": "Bad Request",
+                        "description": "Invalid level or ttl",
+                        "schema": {
+                            "$ref": "#/definitions/output.ResponseError"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid bearer token",
+                        "schema": {
+                            "$ref": "#/definitions/output.ResponseError"
+                        }
+                    },
+                    "403": {
+                        "description": "Not allowed for the caller's roles",
+                        "schema": {
+                            "$ref": "#/definitions/output.ResponseError"
+                        }
+                    },
+                    "429": {
+                        "description": "Rate limit exceeded, retry after Retry-After seconds",
                         "schema": {
                             "$ref": "#/definitions/output.ResponseError"
+                        },
+                        "headers": {
+                            "Retry-After": {
+                                "type": "integer",
+                                "description": "Seconds until the request may be retried"
+                            }
                         }
                     },
                     "500": {
-                        "description": "Internal Server Error",
+                        "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/output.ResponseError"
                         }
                     }
-                }
-            }
-        },
-        "/v0/user/{id}": {
-            "get": {
-                "description": "Search for a user by its UUID.",
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "delete": {
+                "description": "Ends a temporary log level and restores the configured one at once.",
                 "consumes": [
                     "application/json"
                 ],
@@ -72,30 +156,101 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "admin"
                 ],
-                "summary": "Get User",
-                "operationId": "getUser",
-                "parameters": [
+                "summary": "Reset Log Level",
+                "operationId": "resetLogLevel",
+                "responses": {
+                    "200": {
+                        "description": "Returns the restored log level",
+                        "schema": {
+                            "$ref": "#/definitions/output.LogLevelOutput"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid bearer token",
+                        "schema": {
+                            "$ref": "#/definitions/output.ResponseError"
+                        }
+                    },
+                    "403": {
+                        "description": "Not allowed for the caller's roles",
+                        "schema": {
+                            "$ref": "#/definitions/output.ResponseError"
+                        }
+                    },
+                    "429": {
+                        "description": "Rate limit exceeded, retry after Retry-After seconds",
+                        "schema": {
+                            "$ref": "#/definitions/output.ResponseError"
+                        },
+                        "headers": {
+                            "Retry-After": {
+                                "type": "integer",
+                                "description": "Seconds until the request may be retried"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/output.ResponseError"
+                        }
+                    }
+                },
+                "security": [
                     {
-                        "type": "string",
-                        "description": "User ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "BearerAuth": []
                     }
+                ]
+            }
+        },
+        "/v0/api-key": {
+            "get": {
+                "description": "Lists every API key, revoked and expired ones included. Keys themselves are never shown.",
+                "consumes": [
+                    "application/json"
                 ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "api-keys"
+                ],
+                "summary": "List API Keys",
+                "operationId": "listAPIKeys",
                 "responses": {
                     "200": {
-                        "description": "Returns the found user",
+                        "description": "Returns the API keys",
                         "schema": {
-                            "$ref": "#/definitions/output.UserOutput"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/output.APIKeyOutput"
+                            }
                         }
                     },
-                    "400": {
-                        "description": "Invalid UUID format",
+                    "401": {
+                        "description": "Missing or invalid bearer token",
+                        "schema": {
+                            "$ref": "#/definitions/output.ResponseError"
+                        }
+                    },
+                    "403": {
+                        "description": "Not allowed for the caller's roles",
+                        "schema": {
+                            "$ref": "#/definitions/output.ResponseError"
+                        }
+                    },
+                    "429": {
+                        "description": "Rate limit exceeded, retry after Retry-After seconds",
                         "schema": {
                             "$ref": "#/definitions/output.ResponseError"
+                        },
+                        "headers": {
+                            "Retry-After": {
+                                "type": "integer",
+                                "description": "Seconds until the request may be retried"
+                            }
                         }
                     },
                     "500": {
@@ -104,10 +259,15 @@ const docTemplate = `{
                             "$ref": "#/definitions/output.ResponseError"
                         }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
             },
-            "put": {
-                "description": "update existing user details",
+            "post": {
+                "description": "Issues an API key for a machine client, sent back in the X-API-Key header. The key is only\nreturned by this call: only a hash of it is stored. Scopes: users:read, users:write, users:delete.",
                 "consumes": [
                     "application/json"
                 ],
@@ -115,54 +275,75 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "api-keys"
                 ],
-                "summary": "Update User",
-                "operationId": "updateUser",
+                "summary": "Create API Key",
+                "operationId": "createAPIKey",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "User ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Update user details",
-                        "name": "request",
+                        "description": "Name, scopes and optional expiry",
+                        "name": "key",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/input.UserInput"
+                            "$ref": "#/definitions/input.APIKeyInput"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "User Successfully updated"
+                        "description": "Returns the key, shown this one time",
+                        "schema": {
+                            "$ref": "#/definitions/output.CreatedAPIKeyOutput"
+                        }
                     },
                     "400": {
-                        "description": "Bad Request",
+                        "description": "Invalid name, scopes or expiry",
                         "schema": {
                             "$ref": "#/definitions/output.ResponseError"
                         }
                     },
-                    "404": {
-                        "description": "User not found",
+                    "401": {
+                        "description": "Missing or invalid bearer token",
+                        "schema": {
+                            "$ref": "#/definitions/output.ResponseError"
+                        }
+                    },
+                    "403": {
+                        "description": "Not allowed for the caller's roles",
+                        "schema": {
+                            "$ref": "#/definitions/output.ResponseError"
+                        }
+                    },
+                    "429": {
+                        "description": "Rate limit exceeded, retry after Retry-After seconds",
                         "schema": {
                             "$ref": "#/definitions/output.ResponseError"
+                        },
+                        "headers": {
+                            "Retry-After": {
+                                "type": "integer",
+                                "description": "Seconds until the request may be retried"
+                            }
                         }
                     },
                     "500": {
-                        "description": "Internal Server Error",
+                        "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/output.ResponseError"
                         }
                     }
-                }
-            },
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/v0/api-key/{id}": {
             "delete": {
-                "description": "Deletes a user by its UUID.",
+                "description": "Revokes an API key: requests presenting it are rejected from now on.",
                 "consumes": [
                     "application/json"
                 ],
@@ -170,14 +351,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "api-keys"
                 ],
-                "summary": "Delete User",
-                "operationId": "deleteUser",
+                "summary": "Revoke API Key",
+                "operationId": "revokeAPIKey",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "User ID",
+                        "description": "API key ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -185,7 +366,7 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "User successfully deleted"
+                        "description": "API key successfully revoked"
                     },
                     "400": {
                         "description": "Invalid UUID format",
@@ -193,56 +374,1477 @@ const docTemplate = `{
                             "$ref": "#/definitions/output.ResponseError"
                         }
                     },
+                    "401": {
+                        "description": "Missing or invalid bearer token",
+                        "schema": {
+                            "$ref": "#/definitions/output.ResponseError"
+                        }
+                    },
+                    "403": {
+                        "description": "Not allowed for the caller's roles",
+                        "schema": {
+                            "$ref": "#/definitions/output.ResponseError"
+                        }
+                    },
+                    "404": {
+                        "description": "API key not found or already revoked",
+                        "schema": {
+                            "$ref": "#/definitions/output.ResponseError"
+                        }
+                    },
+                    "429": {
+                        "description": "Rate limit exceeded, retry after Retry-After seconds",
+                        "schema": {
+                            "$ref": "#/definitions/output.ResponseError"
+                        },
+                        "headers": {
+                            "Retry-After": {
+                                "type": "integer",
+                                "description": "Seconds until the request may be retried"
+                            }
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/output.ResponseError"
                         }
                     }
-                }
-            }
-        }
-    },
-    "definitions": {
-        "input.UserInput": {
-            "type": "object",
-            "required": [
-                "name",
-                "phone"
-            ],
-            "properties": {
-                "name": {
-                    "type": "string",
-                    "example": "user name"
                 },
-                "phone": {
-                    "type": "string",
-                    "example": "+5511999999999"
-                }
-            }
-        },
-        "output.ResponseError": {
-            "type": "object",
-            "properties": {
-                "error": {
-                    "type": "string",
-                    "example": "message"
-                }
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
             }
         },
-        "output.UserOutput": {
-            "type": "object",
-            "properties": {
-                "id": {
-                    "type": "string"
-                },
-                "name": {
-                    "type": "string"
-                },
-                "phone": {
-                    "type": "string"
-                }
-            }
+        "/v0/user": {
+            "get": {
+                "description": "List users, by default ordered by creation date. Page with limit/offset or with the opaque\nnext_cursor returned by the previous page; the Link header carries the next page URL.\nFilter clauses are separated by ';' and use ==, !=, >, >=, <, <= or =like= (with * as wildcard)\non name, phone, created_at and updated_at. Sort on name, created_at or updated_at, '-' for descending.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "List Users",
+                "operationId": "listUsers",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page size (default 20, max 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of users to skip, cannot be combined with cursor",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Opaque cursor from meta.next_cursor",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Include the total number of users",
+                        "name": "total",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter expression, e.g. name=like=ana*;created_at>2026-01-01",
+                        "name": "filter",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort fields, e.g. -created_at,name",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Returns a page of users",
+                        "schema": {
+                            "$ref": "#/definitions/output.UserListOutput"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid pagination, filter or sort parameters",
+                        "schema": {
+                            "$ref": "#/definitions/output.ResponseError"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid bearer token or API key",
+                        "schema": {
+                            "$ref": "#/definitions/output.ResponseError"
+                        }
+                    },
+                    "403": {
+                        "description": "Not allowed for the caller's roles",
+                        "schema": {
+                            "$ref": "#/definitions/output.ResponseError"
+                        }
+                    },
+                    "429": {
+                        "description": "Rate limit exceeded, retry after Retry-After seconds",
+                        "schema": {
+                            "$ref": "#/definitions/output.ResponseError"
+                        },
+                        "headers": {
+                            "Retry-After": {
+                                "type": "integer",
+                                "description": "Seconds until the request may be retried"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/output.ResponseError"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ]
+            },
+            "post": {
+                "description": "add new user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Create User",
+                "operationId": "create",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Client generated key making retries safe",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Set up users",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/input.UserInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/output.UserOutput"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Entity tag of the created user"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/output.ResponseError"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid bearer token or API key",
+                        "schema": {
+                            "$ref": "#/definitions/output.ResponseError"
+                        }
+                    },
+                    "403": {
+                        "description": "Not allowed for the caller's roles",
+                        "schema": {
+                            "$ref": "#/definitions/output.ResponseError"
+                        }
+                    },
+                    "409": {
+                        "description": "User already exists or a request with the same Idempotency-Key is in progress",
+                        "schema": {
+                            "$ref": "#/definitions/output.ResponseError"
+                        }
+                    },
+                    "422": {
+                        "description": "Idempotency-Key reused with a different request",
+                        "schema": {
+                            "$ref": "#/definitions/output.ResponseError"
+                        }
+                    },
+                    "429": {
+                        "description": "Rate limit exceeded, retry after Retry-After seconds",
+                        "schema": {
+                            "$ref": "#/definitions/output.ResponseError"
+                        },
+                        "headers": {
+                            "Retry-After": {
+                                "type": "integer",
+                                "description": "Seconds until the request may be retried"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/output.ResponseError"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ]
+            }
+        },
+        "/v0/user/deleted": {
+            "get": {
+                "description": "List soft-deleted users, with the same paging, filter and sort parameters as List Users.\ndeleted_at may also be used to filter and sort.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "List Deleted Users",
+                "operationId": "listDeletedUsers",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page size (default 20, max 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of users to skip, cannot be combined with cursor",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Opaque cursor from meta.next_cursor",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Include the total number of deleted users",
+                        "name": "total",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter expression, e.g. deleted_at<2026-01-01",
+                        "name": "filter",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort fields, e.g. -deleted_at",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Returns a page of deleted users",
+                        "schema": {
+                            "$ref": "#/definitions/output.DeletedUserListOutput"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid pagination, filter or sort parameters",
+                        "schema": {
+                            "$ref": "#/definitions/output.ResponseError"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid bearer token or API key",
+                        "schema": {
+                            "$ref": "#/definitions/output.ResponseError"
+                        }
+                    },
+                    "403": {
+                        "description": "Not allowed for the caller's roles",
+                        "schema": {
+                            "$ref": "#/definitions/output.ResponseError"
+                        }
+                    },
+                    "429": {
+                        "description": "Rate limit exceeded, retry after Retry-After seconds",
+                        "schema": {
+                            "$ref": "#/definitions/output.ResponseError"
+                        },
+                        "headers": {
+                            "Retry-After": {
+                                "type": "integer",
+                                "description": "Seconds until the request may be retried"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/output.ResponseError"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ]
+            },
+            "delete": {
+                "description": "Permanently erases all users soft-deleted before the given time, and the values recorded in their history and events.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Purge Deleted Users",
+                "operationId": "purgeDeletedUsers",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "RFC 3339 timestamp, e.g. 2026-01-01T00:00:00Z",
+                        "name": "before",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Returns the number of purged users",
+                        "schema": {
+                            "$ref": "#/definitions/output.PurgeOutput"
+                        }
+                    },
+                    "400": {
+                        "description": "Missing or invalid before parameter",
+                        "schema": {
+                            "$ref": "#/definitions/output.ResponseError"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid bearer token or API key",
+                        "schema": {
+                            "$ref": "#/definitions/output.ResponseError"
+                        }
+                    },
+                    "403": {
+                        "description": "Not allowed for the caller's roles",
+                        "schema": {
+                            "$ref": "#/definitions/output.ResponseError"
+                        }
+                    },
+                    "429": {
+                        "description": "Rate limit exceeded, retry after Retry-After seconds",
+                        "schema": {
+                            "$ref": "#/definitions/output.ResponseError"
+                        },
+                        "headers": {
+                            "Retry-After": {
+                                "type": "integer",
+                                "description": "Seconds until the request may be retried"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/output.ResponseError"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ]
+            }
+        },
+        "/v0/user/deleted/{id}": {
+            "delete": {
+                "description": "Permanently erases a soft-deleted user, and the values recorded in its history and events. Users that are not deleted cannot be purged.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Purge User",
+                "operationId": "purgeUser",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "User successfully purged"
+                    },
+                    "400": {
+                        "description": "Invalid UUID format",
+                        "schema": {
+                            "$ref": "#/definitions/output.ResponseError"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid bearer token or API key",
+                        "schema": {
+                            "$ref": "#/definitions/output.ResponseError"
+                        }
+                    },
+                    "403": {
+                        "description": "Not allowed for the caller's roles",
+                        "schema": {
+                            "$ref": "#/definitions/output.ResponseError"
+                        }
+                    },
+                    "404": {
+                        "description": "Deleted user not found",
+                        "schema": {
+                            "$ref": "#/definitions/output.ResponseError"
+                        }
+                    },
+                    "429": {
+                        "description": "Rate limit exceeded, retry after Retry-After seconds",
+                        "schema": {
+                            "$ref": "#/definitions/output.ResponseError"
+                        },
+                        "headers": {
+                            "Retry-After": {
+                                "type": "integer",
+                                "description": "Seconds until the request may be retried"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/output.ResponseError"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ]
+            }
+        },
+        "/v0/user/{id}": {
+            "get": {
+                "description": "Search for a user by its UUID.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Get User",
+                "operationId": "getUser",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Returns the found user",
+                        "schema": {
+                            "$ref": "#/definitions/output.UserOutput"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Entity tag to send as If-Match on PUT, PATCH and DELETE"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid UUID format",
+                        "schema": {
+                            "$ref": "#/definitions/output.ResponseError"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid bearer token or API key",
+                        "schema": {
+                            "$ref": "#/definitions/output.ResponseError"
+                        }
+                    },
+                    "403": {
+                        "description": "Not allowed for the caller's roles",
+                        "schema": {
+                            "$ref": "#/definitions/output.ResponseError"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/output.ResponseError"
+                        }
+                    },
+                    "429": {
+                        "description": "Rate limit exceeded, retry after Retry-After seconds",
+                        "schema": {
+                            "$ref": "#/definitions/output.ResponseError"
+                        },
+                        "headers": {
+                            "Retry-After": {
+                                "type": "integer",
+                                "description": "Seconds until the request may be retried"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/output.ResponseError"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ]
+            },
+            "put": {
+                "description": "update existing user details",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Update User",
+                "operationId": "updateUser",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the user as last read, or *",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Update user details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/input.UserInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "User Successfully updated"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/output.ResponseError"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid bearer token or API key",
+                        "schema": {
+                            "$ref": "#/definitions/output.ResponseError"
+                        }
+                    },
+                    "403": {
+                        "description": "Not allowed for the caller's roles",
+                        "schema": {
+                            "$ref": "#/definitions/output.ResponseError"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/output.ResponseError"
+                        }
+                    },
+                    "412": {
+                        "description": "User has been modified since it was read",
+                        "schema": {
+                            "$ref": "#/definitions/output.ResponseError"
+                        }
+                    },
+                    "428": {
+                        "description": "Missing If-Match header",
+                        "schema": {
+                            "$ref": "#/definitions/output.ResponseError"
+                        }
+                    },
+                    "429": {
+                        "description": "Rate limit exceeded, retry after Retry-After seconds",
+                        "schema": {
+                            "$ref": "#/definitions/output.ResponseError"
+                        },
+                        "headers": {
+                            "Retry-After": {
+                                "type": "integer",
+                                "description": "Seconds until the request may be retried"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/output.ResponseError"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ]
+            },
+            "delete": {
+                "description": "Deletes a user by its UUID.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Delete User",
+                "operationId": "deleteUser",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the user as last read, or *",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "User successfully deleted"
+                    },
+                    "400": {
+                        "description": "Invalid UUID format",
+                        "schema": {
+                            "$ref": "#/definitions/output.ResponseError"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid bearer token or API key",
+                        "schema": {
+                            "$ref": "#/definitions/output.ResponseError"
+                        }
+                    },
+                    "403": {
+                        "description": "Not allowed for the caller's roles",
+                        "schema": {
+                            "$ref": "#/definitions/output.ResponseError"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/output.ResponseError"
+                        }
+                    },
+                    "412": {
+                        "description": "User has been modified since it was read",
+                        "schema": {
+                            "$ref": "#/definitions/output.ResponseError"
+                        }
+                    },
+                    "428": {
+                        "description": "Missing If-Match header",
+                        "schema": {
+                            "$ref": "#/definitions/output.ResponseError"
+                        }
+                    },
+                    "429": {
+                        "description": "Rate limit exceeded, retry after Retry-After seconds",
+                        "schema": {
+                            "$ref": "#/definitions/output.ResponseError"
+                        },
+                        "headers": {
+                            "Retry-After": {
+                                "type": "integer",
+                                "description": "Seconds until the request may be retried"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/output.ResponseError"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ]
+            },
+            "patch": {
+                "description": "Partially update a user with a JSON Merge Patch (RFC 7396, application/merge-patch+json)\nor a JSON Patch (RFC 6902, application/json-patch+json) applied to {\"name\", \"phone\"}.",
+                "consumes": [
+                    "application/merge-patch+json",
+                    "application/json-patch+json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Patch User",
+                "operationId": "patchUser",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the user as last read, or *",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Client generated key making retries safe",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Patch document",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Returns the updated user",
+                        "schema": {
+                            "$ref": "#/definitions/output.UserOutput"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Entity tag of the updated user"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid patch document or resulting user",
+                        "schema": {
+                            "$ref": "#/definitions/output.ResponseError"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid bearer token or API key",
+                        "schema": {
+                            "$ref": "#/definitions/output.ResponseError"
+                        }
+                    },
+                    "403": {
+                        "description": "Not allowed for the caller's roles",
+                        "schema": {
+                            "$ref": "#/definitions/output.ResponseError"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/output.ResponseError"
+                        }
+                    },
+                    "409": {
+                        "description": "JSON Patch test operation failed",
+                        "schema": {
+                            "$ref": "#/definitions/output.ResponseError"
+                        }
+                    },
+                    "412": {
+                        "description": "User has been modified since it was read",
+                        "schema": {
+                            "$ref": "#/definitions/output.ResponseError"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported patch media type",
+                        "schema": {
+                            "$ref": "#/definitions/output.ResponseError"
+                        }
+                    },
+                    "422": {
+                        "description": "Patch cannot be applied to the user",
+                        "schema": {
+                            "$ref": "#/definitions/output.ResponseError"
+                        }
+                    },
+                    "428": {
+                        "description": "Missing If-Match header",
+                        "schema": {
+                            "$ref": "#/definitions/output.ResponseError"
+                        }
+                    },
+                    "429": {
+                        "description": "Rate limit exceeded, retry after Retry-After seconds",
+                        "schema": {
+                            "$ref": "#/definitions/output.ResponseError"
+                        },
+                        "headers": {
+                            "Retry-After": {
+                                "type": "integer",
+                                "description": "Seconds until the request may be retried"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/output.ResponseError"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ]
+            }
+        },
+        "/v0/user/{id}/history": {
+            "get": {
+                "description": "Audit trail of a user, most recent first: who created, changed, deleted or restored it, in which\nrequest, and the old and new value of each changed field. After a purge, only the values are erased.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "User History",
+                "operationId": "userHistory",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default 20, max 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of events to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Include the total number of events",
+                        "name": "total",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Returns a page of audit events",
+                        "schema": {
+                            "$ref": "#/definitions/output.UserHistoryOutput"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid UUID format or pagination parameters",
+                        "schema": {
+                            "$ref": "#/definitions/output.ResponseError"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid bearer token or API key",
+                        "schema": {
+                            "$ref": "#/definitions/output.ResponseError"
+                        }
+                    },
+                    "403": {
+                        "description": "Not allowed for the caller's roles",
+                        "schema": {
+                            "$ref": "#/definitions/output.ResponseError"
+                        }
+                    },
+                    "429": {
+                        "description": "Rate limit exceeded, retry after Retry-After seconds",
+                        "schema": {
+                            "$ref": "#/definitions/output.ResponseError"
+                        },
+                        "headers": {
+                            "Retry-After": {
+                                "type": "integer",
+                                "description": "Seconds until the request may be retried"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/output.ResponseError"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ]
+            }
+        },
+        "/v0/user/{id}/restore": {
+            "post": {
+                "description": "Undeletes a soft-deleted user.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Restore User",
+                "operationId": "restoreUser",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Returns the restored user",
+                        "schema": {
+                            "$ref": "#/definitions/output.UserOutput"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Entity tag to send as If-Match on PUT, PATCH and DELETE"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid UUID format",
+                        "schema": {
+                            "$ref": "#/definitions/output.ResponseError"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid bearer token or API key",
+                        "schema": {
+                            "$ref": "#/definitions/output.ResponseError"
+                        }
+                    },
+                    "403": {
+                        "description": "Not allowed for the caller's roles",
+                        "schema": {
+                            "$ref": "#/definitions/output.ResponseError"
+                        }
+                    },
+                    "404": {
+                        "description": "Deleted user not found",
+                        "schema": {
+                            "$ref": "#/definitions/output.ResponseError"
+                        }
+                    },
+                    "429": {
+                        "description": "Rate limit exceeded, retry after Retry-After seconds",
+                        "schema": {
+                            "$ref": "#/definitions/output.ResponseError"
+                        },
+                        "headers": {
+                            "Retry-After": {
+                                "type": "integer",
+                                "description": "Seconds until the request may be retried"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/output.ResponseError"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ]
+            }
+        }
+    },
+    "definitions": {
+        "input.APIKeyInput": {
+            "type": "object",
+            "required": [
+                "name",
+                "scopes"
+            ],
+            "properties": {
+                "expires_at": {
+                    "type": "string",
+                    "example": "2027-01-01T00:00:00Z"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "acme integration"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "users:read",
+                        "users:write"
+                    ]
+                }
+            }
+        },
+        "input.LogLevelInput": {
+            "type": "object",
+            "required": [
+                "level",
+                "ttl_seconds"
+            ],
+            "properties": {
+                "level": {
+                    "type": "string",
+                    "enum": [
+                        "debug",
+                        "info",
+                        "warn",
+                        "error"
+                    ],
+                    "example": "debug"
+                },
+                "ttl_seconds": {
+                    "type": "integer",
+                    "maximum": 86400,
+                    "minimum": 1,
+                    "example": 600
+                }
+            }
+        },
+        "input.UserInput": {
+            "type": "object",
+            "required": [
+                "name",
+                "phone"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "example": "user name"
+                },
+                "phone": {
+                    "type": "string",
+                    "example": "+5511999999999"
+                }
+            }
+        },
+        "output.APIKeyOutput": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string",
+                    "example": "admin@example.com"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "last_used_at": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "acme integration"
+                },
+                "prefix": {
+                    "type": "string",
+                    "example": "ak_3f9a0c12d4e5"
+                },
+                "revoked_at": {
+                    "type": "string"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "users:read"
+                    ]
+                }
+            }
+        },
+        "output.AuditEventOutput": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string",
+                    "example": "update"
+                },
+                "actor": {
+                    "type": "string",
+                    "example": "anonymous"
+                },
+                "changes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/output.FieldChangeOutput"
+                    }
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "request_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "output.CreatedAPIKeyOutput": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string",
+                    "example": "admin@example.com"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "key": {
+                    "type": "string",
+                    "example": "ak_3f9a0c12d4e5.q0V3c1dW..."
+                },
+                "last_used_at": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "acme integration"
+                },
+                "prefix": {
+                    "type": "string",
+                    "example": "ak_3f9a0c12d4e5"
+                },
+                "revoked_at": {
+                    "type": "string"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "users:read"
+                    ]
+                }
+            }
+        },
+        "output.DeletedUserListOutput": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/output.DeletedUserOutput"
+                    }
+                },
+                "meta": {
+                    "$ref": "#/definitions/output.PageMeta"
+                }
+            }
+        },
+        "output.DeletedUserOutput": {
+            "type": "object",
+            "properties": {
+                "deleted_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "phone": {
+                    "type": "string"
+                }
+            }
+        },
+        "output.FieldChangeOutput": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "type": "string",
+                    "example": "name"
+                },
+                "new": {},
+                "old": {}
+            }
+        },
+        "output.LogLevelOutput": {
+            "type": "object",
+            "properties": {
+                "configured": {
+                    "type": "string",
+                    "example": "info"
+                },
+                "level": {
+                    "type": "string",
+                    "example": "debug"
+                },
+                "revert_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "output.PageMeta": {
+            "type": "object",
+            "properties": {
+                "has_more": {
+                    "type": "boolean"
+                },
+                "limit": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "next_cursor": {
+                    "type": "string"
+                },
+                "offset": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 120
+                }
+            }
+        },
+        "output.PurgeOutput": {
+            "type": "object",
+            "properties": {
+                "purged": {
+                    "type": "integer",
+                    "example": 12
+                }
+            }
+        },
+        "output.ResponseError": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "not_found"
+                },
+                "error": {
+                    "type": "string",
+                    "example": "message"
+                }
+            }
+        },
+        "output.UserHistoryOutput": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/output.AuditEventOutput"
+                    }
+                },
+                "meta": {
+                    "$ref": "#/definitions/output.PageMeta"
+                }
+            }
+        },
+        "output.UserListOutput": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/output.UserOutput"
+                    }
+                },
+                "meta": {
+                    "$ref": "#/definitions/output.PageMeta"
+                }
+            }
+        },
+        "output.UserOutput": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "phone": {
+                    "type": "string"
+                }
+            }
+        }
+    },
+    "securityDefinitions": {
+        "ApiKeyAuth": {
+            "description": "API key issued with POST /v0/api-key",
+            "type": "apiKey",
+            "name": "X-API-Key",
+            "in": "header"
+        },
+        "BearerAuth": {
+            "description": "\"Bearer \" followed by a JWT",
+            "type": "apiKey",
+            "name": "Authorization",
+            "in": "header"
         }
     }
 }`
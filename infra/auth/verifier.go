@@ -0,0 +1,155 @@
+// Package auth verifies the JWT bearer tokens presented by API clients.
+package auth
+
+import (
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/apperror"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/entity"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const _defaultClockSkew = 30 * time.Second
+
+// Options configure a Verifier. At least one of HMACSecret, PublicKeyPEM and
+// JWKSFile is required; a token must be signed with one of the keys they
+// provide, with the algorithm that key is for.
+type Options struct {
+	// Issuer and Audience, when set, must match the iss and aud claims.
+	Issuer   string
+	Audience string
+	// ClockSkew is the leeway granted when checking exp, nbf and iat.
+	ClockSkew time.Duration
+
+	// HMACSecret verifies HS256 tokens.
+	HMACSecret []byte
+	// PublicKeyPEM is an RSA (RS256) or P-256 EC (ES256) public key.
+	PublicKeyPEM []byte
+	// JWKSFile is a local JSON Web Key Set of RS256 and ES256 keys,
+	// selected by the kid header of the token.
+	JWKSFile string
+}
+
+type Verifier struct {
+	parser *jwt.Parser
+	keys   []verificationKey
+}
+
+type claims struct {
+	jwt.RegisteredClaims
+	Roles []string `json:"roles"`
+	// Scope is the space-separated list of granted scopes (RFC 8693).
+	Scope string `json:"scope"`
+}
+
+func New(opts Options) (*Verifier, error) {
+	var keys []verificationKey
+	if len(opts.HMACSecret) > 0 {
+		keys = append(keys, verificationKey{key: opts.HMACSecret})
+	}
+	if len(opts.PublicKeyPEM) > 0 {
+		pub, err := parsePublicKeyPEM(opts.PublicKeyPEM)
+		if err != nil {
+			return nil, fmt.Errorf("auth - New - public key: %w", err)
+		}
+		keys = append(keys, verificationKey{key: pub})
+	}
+	if opts.JWKSFile != "" {
+		jwks, err := loadJWKS(opts.JWKSFile)
+		if err != nil {
+			return nil, fmt.Errorf("auth - New - JWKS: %w", err)
+		}
+		keys = append(keys, jwks...)
+	}
+	if len(keys) == 0 {
+		return nil, errors.New("auth - New: no verification key configured")
+	}
+
+	skew := opts.ClockSkew
+	if skew <= 0 {
+		skew = _defaultClockSkew
+	}
+	parserOpts := []jwt.ParserOption{
+		jwt.WithValidMethods([]string{"HS256", "RS256", "ES256"}),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+		jwt.WithLeeway(skew),
+	}
+	if opts.Issuer != "" {
+		parserOpts = append(parserOpts, jwt.WithIssuer(opts.Issuer))
+	}
+	if opts.Audience != "" {
+		parserOpts = append(parserOpts, jwt.WithAudience(opts.Audience))
+	}
+
+	return &Verifier{parser: jwt.NewParser(parserOpts...), keys: keys}, nil
+}
+
+// Verify checks the signature and claims of token and returns the principal
+// it was issued to. Every failure is an unauthorized error.
+func (v *Verifier) Verify(token string) (entity.Principal, error) {
+	var c claims
+	if _, err := v.parser.ParseWithClaims(token, &c, v.key); err != nil {
+		return entity.Principal{}, apperror.Unauthorized("invalid token", err)
+	}
+	if c.Subject == "" {
+		return entity.Principal{}, apperror.Unauthorized("invalid token", errors.New("token has no subject"))
+	}
+
+	return entity.Principal{Subject: c.Subject, Roles: c.Roles, Scopes: strings.Fields(c.Scope)}, nil
+}
+
+// key selects the verification key of a token: the key with its kid, or
+// else the only key without an ID usable with its algorithm.
+func (v *Verifier) key(t *jwt.Token) (any, error) {
+	kid, _ := t.Header["kid"].(string)
+
+	var byID, anonymous []any
+	for _, k := range v.keys {
+		if !usableWith(t.Method, k.key) {
+			continue
+		}
+		switch k.id {
+		case "":
+			anonymous = append(anonymous, k.key)
+		case kid:
+			byID = append(byID, k.key)
+		}
+	}
+
+	found := byID
+	if len(found) == 0 {
+		found = anonymous
+	}
+	switch len(found) {
+	case 0:
+		return nil, fmt.Errorf("no %s key for kid %q", t.Method.Alg(), kid)
+	case 1:
+		return found[0], nil
+	default:
+		return nil, fmt.Errorf("several %s keys match kid %q", t.Method.Alg(), kid)
+	}
+}
+
+// usableWith reports whether key fits the signing method, so that a public
+// key is never used as an HMAC secret or the other way around.
+func usableWith(m jwt.SigningMethod, key any) bool {
+	switch m.(type) {
+	case *jwt.SigningMethodHMAC:
+		_, ok := key.([]byte)
+		return ok
+	case *jwt.SigningMethodRSA:
+		_, ok := key.(*rsa.PublicKey)
+		return ok
+	case *jwt.SigningMethodECDSA:
+		pub, ok := key.(*ecdsa.PublicKey)
+		return ok && pub.Curve.Params().BitSize == 256
+	default:
+		return false
+	}
+}
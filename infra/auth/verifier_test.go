@@ -0,0 +1,183 @@
+package auth
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/apperror"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/entity"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var secret = []byte("0123456789abcdef0123456789abcdef")
+
+func sign(t *testing.T, method jwt.SigningMethod, key any, kid string, c jwt.MapClaims) string {
+	t.Helper()
+	tok := jwt.NewWithClaims(method, c)
+	if kid != "" {
+		tok.Header["kid"] = kid
+	}
+	s, err := tok.SignedString(key)
+	require.NoError(t, err)
+	return s
+}
+
+func validClaims() jwt.MapClaims {
+	now := time.Now()
+	return jwt.MapClaims{
+		"sub":   "user-1",
+		"iss":   "https://issuer.example.com",
+		"aud":   "users-api",
+		"exp":   now.Add(time.Hour).Unix(),
+		"iat":   now.Unix(),
+		"roles": []string{"admin"},
+		"scope": "users:read users:write",
+	}
+}
+
+func pemKey(t *testing.T, pub any) []byte {
+	t.Helper()
+	der, err := x509.MarshalPKIXPublicKey(pub)
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+}
+
+func b64(b []byte) string { return base64.RawURLEncoding.EncodeToString(b) }
+
+func writeJWKS(t *testing.T, keys ...map[string]string) string {
+	t.Helper()
+	b, err := json.Marshal(map[string]any{"keys": keys})
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	require.NoError(t, os.WriteFile(path, b, 0o600))
+	return path
+}
+
+func TestVerify_HS256(t *testing.T) {
+	v, err := New(Options{HMACSecret: secret, Issuer: "https://issuer.example.com", Audience: "users-api"})
+	require.NoError(t, err)
+
+	p, err := v.Verify(sign(t, jwt.SigningMethodHS256, secret, "", validClaims()))
+
+	require.NoError(t, err)
+	assert.Equal(t, entity.Principal{Subject: "user-1", Roles: []string{"admin"}, Scopes: []string{"users:read", "users:write"}}, p)
+}
+
+func TestVerify_PublicKeyPEM(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	v, err := New(Options{PublicKeyPEM: pemKey(t, &rsaKey.PublicKey)})
+	require.NoError(t, err)
+	_, err = v.Verify(sign(t, jwt.SigningMethodRS256, rsaKey, "", validClaims()))
+	assert.NoError(t, err)
+
+	v, err = New(Options{PublicKeyPEM: pemKey(t, &ecKey.PublicKey)})
+	require.NoError(t, err)
+	_, err = v.Verify(sign(t, jwt.SigningMethodES256, ecKey, "", validClaims()))
+	assert.NoError(t, err)
+}
+
+func TestVerify_JWKS(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	otherRSA, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	path := writeJWKS(t,
+		map[string]string{"kty": "RSA", "kid": "rsa-1", "use": "sig", "n": b64(rsaKey.N.Bytes()), "e": "AQAB"},
+		map[string]string{"kty": "RSA", "kid": "rsa-2", "n": b64(otherRSA.N.Bytes()), "e": "AQAB"},
+		map[string]string{"kty": "EC", "kid": "ec-1", "crv": "P-256", "x": b64(ecKey.X.FillBytes(make([]byte, 32))), "y": b64(ecKey.Y.FillBytes(make([]byte, 32)))},
+		map[string]string{"kty": "RSA", "kid": "enc", "use": "enc", "n": "garbage"},
+	)
+	v, err := New(Options{JWKSFile: path})
+	require.NoError(t, err)
+
+	_, err = v.Verify(sign(t, jwt.SigningMethodRS256, rsaKey, "rsa-1", validClaims()))
+	assert.NoError(t, err)
+	_, err = v.Verify(sign(t, jwt.SigningMethodRS256, otherRSA, "rsa-2", validClaims()))
+	assert.NoError(t, err)
+	_, err = v.Verify(sign(t, jwt.SigningMethodES256, ecKey, "ec-1", validClaims()))
+	assert.NoError(t, err)
+
+	// Signed by another key than the kid names, or by an unknown kid.
+	_, err = v.Verify(sign(t, jwt.SigningMethodRS256, otherRSA, "rsa-1", validClaims()))
+	assert.Error(t, err)
+	_, err = v.Verify(sign(t, jwt.SigningMethodRS256, rsaKey, "rsa-9", validClaims()))
+	assert.Error(t, err)
+	// Several RSA keys and no kid.
+	_, err = v.Verify(sign(t, jwt.SigningMethodRS256, rsaKey, "", validClaims()))
+	assert.Error(t, err)
+}
+
+func TestVerify_Rejects(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	rsaPEM := pemKey(t, &rsaKey.PublicKey)
+
+	v, err := New(Options{HMACSecret: secret, PublicKeyPEM: rsaPEM, Issuer: "https://issuer.example.com", Audience: "users-api", ClockSkew: time.Minute})
+	require.NoError(t, err)
+
+	with := func(key string, value any) jwt.MapClaims {
+		c := validClaims()
+		if value == nil {
+			delete(c, key)
+		} else {
+			c[key] = value
+		}
+		return c
+	}
+	now := time.Now()
+
+	cases := map[string]string{
+		"garbage":            "not.a.token",
+		"wrong secret":       sign(t, jwt.SigningMethodHS256, []byte("another secret, also 32 bytes!!!"), "", validClaims()),
+		"expired":            sign(t, jwt.SigningMethodHS256, secret, "", with("exp", now.Add(-2*time.Minute).Unix())),
+		"no expiry":          sign(t, jwt.SigningMethodHS256, secret, "", with("exp", nil)),
+		"not yet valid":      sign(t, jwt.SigningMethodHS256, secret, "", with("nbf", now.Add(2*time.Minute).Unix())),
+		"wrong issuer":       sign(t, jwt.SigningMethodHS256, secret, "", with("iss", "https://evil.example.com")),
+		"wrong audience":     sign(t, jwt.SigningMethodHS256, secret, "", with("aud", "other-api")),
+		"no subject":         sign(t, jwt.SigningMethodHS256, secret, "", with("sub", nil)),
+		"unsupported alg":    sign(t, jwt.SigningMethodHS512, secret, "", validClaims()),
+		"none alg":           sign(t, jwt.SigningMethodNone, jwt.UnsafeAllowNoneSignatureType, "", validClaims()),
+		"public key as HMAC": sign(t, jwt.SigningMethodHS256, rsaPEM, "", validClaims()),
+	}
+	for name, token := range cases {
+		_, err := v.Verify(token)
+		assert.ErrorIs(t, err, apperror.ErrUnauthorized, name)
+	}
+
+	// Within the clock skew.
+	_, err = v.Verify(sign(t, jwt.SigningMethodHS256, secret, "", with("exp", now.Add(-30*time.Second).Unix())))
+	assert.NoError(t, err)
+}
+
+func TestNew_Errors(t *testing.T) {
+	_, err := New(Options{})
+	assert.EqualError(t, err, "auth - New: no verification key configured")
+
+	_, err = New(Options{PublicKeyPEM: []byte("not pem")})
+	assert.Error(t, err)
+
+	_, err = New(Options{JWKSFile: filepath.Join(t.TempDir(), "missing.json")})
+	assert.Error(t, err)
+
+	_, err = New(Options{JWKSFile: writeJWKS(t, map[string]string{"kty": "EC", "crv": "P-256", "x": "AQ", "y": "AQ"})})
+	assert.Error(t, err)
+}
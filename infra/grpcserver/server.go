@@ -0,0 +1,90 @@
+package grpcserver
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"time"
+
+	"google.golang.org/grpc"
+)
+
+const (
+	_defaultAddr            = ":9090"
+	_defaultShutdownTimeout = 3 * time.Second
+)
+
+type Server struct {
+	server          *grpc.Server
+	addr            string
+	listener        net.Listener
+	notify          chan error
+	shutdownTimeout time.Duration
+}
+
+func New(server *grpc.Server, opts ...Option) *Server {
+	s := &Server{
+		server:          server,
+		addr:            _defaultAddr,
+		notify:          make(chan error, 1),
+		shutdownTimeout: _defaultShutdownTimeout,
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+// Start listens on the address of the server, so that it fails when the
+// port is taken, and serves in the background. Notify reports when serving
+// stops.
+func (s *Server) Start() error {
+	l, err := net.Listen("tcp", s.addr)
+	if err != nil {
+		return fmt.Errorf("grpcserver - Start: %w", err)
+	}
+	s.listener = l
+
+	go func() {
+		s.notify <- s.server.Serve(l)
+		close(s.notify)
+	}()
+	return nil
+}
+
+// Addr returns the address the server listens on, or nil before Start.
+func (s *Server) Addr() net.Addr {
+	if s.listener == nil {
+		return nil
+	}
+	return s.listener.Addr()
+}
+
+func (s *Server) Notify() <-chan error {
+	return s.notify
+}
+
+// Shutdown stops accepting connections and waits for pending RPCs to finish.
+// RPCs still running after the shutdown timeout or once ctx is done, such as
+// streams that never end, are cancelled.
+func (s *Server) Shutdown(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, s.shutdownTimeout)
+	defer cancel()
+
+	done := make(chan struct{})
+	go func() {
+		s.server.GracefulStop()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		s.server.Stop()
+		<-done
+		return fmt.Errorf("grpcserver - Shutdown: pending RPCs cancelled: %w", ctx.Err())
+	}
+}
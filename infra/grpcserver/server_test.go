@@ -0,0 +1,69 @@
+package grpcserver
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func TestNew_ServeAndShutdown(t *testing.T) {
+	srv := grpc.NewServer()
+	grpc_health_v1.RegisterHealthServer(srv, health.NewServer())
+
+	s := New(srv, Port("0"), ShutdownTimeout(time.Second))
+	assert.Nil(t, s.Addr())
+	require.NoError(t, s.Start())
+	require.NotNil(t, s.Addr())
+
+	conn, err := grpc.NewClient(s.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer conn.Close()
+
+	resp, err := grpc_health_v1.NewHealthClient(conn).Check(context.Background(), &grpc_health_v1.HealthCheckRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, grpc_health_v1.HealthCheckResponse_SERVING, resp.Status)
+
+	assert.NoError(t, s.Shutdown(context.Background()))
+	assert.NoError(t, <-s.Notify())
+}
+
+func TestStart_ListenError(t *testing.T) {
+	s := New(grpc.NewServer(), Port("-1"))
+
+	assert.Error(t, s.Start())
+	assert.Nil(t, s.Addr())
+}
+
+func TestShutdown_Timeout(t *testing.T) {
+	srv := grpc.NewServer()
+	grpc_health_v1.RegisterHealthServer(srv, health.NewServer())
+	s := New(srv, Port("0"), ShutdownTimeout(50*time.Millisecond))
+	require.NoError(t, s.Start())
+
+	conn, err := grpc.NewClient(s.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer conn.Close()
+
+	// A health watch stream never ends on its own.
+	stream, err := grpc_health_v1.NewHealthClient(conn).Watch(context.Background(), &grpc_health_v1.HealthCheckRequest{})
+	require.NoError(t, err)
+	_, err = stream.Recv()
+	require.NoError(t, err)
+
+	assert.ErrorIs(t, s.Shutdown(context.Background()), context.DeadlineExceeded)
+}
+
+func TestOptions(t *testing.T) {
+	s := &Server{}
+	Port("9091")(s)
+	ShutdownTimeout(5 * time.Second)(s)
+	assert.Equal(t, ":9091", s.addr)
+	assert.Equal(t, 5*time.Second, s.shutdownTimeout)
+}
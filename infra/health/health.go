@@ -0,0 +1,189 @@
+// Package health runs the liveness and readiness checks registered by the
+// components of the application.
+package health
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	defaultTimeout  = 2 * time.Second
+	defaultCacheTTL = 5 * time.Second
+)
+
+// ErrShuttingDown fails readiness once Shutdown was called.
+var ErrShuttingDown = errors.New("shutting down")
+
+type Status string
+
+const (
+	StatusOK Status = "ok"
+	// StatusDegraded is a report whose failed checks are all non-critical.
+	StatusDegraded Status = "degraded"
+	StatusFailing  Status = "failing"
+)
+
+// Check returns an error when the component it checks is unhealthy. It
+// should return once ctx is done.
+type Check func(ctx context.Context) error
+
+type CheckOptions struct {
+	// Critical checks fail the whole report. The others only degrade it.
+	Critical bool
+	// Liveness checks are run by Live instead of Ready. They should only
+	// fail when restarting the process would help.
+	Liveness bool
+	// Timeout bounds a run of the check, 2s by default.
+	Timeout time.Duration
+	// CacheTTL is how long a result is reused before the check runs again,
+	// 5s by default, so that frequent probes do not hammer the database.
+	CacheTTL time.Duration
+}
+
+type Result struct {
+	Name      string
+	Status    Status
+	Critical  bool
+	Error     string
+	Duration  time.Duration
+	CheckedAt time.Time
+}
+
+type Report struct {
+	Status Status
+	Checks []Result
+}
+
+// Registry holds the checks of the application. Checks are registered at
+// startup and may run concurrently afterwards.
+type Registry struct {
+	mu       sync.RWMutex
+	checks   []*check
+	shutdown atomic.Bool
+	now      func() time.Time
+}
+
+func NewRegistry() *Registry {
+	return &Registry{now: time.Now}
+}
+
+// Register adds a check named name.
+func (r *Registry) Register(name string, fn Check, opts CheckOptions) {
+	if opts.Timeout <= 0 {
+		opts.Timeout = defaultTimeout
+	}
+	if opts.CacheTTL <= 0 {
+		opts.CacheTTL = defaultCacheTTL
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.checks = append(r.checks, &check{name: name, fn: fn, opts: opts})
+}
+
+// Live runs the liveness checks.
+func (r *Registry) Live(ctx context.Context) Report {
+	return r.run(ctx, true)
+}
+
+// Ready runs the readiness checks. It fails without running them once
+// Shutdown was called.
+func (r *Registry) Ready(ctx context.Context) Report {
+	if r.shutdown.Load() {
+		return Report{Status: StatusFailing, Checks: []Result{{
+			Name:      "shutdown",
+			Status:    StatusFailing,
+			Critical:  true,
+			Error:     ErrShuttingDown.Error(),
+			CheckedAt: r.now(),
+		}}}
+	}
+	return r.run(ctx, false)
+}
+
+// Shutdown makes readiness fail, so that load balancers stop sending
+// requests while the servers drain the ones in flight.
+func (r *Registry) Shutdown() {
+	r.shutdown.Store(true)
+}
+
+func (r *Registry) run(ctx context.Context, liveness bool) Report {
+	r.mu.RLock()
+	var checks []*check
+	for _, c := range r.checks {
+		if c.opts.Liveness == liveness {
+			checks = append(checks, c)
+		}
+	}
+	r.mu.RUnlock()
+
+	report := Report{Status: StatusOK, Checks: make([]Result, len(checks))}
+	var wg sync.WaitGroup
+	for i, c := range checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			report.Checks[i] = c.result(ctx, r.now)
+		}()
+	}
+	wg.Wait()
+
+	for _, res := range report.Checks {
+		switch {
+		case res.Status == StatusOK:
+		case res.Critical:
+			report.Status = StatusFailing
+		case report.Status == StatusOK:
+			report.Status = StatusDegraded
+		}
+	}
+	return report
+}
+
+type check struct {
+	name string
+	fn   Check
+	opts CheckOptions
+
+	// mu makes concurrent probes wait for the run in flight rather than
+	// start their own.
+	mu      sync.Mutex
+	last    Result
+	expires time.Time
+}
+
+func (c *check) result(ctx context.Context, now func() time.Time) Result {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if now().Before(c.expires) {
+		return c.last
+	}
+
+	// The result is shared with other probes, so it must not depend on the
+	// caller going away.
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), c.opts.Timeout)
+	defer cancel()
+
+	start := now()
+	done := make(chan error, 1)
+	go func() { done <- c.fn(ctx) }()
+	var err error
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	c.last = Result{Name: c.name, Status: StatusOK, Critical: c.opts.Critical, Duration: now().Sub(start), CheckedAt: start}
+	if err != nil {
+		c.last.Status = StatusFailing
+		c.last.Error = err.Error()
+	}
+	c.expires = start.Add(c.opts.CacheTTL)
+	return c.last
+}
@@ -0,0 +1,158 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func ok(context.Context) error { return nil }
+
+func fail(context.Context) error { return errors.New("boom") }
+
+func TestRegistry_Ready(t *testing.T) {
+	tests := []struct {
+		name   string
+		checks map[string]Check
+		want   Status
+	}{
+		{"all ok", map[string]Check{"postgres": ok}, StatusOK},
+		{"non-critical failure", map[string]Check{"postgres": ok, "cache": fail}, StatusDegraded},
+		{"critical failure", map[string]Check{"postgres": fail, "cache": fail}, StatusFailing},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewRegistry()
+			for name, fn := range tt.checks {
+				r.Register(name, fn, CheckOptions{Critical: name == "postgres"})
+			}
+
+			report := r.Ready(context.Background())
+
+			assert.Equal(t, tt.want, report.Status)
+			assert.Len(t, report.Checks, len(tt.checks))
+		})
+	}
+}
+
+func TestRegistry_Result(t *testing.T) {
+	r := NewRegistry()
+	r.Register("postgres", fail, CheckOptions{Critical: true})
+
+	report := r.Ready(context.Background())
+
+	require.Len(t, report.Checks, 1)
+	res := report.Checks[0]
+	assert.Equal(t, "postgres", res.Name)
+	assert.Equal(t, StatusFailing, res.Status)
+	assert.True(t, res.Critical)
+	assert.Equal(t, "boom", res.Error)
+	assert.False(t, res.CheckedAt.IsZero())
+}
+
+func TestRegistry_Live(t *testing.T) {
+	r := NewRegistry()
+	r.Register("postgres", fail, CheckOptions{Critical: true})
+	r.Register("worker", ok, CheckOptions{Critical: true, Liveness: true})
+
+	live := r.Live(context.Background())
+	ready := r.Ready(context.Background())
+
+	assert.Equal(t, StatusOK, live.Status)
+	require.Len(t, live.Checks, 1)
+	assert.Equal(t, "worker", live.Checks[0].Name)
+	assert.Equal(t, StatusFailing, ready.Status)
+	require.Len(t, ready.Checks, 1)
+	assert.Equal(t, "postgres", ready.Checks[0].Name)
+}
+
+func TestRegistry_Cache(t *testing.T) {
+	r := NewRegistry()
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	r.now = func() time.Time { return now }
+	var runs atomic.Int32
+	r.Register("postgres", func(context.Context) error {
+		runs.Add(1)
+		return nil
+	}, CheckOptions{CacheTTL: time.Minute})
+
+	r.Ready(context.Background())
+	r.Ready(context.Background())
+	assert.Equal(t, int32(1), runs.Load())
+
+	now = now.Add(time.Minute)
+	r.Ready(context.Background())
+	assert.Equal(t, int32(2), runs.Load())
+}
+
+func TestRegistry_Timeout(t *testing.T) {
+	r := NewRegistry()
+	block := make(chan struct{})
+	defer close(block)
+	r.Register("postgres", func(context.Context) error {
+		<-block
+		return nil
+	}, CheckOptions{Critical: true, Timeout: 10 * time.Millisecond})
+
+	report := r.Ready(context.Background())
+
+	assert.Equal(t, StatusFailing, report.Status)
+	assert.Equal(t, context.DeadlineExceeded.Error(), report.Checks[0].Error)
+}
+
+func TestRegistry_CallerCanceled(t *testing.T) {
+	r := NewRegistry()
+	r.Register("postgres", func(ctx context.Context) error { return ctx.Err() }, CheckOptions{Critical: true})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	report := r.Ready(ctx)
+
+	assert.Equal(t, StatusOK, report.Status)
+}
+
+func TestRegistry_Shutdown(t *testing.T) {
+	r := NewRegistry()
+	var runs atomic.Int32
+	r.Register("postgres", func(context.Context) error {
+		runs.Add(1)
+		return nil
+	}, CheckOptions{Critical: true})
+	r.Register("worker", ok, CheckOptions{Critical: true, Liveness: true})
+
+	r.Shutdown()
+	ready := r.Ready(context.Background())
+	live := r.Live(context.Background())
+
+	assert.Equal(t, StatusFailing, ready.Status)
+	require.Len(t, ready.Checks, 1)
+	assert.Equal(t, "shutdown", ready.Checks[0].Name)
+	assert.Equal(t, ErrShuttingDown.Error(), ready.Checks[0].Error)
+	assert.Zero(t, runs.Load())
+	assert.Equal(t, StatusOK, live.Status)
+}
+
+func TestHeartbeat(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	h := &Heartbeat{maxAge: time.Minute, now: func() time.Time { return now }}
+	h.Beat()
+
+	now = now.Add(time.Minute)
+	assert.NoError(t, h.Check(context.Background()))
+
+	now = now.Add(time.Second)
+	assert.EqualError(t, h.Check(context.Background()), "no progress for 1m1s")
+
+	h.Beat()
+	assert.NoError(t, h.Check(context.Background()))
+}
+
+func TestHeartbeat_Nil(t *testing.T) {
+	var h *Heartbeat
+	assert.NotPanics(t, h.Beat)
+}
@@ -0,0 +1,42 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+// Heartbeat tells whether a background worker is still making progress. The
+// worker beats after every run, and Check fails once no beat was seen for
+// longer than the allowed age.
+type Heartbeat struct {
+	maxAge time.Duration
+	last   atomic.Int64
+	now    func() time.Time
+}
+
+// NewHeartbeat returns a heartbeat that fails after maxAge without a beat.
+// Creating it counts as the first beat.
+func NewHeartbeat(maxAge time.Duration) *Heartbeat {
+	h := &Heartbeat{maxAge: maxAge, now: time.Now}
+	h.Beat()
+	return h
+}
+
+// Beat records progress. It does nothing on a nil heartbeat.
+func (h *Heartbeat) Beat() {
+	if h == nil {
+		return
+	}
+	h.last.Store(h.now().UnixNano())
+}
+
+// Check fails when the last beat is older than the allowed age.
+func (h *Heartbeat) Check(context.Context) error {
+	last := time.Unix(0, h.last.Load())
+	if age := h.now().Sub(last); age > h.maxAge {
+		return fmt.Errorf("no progress for %s", age.Round(time.Second))
+	}
+	return nil
+}
@@ -2,6 +2,8 @@ package httpserver
 
 import (
 	"context"
+	"fmt"
+	"net"
 	"net/http"
 	"time"
 )
@@ -15,6 +17,7 @@ const (
 
 type Server struct {
 	server          *http.Server
+	listener        net.Listener
 	notify          chan error
 	shutdownTimeout time.Duration
 }
@@ -37,25 +40,48 @@ func New(handler http.Handler, opts ...Option) *Server {
 		opt(s)
 	}
 
-	s.start()
-
 	return s
 }
 
-func (s *Server) start() {
+// Start listens on the address of the server, so that it fails when the
+// port is taken, and serves in the background. Notify reports when serving
+// stops.
+func (s *Server) Start() error {
+	l, err := net.Listen("tcp", s.server.Addr)
+	if err != nil {
+		return fmt.Errorf("httpserver - Start: %w", err)
+	}
+	s.listener = l
+
 	go func() {
-		s.notify <- s.server.ListenAndServe()
+		s.notify <- s.server.Serve(l)
 		close(s.notify)
 	}()
+	return nil
+}
+
+// Addr returns the address the server listens on, or nil before Start.
+func (s *Server) Addr() net.Addr {
+	if s.listener == nil {
+		return nil
+	}
+	return s.listener.Addr()
 }
 
 func (s *Server) Notify() <-chan error {
 	return s.notify
 }
 
-func (s *Server) Shutdown() error {
-	ctx, cancel := context.WithTimeout(context.Background(), s.shutdownTimeout)
+// Shutdown stops accepting connections and waits for the requests in flight
+// until the shutdown timeout or ctx is done, then closes the connections
+// left.
+func (s *Server) Shutdown(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, s.shutdownTimeout)
 	defer cancel()
 
-	return s.server.Shutdown(ctx)
+	if err := s.server.Shutdown(ctx); err != nil {
+		_ = s.server.Close()
+		return fmt.Errorf("httpserver - Shutdown: %w", err)
+	}
+	return nil
 }
@@ -1,11 +1,13 @@
 package httpserver
 
 import (
+	"context"
 	"net/http"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestNew_DefaultsAndStart(t *testing.T) {
@@ -16,9 +18,43 @@ func TestNew_DefaultsAndStart(t *testing.T) {
 	s := New(handler, Port("0"), ShutdownTimeout(1*time.Second))
 	assert.NotNil(t, s)
 	assert.NotNil(t, s.Notify())
+	assert.Nil(t, s.Addr())
 
-	err := s.Shutdown()
+	require.NoError(t, s.Start())
+	resp, err := http.Get("http://" + s.Addr().String())
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	err = s.Shutdown(context.Background())
 	assert.NoError(t, err)
+	assert.ErrorIs(t, <-s.Notify(), http.ErrServerClosed)
+}
+
+func TestStart_ListenError(t *testing.T) {
+	s := New(http.NotFoundHandler(), Port("-1"))
+
+	assert.Error(t, s.Start())
+	assert.Nil(t, s.Addr())
+}
+
+func TestShutdown_Timeout(t *testing.T) {
+	started, release := make(chan struct{}), make(chan struct{})
+	defer close(release)
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-release
+	})
+	s := New(handler, Port("0"), ShutdownTimeout(50*time.Millisecond))
+	require.NoError(t, s.Start())
+	go func() {
+		if resp, err := http.Get("http://" + s.Addr().String()); err == nil {
+			resp.Body.Close()
+		}
+	}()
+	<-started
+
+	assert.ErrorIs(t, s.Shutdown(context.Background()), context.DeadlineExceeded)
 }
 
 func TestPort(t *testing.T) {
@@ -50,5 +86,5 @@ func TestNotify(t *testing.T) {
 	s := New(handler, Port("0"), ShutdownTimeout(1*time.Second))
 	ch := s.Notify()
 	assert.NotNil(t, ch)
-	_ = s.Shutdown()
+	_ = s.Shutdown(context.Background())
 }
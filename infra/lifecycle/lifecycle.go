@@ -0,0 +1,249 @@
+// Package lifecycle starts and stops the components of the application in
+// the order of their dependencies.
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/DeSouzaRafael/go-clean-architecture-template/infra/logger"
+)
+
+const _defaultTimeout = 30 * time.Second
+
+// Hook is a component the Manager starts and stops. Start must not block
+// while the component runs, and Stop should drain its in-flight work before
+// ctx is done. Either may be nil.
+type Hook struct {
+	Name string
+	// DependsOn names the components started before this one and stopped
+	// after it.
+	DependsOn []string
+	Start     func(ctx context.Context) error
+	Stop      func(ctx context.Context) error
+}
+
+// BlockedError reports the components still stopping when the shutdown
+// deadline passed.
+type BlockedError struct {
+	Components []string
+}
+
+func (e *BlockedError) Error() string {
+	return "shutdown deadline exceeded, blocked by " + strings.Join(e.Components, ", ")
+}
+
+func (e *BlockedError) Unwrap() error {
+	return context.DeadlineExceeded
+}
+
+// Manager starts components in the order of their dependencies and stops
+// them in reverse order, those independent of each other at the same time.
+type Manager struct {
+	l       logger.Interface
+	timeout time.Duration
+
+	mu      sync.Mutex
+	hooks   []Hook
+	started []Hook
+}
+
+// New returns a Manager stopping every component within timeout, 30s when
+// zero.
+func New(l logger.Interface, timeout time.Duration) *Manager {
+	if timeout <= 0 {
+		timeout = _defaultTimeout
+	}
+	return &Manager{l: l, timeout: timeout}
+}
+
+// Append registers h. Components are started in the order they are
+// appended, unless their dependencies come later.
+func (m *Manager) Append(h Hook) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.hooks = append(m.hooks, h)
+}
+
+// Start starts every component after its dependencies. When one fails, the
+// components already started are stopped and its error is returned.
+func (m *Manager) Start(ctx context.Context) error {
+	m.mu.Lock()
+	order, err := sortHooks(m.hooks)
+	m.mu.Unlock()
+	if err != nil {
+		return fmt.Errorf("lifecycle - Start: %w", err)
+	}
+
+	for _, h := range order {
+		if h.Start != nil {
+			if err := h.Start(ctx); err != nil {
+				err = fmt.Errorf("lifecycle - Start - %s: %w", h.Name, err)
+				if stopErr := m.Stop(ctx); stopErr != nil {
+					err = errors.Join(err, stopErr)
+				}
+				return err
+			}
+		}
+		m.mu.Lock()
+		m.started = append(m.started, h)
+		m.mu.Unlock()
+		m.l.Debug("lifecycle - started", "component", h.Name)
+	}
+	return nil
+}
+
+// Stop stops the started components, each once the components depending on
+// it are stopped, and waits for them until the shutdown timeout. Past it,
+// the components left are stopped with a done context and not waited for,
+// and a *BlockedError names the ones that were still stopping.
+func (m *Manager) Stop(ctx context.Context) error {
+	m.mu.Lock()
+	started := m.started
+	m.started = nil
+	m.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(ctx, m.timeout)
+	defer cancel()
+
+	done := make(map[string]chan struct{}, len(started))
+	dependents := make(map[string][]string)
+	for _, h := range started {
+		done[h.Name] = make(chan struct{})
+		for _, dep := range h.DependsOn {
+			dependents[dep] = append(dependents[dep], h.Name)
+		}
+	}
+
+	type result struct {
+		name string
+		err  error
+	}
+	var (
+		mu       sync.Mutex
+		stopping = make(map[string]bool)
+		results  = make(chan result, len(started))
+	)
+	for _, h := range started {
+		go func() {
+			defer close(done[h.Name])
+			for _, d := range dependents[h.Name] {
+				select {
+				case <-done[d]:
+				case <-ctx.Done():
+				}
+			}
+			if h.Stop == nil {
+				results <- result{name: h.Name}
+				return
+			}
+
+			mu.Lock()
+			stopping[h.Name] = true
+			mu.Unlock()
+			begin := time.Now()
+			err := h.Stop(ctx)
+			mu.Lock()
+			delete(stopping, h.Name)
+			mu.Unlock()
+
+			m.l.Info("lifecycle - stopped", "component", h.Name, "duration", time.Since(begin).String())
+			results <- result{name: h.Name, err: err}
+		}()
+	}
+
+	var errs []error
+	for range started {
+		select {
+		case r := <-results:
+			if r.err != nil {
+				errs = append(errs, fmt.Errorf("stop %s: %w", r.name, r.err))
+			}
+		case <-ctx.Done():
+			mu.Lock()
+			blocked := make([]string, 0, len(stopping))
+			for name := range stopping {
+				blocked = append(blocked, name)
+			}
+			mu.Unlock()
+			sort.Strings(blocked)
+			return errors.Join(append(errs, &BlockedError{Components: blocked})...)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// sortHooks orders hooks after their dependencies, keeping the order they
+// were appended in otherwise.
+func sortHooks(hooks []Hook) ([]Hook, error) {
+	names := make(map[string]bool, len(hooks))
+	for _, h := range hooks {
+		if names[h.Name] {
+			return nil, fmt.Errorf("component %s appended twice", h.Name)
+		}
+		names[h.Name] = true
+	}
+	for _, h := range hooks {
+		for _, dep := range h.DependsOn {
+			if !names[dep] {
+				return nil, fmt.Errorf("component %s depends on unknown component %s", h.Name, dep)
+			}
+		}
+	}
+
+	order := make([]Hook, 0, len(hooks))
+	placed := make(map[string]bool, len(hooks))
+	pending := slices.Clone(hooks)
+	for len(pending) > 0 {
+		i := slices.IndexFunc(pending, func(h Hook) bool {
+			return !slices.ContainsFunc(h.DependsOn, func(dep string) bool { return !placed[dep] })
+		})
+		if i < 0 {
+			cycle := make([]string, len(pending))
+			for j, h := range pending {
+				cycle[j] = h.Name
+			}
+			return nil, fmt.Errorf("dependency cycle between %s", strings.Join(cycle, ", "))
+		}
+		placed[pending[i].Name] = true
+		order = append(order, pending[i])
+		pending = slices.Delete(pending, i, i+1)
+	}
+	return order, nil
+}
+
+// Worker returns a hook running run in a goroutine of its own from Start.
+// Stop cancels the context of run and waits for it to return, so that the
+// work in progress is finished rather than cut off.
+func Worker(name string, run func(ctx context.Context), dependsOn ...string) Hook {
+	var cancel context.CancelFunc
+	done := make(chan struct{})
+	return Hook{
+		Name:      name,
+		DependsOn: dependsOn,
+		Start: func(context.Context) error {
+			var ctx context.Context
+			ctx, cancel = context.WithCancel(context.Background())
+			go func() {
+				defer close(done)
+				run(ctx)
+			}()
+			return nil
+		},
+		Stop: func(ctx context.Context) error {
+			cancel()
+			select {
+			case <-done:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		},
+	}
+}
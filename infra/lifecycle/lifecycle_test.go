@@ -0,0 +1,148 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/DeSouzaRafael/go-clean-architecture-template/infra/logger"
+)
+
+// recorder records the start and stop of components, in order.
+type recorder struct {
+	mu     sync.Mutex
+	events []string
+}
+
+func (r *recorder) add(event string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, event)
+}
+
+func (r *recorder) hook(name string, deps ...string) Hook {
+	return Hook{
+		Name:      name,
+		DependsOn: deps,
+		Start: func(context.Context) error {
+			r.add("start " + name)
+			return nil
+		},
+		Stop: func(context.Context) error {
+			r.add("stop " + name)
+			return nil
+		},
+	}
+}
+
+func newTestManager(timeout time.Duration) *Manager {
+	return New(logger.NewLogger("error"), timeout)
+}
+
+func TestManager_Order(t *testing.T) {
+	rec := &recorder{}
+	m := newTestManager(time.Second)
+	m.Append(rec.hook("http", "postgres"))
+	m.Append(rec.hook("postgres", "tracing"))
+	m.Append(rec.hook("tracing"))
+
+	require.NoError(t, m.Start(context.Background()))
+	require.NoError(t, m.Stop(context.Background()))
+
+	assert.Equal(t, []string{
+		"start tracing", "start postgres", "start http",
+		"stop http", "stop postgres", "stop tracing",
+	}, rec.events)
+}
+
+func TestManager_StartFailure(t *testing.T) {
+	rec := &recorder{}
+	m := newTestManager(time.Second)
+	m.Append(rec.hook("postgres"))
+	m.Append(Hook{Name: "http", DependsOn: []string{"postgres"}, Start: func(context.Context) error {
+		return errors.New("address already in use")
+	}})
+	m.Append(rec.hook("grpc", "http"))
+
+	err := m.Start(context.Background())
+
+	assert.EqualError(t, err, "lifecycle - Start - http: address already in use")
+	assert.Equal(t, []string{"start postgres", "stop postgres"}, rec.events)
+}
+
+func TestManager_InvalidDependencies(t *testing.T) {
+	tests := map[string][]Hook{
+		"unknown":   {{Name: "http", DependsOn: []string{"postgres"}}},
+		"duplicate": {{Name: "http"}, {Name: "http"}},
+		"cycle":     {{Name: "a", DependsOn: []string{"b"}}, {Name: "b", DependsOn: []string{"a"}}},
+	}
+	for name, hooks := range tests {
+		t.Run(name, func(t *testing.T) {
+			m := newTestManager(time.Second)
+			for _, h := range hooks {
+				m.Append(h)
+			}
+
+			assert.Error(t, m.Start(context.Background()))
+		})
+	}
+}
+
+func TestManager_Blocked(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	rec := &recorder{}
+	m := newTestManager(20 * time.Millisecond)
+	m.Append(rec.hook("postgres"))
+	m.Append(Hook{Name: "http", DependsOn: []string{"postgres"}, Stop: func(context.Context) error {
+		<-release
+		return nil
+	}})
+	m.Append(rec.hook("metrics", "postgres"))
+	require.NoError(t, m.Start(context.Background()))
+
+	err := m.Stop(context.Background())
+
+	var blocked *BlockedError
+	require.ErrorAs(t, err, &blocked)
+	assert.Equal(t, []string{"http"}, blocked.Components)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	// postgres stops past the deadline, without waiting for http any longer.
+	assert.Eventually(t, func() bool {
+		rec.mu.Lock()
+		defer rec.mu.Unlock()
+		return len(rec.events) == 4 && rec.events[3] == "stop postgres"
+	}, time.Second, time.Millisecond)
+}
+
+func TestManager_StopErrors(t *testing.T) {
+	m := newTestManager(time.Second)
+	m.Append(Hook{Name: "publisher", Stop: func(context.Context) error { return errors.New("flush failed") }})
+	require.NoError(t, m.Start(context.Background()))
+
+	assert.EqualError(t, m.Stop(context.Background()), "stop publisher: flush failed")
+	assert.NoError(t, m.Stop(context.Background()))
+}
+
+func TestWorker(t *testing.T) {
+	finished := make(chan struct{})
+	h := Worker("relay", func(ctx context.Context) {
+		<-ctx.Done()
+		close(finished)
+	}, "postgres")
+
+	require.NoError(t, h.Start(context.Background()))
+	require.NoError(t, h.Stop(context.Background()))
+
+	assert.Equal(t, []string{"postgres"}, h.DependsOn)
+	select {
+	case <-finished:
+	default:
+		t.Fatal("Stop returned before the worker")
+	}
+}
@@ -0,0 +1,132 @@
+package logger
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/entity"
+)
+
+// Level is the minimum level of a Logger, shared with the loggers derived
+// from it, that may change while they log. Set changes the configured level,
+// and SetTemporary overrides it until a timeout.
+type Level struct {
+	current atomic.Int32
+
+	mu         sync.Mutex
+	configured zerolog.Level
+	revertAt   time.Time
+	timer      *time.Timer
+	now        func() time.Time
+}
+
+func newLevel(level zerolog.Level) *Level {
+	v := &Level{configured: level, now: time.Now}
+	v.current.Store(int32(level))
+	return v
+}
+
+func (v *Level) enabled(level zerolog.Level) bool {
+	return level >= zerolog.Level(v.current.Load())
+}
+
+// Set changes the configured level: debug, info, warn or error. It applies
+// at once, unless a temporary level is active, and then on its revert.
+func (v *Level) Set(level string) error {
+	lvl, err := strictLevel(level)
+	if err != nil {
+		return err
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.configured = lvl
+	if v.timer == nil {
+		v.current.Store(int32(lvl))
+	}
+	return nil
+}
+
+// SetTemporary overrides the level for ttl, after which the configured
+// level is restored. It replaces any previous override.
+func (v *Level) SetTemporary(level string, ttl time.Duration) (entity.LogLevel, error) {
+	lvl, err := strictLevel(level)
+	if err != nil {
+		return entity.LogLevel{}, err
+	}
+	if ttl <= 0 {
+		return entity.LogLevel{}, fmt.Errorf("log level ttl must be positive, got %s", ttl)
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.stop()
+	var t *time.Timer
+	t = time.AfterFunc(ttl, func() {
+		v.mu.Lock()
+		defer v.mu.Unlock()
+		// A newer override or a Revert may have replaced this one.
+		if v.timer == t {
+			v.stop()
+		}
+	})
+	v.timer = t
+	v.revertAt = v.now().Add(ttl)
+	v.current.Store(int32(lvl))
+	return v.state(), nil
+}
+
+// Revert ends a temporary override, if any, and restores the configured
+// level.
+func (v *Level) Revert() entity.LogLevel {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.stop()
+	return v.state()
+}
+
+// LogLevel returns the current and configured levels.
+func (v *Level) LogLevel() entity.LogLevel {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return v.state()
+}
+
+// stop ends the override and restores the configured level. v.mu is held.
+func (v *Level) stop() {
+	if v.timer != nil {
+		v.timer.Stop()
+		v.timer = nil
+	}
+	v.revertAt = time.Time{}
+	v.current.Store(int32(v.configured))
+}
+
+// state is the LogLevel of v. v.mu is held.
+func (v *Level) state() entity.LogLevel {
+	s := entity.LogLevel{
+		Level:      zerolog.Level(v.current.Load()).String(),
+		Configured: v.configured.String(),
+	}
+	if v.timer != nil {
+		at := v.revertAt
+		s.RevertAt = &at
+	}
+	return s
+}
+
+// strictLevel parses a level given at runtime, which unlike Options.Level
+// does not fall back to info.
+func strictLevel(level string) (zerolog.Level, error) {
+	switch l := strings.ToLower(level); l {
+	case entity.LogLevelDebug, entity.LogLevelInfo, entity.LogLevelWarn, entity.LogLevelError:
+		return parseLevel(l), nil
+	default:
+		return zerolog.NoLevel, fmt.Errorf("unknown log level %q", level)
+	}
+}
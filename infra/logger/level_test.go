@@ -0,0 +1,70 @@
+package logger
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLevel_Set(t *testing.T) {
+	l, buf := newTestLogger("info")
+	child := l.With("component", "worker")
+
+	child.Debug("hidden")
+	require.NoError(t, l.Level().Set("debug"))
+	child.Debug("shown")
+
+	got := lines(t, buf)
+	require.Len(t, got, 1)
+	assert.Equal(t, "shown", got[0]["message"])
+	assert.Error(t, l.Level().Set("verbose"))
+}
+
+func TestLevel_SetTemporary(t *testing.T) {
+	l, buf := newTestLogger("warn")
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	l.Level().now = func() time.Time { return now }
+
+	state, err := l.Level().SetTemporary("debug", time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, "debug", state.Level)
+	assert.Equal(t, "warn", state.Configured)
+	require.NotNil(t, state.RevertAt)
+	assert.Equal(t, now.Add(time.Hour), *state.RevertAt)
+
+	// The configured level applies on revert, not during the override.
+	require.NoError(t, l.Level().Set("error"))
+	l.Info("during")
+	state = l.Level().Revert()
+	l.Warn("after")
+
+	assert.Equal(t, "error", state.Level)
+	assert.Nil(t, state.RevertAt)
+	got := lines(t, buf)
+	require.Len(t, got, 1)
+	assert.Equal(t, "during", got[0]["message"])
+}
+
+func TestLevel_SetTemporary_Expires(t *testing.T) {
+	l, _ := newTestLogger("info")
+
+	_, err := l.Level().SetTemporary("debug", 10*time.Millisecond)
+	require.NoError(t, err)
+
+	assert.Eventually(t, func() bool {
+		return l.Level().LogLevel().Level == "info"
+	}, time.Second, 5*time.Millisecond)
+	assert.Nil(t, l.Level().LogLevel().RevertAt)
+}
+
+func TestLevel_SetTemporary_Invalid(t *testing.T) {
+	l, _ := newTestLogger("info")
+
+	_, err := l.Level().SetTemporary("trace", time.Minute)
+	assert.Error(t, err)
+	_, err = l.Level().SetTemporary("debug", 0)
+	assert.Error(t, err)
+	assert.Equal(t, "info", l.Level().LogLevel().Level)
+}
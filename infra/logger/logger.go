@@ -1,94 +1,295 @@
 package logger
 
 import (
-	"fmt"
+	"context"
+	"io"
+	"log/slog"
 	"os"
+	"runtime"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/rs/zerolog"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/reqctx"
+)
+
+const (
+	FormatJSON    = "json"
+	FormatConsole = "console"
 )
 
+// Interface is the logging API of the application. As with log/slog, args
+// are alternating keys and values, or slog.Attr values such as Err(err):
+//
+//	l.Error("http - v0 - get", logger.Err(err), "id", id)
 type Interface interface {
-	Debug(message interface{}, args ...interface{})
-	Info(message string, args ...interface{})
-	Warn(message string, args ...interface{})
-	Error(message interface{}, args ...interface{})
-	Fatal(message interface{}, args ...interface{})
+	Debug(msg string, args ...any)
+	Info(msg string, args ...any)
+	Warn(msg string, args ...any)
+	Error(msg string, args ...any)
+	// Fatal logs at fatal level and exits the process.
+	Fatal(msg string, args ...any)
+	// With returns a logger adding args to every line.
+	With(args ...any) Interface
+	// WithContext returns a logger adding the request metadata carried by
+	// ctx to every line.
+	WithContext(ctx context.Context) Interface
+}
+
+// Options configures New.
+type Options struct {
+	// Level is the minimum level written: debug, info, warn or error.
+	// Defaults to info.
+	Level string
+	// Format is json, the default, or console for human-readable lines.
+	Format string
+	// Output defaults to os.Stdout.
+	Output io.Writer
 }
 
 type Logger struct {
-	logger *zerolog.Logger
+	logger zerolog.Logger
+	level  *Level
+	exit   func(int)
 }
 
 var _ Interface = (*Logger)(nil)
 
+// New returns a Logger writing to opts.Output.
+func New(opts Options) *Logger {
+	var w io.Writer = os.Stdout
+	if opts.Output != nil {
+		w = opts.Output
+	}
+	if strings.ToLower(opts.Format) == FormatConsole {
+		// Colors are only for the terminal, not for a given Output.
+		w = zerolog.ConsoleWriter{Out: w, TimeFormat: time.RFC3339, NoColor: opts.Output != nil}
+	}
+
+	return &Logger{
+		logger: zerolog.New(w).With().Timestamp().Logger(),
+		level:  newLevel(parseLevel(opts.Level)),
+		exit:   os.Exit,
+	}
+}
+
+// NewLogger returns a JSON Logger writing to os.Stdout at level.
 func NewLogger(level string) *Logger {
-	var l zerolog.Level
+	return New(Options{Level: level})
+}
 
+func parseLevel(level string) zerolog.Level {
 	switch strings.ToLower(level) {
 	case "error":
-		l = zerolog.ErrorLevel
+		return zerolog.ErrorLevel
 	case "warn":
-		l = zerolog.WarnLevel
-	case "info":
-		l = zerolog.InfoLevel
+		return zerolog.WarnLevel
 	case "debug":
-		l = zerolog.DebugLevel
+		return zerolog.DebugLevel
 	default:
-		l = zerolog.InfoLevel
+		return zerolog.InfoLevel
 	}
+}
 
-	zerolog.SetGlobalLevel(l)
+// Level returns the level of l, shared with the loggers derived from it, to
+// change it while they log.
+func (l *Logger) Level() *Level {
+	return l.level
+}
 
-	skipFrameCount := 3
-	logger := zerolog.New(os.Stdout).With().Timestamp().CallerWithSkipFrameCount(zerolog.CallerSkipFrameCount + skipFrameCount).Logger()
+func (l *Logger) Debug(msg string, args ...any) {
+	l.log(zerolog.DebugLevel, msg, args)
+}
 
-	return &Logger{
-		logger: &logger,
-	}
+func (l *Logger) Info(msg string, args ...any) {
+	l.log(zerolog.InfoLevel, msg, args)
+}
+
+func (l *Logger) Warn(msg string, args ...any) {
+	l.log(zerolog.WarnLevel, msg, args)
 }
 
-func (l *Logger) Debug(message interface{}, args ...interface{}) {
-	l.msg("debug", message, args...)
+func (l *Logger) Error(msg string, args ...any) {
+	l.log(zerolog.ErrorLevel, msg, args)
 }
 
-func (l *Logger) Info(message string, args ...interface{}) {
-	l.log(message, args...)
+func (l *Logger) Fatal(msg string, args ...any) {
+	l.log(zerolog.FatalLevel, msg, args)
+	l.exit(1)
 }
 
-func (l *Logger) Warn(message string, args ...interface{}) {
-	l.log(message, args...)
+func (l *Logger) With(args ...any) Interface {
+	return l.with(attrs(args))
 }
 
-func (l *Logger) Error(message interface{}, args ...interface{}) {
-	if l.logger.GetLevel() == zerolog.DebugLevel {
-		l.Debug(message, args...)
+// WithContext returns a logger adding the request ID, route and principal
+// subject found in ctx, if any, as request_id, route and principal fields,
+// and the current span as trace_id and span_id.
+func (l *Logger) WithContext(ctx context.Context) Interface {
+	return l.withContext(ctx)
+}
+
+func (l *Logger) withContext(ctx context.Context) *Logger {
+	as := make([]slog.Attr, 0, 5)
+	if id := reqctx.RequestID(ctx); id != "" {
+		as = append(as, slog.String("request_id", id))
+	}
+	if route := reqctx.Route(ctx); route != "" {
+		as = append(as, slog.String("route", route))
 	}
+	if p, ok := reqctx.Principal(ctx); ok {
+		as = append(as, slog.String("principal", p.Subject))
+	}
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		as = append(as, slog.String("trace_id", sc.TraceID().String()), slog.String("span_id", sc.SpanID().String()))
+	}
+	return l.with(as)
+}
 
-	l.msg("error", message, args...)
+func (l *Logger) with(as []slog.Attr) *Logger {
+	if len(as) == 0 {
+		return l
+	}
+	return &Logger{
+		logger: l.logger.With().Fields(fields(zerolog.NoLevel, as)).Logger(),
+		level:  l.level,
+		exit:   l.exit,
+	}
 }
 
-func (l *Logger) Fatal(message interface{}, args ...interface{}) {
-	l.msg("fatal", message, args...)
+func (l *Logger) log(level zerolog.Level, msg string, args []any) {
+	if !l.level.enabled(level) {
+		return
+	}
 
-	os.Exit(1)
+	// Skip runtime.Callers, log and the level method.
+	var pcs [1]uintptr
+	runtime.Callers(3, pcs[:])
+	l.write(level, pcs[0], msg, attrs(args))
 }
 
-func (l *Logger) log(message string, args ...interface{}) {
+// write is shared with the slog Handler, which brings its own caller.
+func (l *Logger) write(level zerolog.Level, pc uintptr, msg string, as []slog.Attr) {
+	if !l.level.enabled(level) {
+		return
+	}
+	e := l.logger.WithLevel(level)
+	if !e.Enabled() {
+		return
+	}
+	if pc != 0 {
+		f, _ := runtime.CallersFrames([]uintptr{pc}).Next()
+		e.Str(zerolog.CallerFieldName, f.File+":"+strconv.Itoa(f.Line))
+	}
+	if len(as) > 0 {
+		e.Fields(fields(level, as))
+	}
+	e.Msg(msg)
+}
+
+// attrs parses args the way slog.Logger does.
+func attrs(args []any) []slog.Attr {
 	if len(args) == 0 {
-		l.logger.Info().Msg(message)
-	} else {
-		l.logger.Info().Msgf(message, args...)
+		return nil
 	}
+	var r slog.Record
+	r.Add(args...)
+	as := make([]slog.Attr, 0, r.NumAttrs())
+	r.Attrs(func(a slog.Attr) bool {
+		as = append(as, a)
+		return true
+	})
+	return as
 }
 
-func (l *Logger) msg(level string, message interface{}, args ...interface{}) {
-	switch msg := message.(type) {
-	case error:
-		l.log(msg.Error(), args...)
-	case string:
-		l.log(msg, args...)
-	default:
-		l.log(fmt.Sprintf("%s message %v has unknown type %v", level, message, msg), args...)
+// fields flattens as into the key/value list zerolog expects, keeping their
+// order. Errors logged with Err carry their stack at error level and above.
+func fields(level zerolog.Level, as []slog.Attr) []any {
+	kv := make([]any, 0, 2*len(as))
+	for _, a := range as {
+		a.Value = a.Value.Resolve()
+		if a.Equal(slog.Attr{}) {
+			continue
+		}
+		if e, ok := a.Value.Any().(*errorValue); ok {
+			kv = append(kv, a.Key, e.err.Error())
+			if level >= zerolog.ErrorLevel && level != zerolog.NoLevel {
+				kv = append(kv, "stack", e.stack())
+			}
+			continue
+		}
+		if a.Value.Kind() == slog.KindGroup && a.Key == "" {
+			kv = append(kv, fields(level, a.Value.Group())...)
+			continue
+		}
+		kv = append(kv, a.Key, value(a.Value))
+	}
+	return kv
+}
+
+func value(v slog.Value) any {
+	switch v.Kind() {
+	case slog.KindGroup:
+		group := make(map[string]any, len(v.Group()))
+		for _, a := range v.Group() {
+			a.Value = a.Value.Resolve()
+			if a.Value.Kind() == slog.KindGroup && a.Key == "" {
+				for _, ia := range a.Value.Group() {
+					group[ia.Key] = value(ia.Value.Resolve())
+				}
+				continue
+			}
+			group[a.Key] = value(a.Value)
+		}
+		return group
+	case slog.KindAny:
+		switch x := v.Any().(type) {
+		case *errorValue:
+			return x.err.Error()
+		case error:
+			return x.Error()
+		}
+	}
+	return v.Any()
+}
+
+// Err returns an error field carrying the stack of its caller, which is
+// written next to the error when logged at error level or above.
+func Err(err error) slog.Attr {
+	if err == nil {
+		return slog.Any(zerolog.ErrorFieldName, nil)
+	}
+	e := &errorValue{err: err}
+	e.n = runtime.Callers(2, e.pcs[:])
+	return slog.Any(zerolog.ErrorFieldName, e)
+}
+
+type errorValue struct {
+	err error
+	pcs [32]uintptr
+	n   int
+}
+
+func (e *errorValue) Error() string {
+	return e.err.Error()
+}
+
+func (e *errorValue) Unwrap() error {
+	return e.err
+}
+
+func (e *errorValue) stack() []string {
+	stack := make([]string, 0, e.n)
+	frames := runtime.CallersFrames(e.pcs[:e.n])
+	for {
+		f, more := frames.Next()
+		stack = append(stack, f.Function+" "+f.File+":"+strconv.Itoa(f.Line))
+		if !more {
+			break
+		}
 	}
+	return stack
 }
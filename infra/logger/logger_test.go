@@ -2,13 +2,41 @@ package logger
 
 import (
 	"bytes"
-	"fmt"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"strings"
 	"testing"
 
-	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/entity"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/reqctx"
 )
 
+func newTestLogger(level string) (*Logger, *bytes.Buffer) {
+	buf := &bytes.Buffer{}
+	return New(Options{Level: level, Output: buf}), buf
+}
+
+// lines decodes the JSON lines written to buf.
+func lines(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	t.Helper()
+	var out []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var m map[string]any
+		require.NoError(t, json.Unmarshal([]byte(line), &m))
+		out = append(out, m)
+	}
+	return out
+}
+
 func TestNewLogger_Levels(t *testing.T) {
 	levels := []string{"debug", "info", "warn", "error", "unknown"}
 	for _, level := range levels {
@@ -17,92 +45,131 @@ func TestNewLogger_Levels(t *testing.T) {
 	}
 }
 
-func TestLogger_Info(t *testing.T) {
-	buf := &bytes.Buffer{}
-	zl := zerolog.New(buf)
-	l := &Logger{logger: &zl}
-	l.Info("hello world")
-	assert.Contains(t, buf.String(), "hello world")
-}
+func TestLogger_Levels(t *testing.T) {
+	l, buf := newTestLogger("debug")
 
-func TestLogger_InfoWithArgs(t *testing.T) {
-	buf := &bytes.Buffer{}
-	zl := zerolog.New(buf)
-	l := &Logger{logger: &zl}
-	l.Info("value: %d", 42)
-	assert.Contains(t, buf.String(), "42")
+	l.Debug("d")
+	l.Info("i")
+	l.Warn("w")
+	l.Error("e")
+
+	got := lines(t, buf)
+	require.Len(t, got, 4)
+	for i, level := range []string{"debug", "info", "warn", "error"} {
+		assert.Equal(t, level, got[i]["level"])
+		assert.Contains(t, got[i]["caller"], "logger_test.go:")
+	}
 }
 
-func TestLogger_Warn(t *testing.T) {
-	buf := &bytes.Buffer{}
-	zl := zerolog.New(buf)
-	l := &Logger{logger: &zl}
-	l.Warn("a warning")
-	assert.Contains(t, buf.String(), "a warning")
+func TestLogger_LevelFilter(t *testing.T) {
+	l, buf := newTestLogger("warn")
+
+	l.Debug("d")
+	l.Info("i")
+	l.Warn("w")
+
+	got := lines(t, buf)
+	require.Len(t, got, 1)
+	assert.Equal(t, "w", got[0]["message"])
 }
 
-func TestLogger_WarnWithArgs(t *testing.T) {
-	buf := &bytes.Buffer{}
-	zl := zerolog.New(buf)
-	l := &Logger{logger: &zl}
-	l.Warn("warn value: %s", "test")
-	assert.Contains(t, buf.String(), "test")
+func TestLogger_Fields(t *testing.T) {
+	l, buf := newTestLogger("info")
+
+	l.Info("user created", "id", "42", slog.Int("attempt", 2), slog.Group("req", "method", "POST"), "dangling")
+
+	got := lines(t, buf)[0]
+	assert.Equal(t, "user created", got["message"])
+	assert.Equal(t, "42", got["id"])
+	assert.Equal(t, float64(2), got["attempt"])
+	assert.Equal(t, map[string]any{"method": "POST"}, got["req"])
+	assert.Equal(t, "dangling", got["!BADKEY"])
 }
 
-func TestLogger_Debug(t *testing.T) {
-	buf := &bytes.Buffer{}
-	zerolog.SetGlobalLevel(zerolog.DebugLevel)
-	zl := zerolog.New(buf).Level(zerolog.DebugLevel)
-	l := &Logger{logger: &zl}
-	l.Debug("debug msg")
-	assert.Contains(t, buf.String(), "debug msg")
+func TestLogger_Err(t *testing.T) {
+	l, buf := newTestLogger("info")
+
+	// A message after an error is no longer taken as a format argument.
+	l.Error("http - v0 - get", Err(errors.New("boom")))
+	l.Warn("retrying", Err(errors.New("timeout")))
+
+	got := lines(t, buf)
+	require.Len(t, got, 2)
+	assert.Equal(t, "http - v0 - get", got[0]["message"])
+	assert.Equal(t, "boom", got[0]["error"])
+	require.NotEmpty(t, got[0]["stack"])
+	assert.Contains(t, got[0]["stack"].([]any)[0], "TestLogger_Err")
+	assert.Equal(t, "timeout", got[1]["error"])
+	assert.NotContains(t, got[1], "stack")
 }
 
-func TestLogger_DebugWithString(t *testing.T) {
-	buf := &bytes.Buffer{}
-	zerolog.SetGlobalLevel(zerolog.DebugLevel)
-	zl := zerolog.New(buf).Level(zerolog.DebugLevel)
-	l := &Logger{logger: &zl}
-	l.Debug("string message")
-	assert.Contains(t, buf.String(), "string message")
+func TestLogger_With(t *testing.T) {
+	l, buf := newTestLogger("info")
+
+	child := l.With("component", "outbox")
+	child.Info("relayed", "count", 3)
+	l.Info("plain")
+
+	got := lines(t, buf)
+	assert.Equal(t, "outbox", got[0]["component"])
+	assert.Equal(t, float64(3), got[0]["count"])
+	assert.NotContains(t, got[1], "component")
 }
 
-func TestLogger_DebugWithError(t *testing.T) {
-	buf := &bytes.Buffer{}
-	zerolog.SetGlobalLevel(zerolog.DebugLevel)
-	zl := zerolog.New(buf).Level(zerolog.DebugLevel)
-	l := &Logger{logger: &zl}
-	l.Debug(fmt.Errorf("error obj"))
-	assert.Contains(t, buf.String(), "error obj")
+func TestLogger_Fatal(t *testing.T) {
+	l, buf := newTestLogger("error")
+	var code int
+	l.exit = func(c int) { code = c }
+
+	l.Fatal("app - Run", Err(errors.New("no database")))
+
+	assert.Equal(t, 1, code)
+	got := lines(t, buf)[0]
+	assert.Equal(t, "fatal", got["level"])
+	assert.Equal(t, "no database", got["error"])
 }
 
-func TestLogger_Error_AtDebugLevel(t *testing.T) {
+func TestLogger_ConsoleFormat(t *testing.T) {
 	buf := &bytes.Buffer{}
-	zerolog.SetGlobalLevel(zerolog.DebugLevel)
-	zl := zerolog.New(buf).Level(zerolog.DebugLevel)
-	l := &Logger{logger: &zl}
-	l.Error(fmt.Errorf("something went wrong"))
-	assert.Contains(t, buf.String(), "something went wrong")
+	l := New(Options{Format: FormatConsole, Output: buf})
+
+	l.Warn("slow query", "elapsed", "250ms")
+
+	assert.Contains(t, buf.String(), "WRN")
+	assert.Contains(t, buf.String(), "slow query")
+	assert.Contains(t, buf.String(), "elapsed=250ms")
+	assert.False(t, json.Valid(buf.Bytes()))
 }
 
-func TestLogger_Error_WithString(t *testing.T) {
-	buf := &bytes.Buffer{}
-	zl := zerolog.New(buf)
-	l := &Logger{logger: &zl}
-	l.Error("string error")
-	assert.Contains(t, buf.String(), "string error")
+func TestLogger_WithContext(t *testing.T) {
+	l, buf := newTestLogger("info")
+
+	ctx := reqctx.WithRequestID(context.Background(), "req-1")
+	ctx = reqctx.WithRoute(ctx, "GET /v0/user/:id")
+	ctx = reqctx.WithPrincipal(ctx, entity.Principal{Subject: "user-1"})
+	l.WithContext(ctx).Error("http - v0 - getUser", Err(errors.New("boom")))
+
+	got := lines(t, buf)[0]
+	assert.Equal(t, "req-1", got["request_id"])
+	assert.Equal(t, "GET /v0/user/:id", got["route"])
+	assert.Equal(t, "user-1", got["principal"])
+	assert.Equal(t, "boom", got["error"])
 }
 
-func TestLogger_Error_UnknownType(t *testing.T) {
-	buf := &bytes.Buffer{}
-	zl := zerolog.New(buf)
-	l := &Logger{logger: &zl}
-	l.Error(12345)
-	assert.Contains(t, buf.String(), "unknown type")
+func TestLogger_WithContext_Span(t *testing.T) {
+	l, buf := newTestLogger("info")
+	traceID, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	spanID, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+	ctx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{TraceID: traceID, SpanID: spanID}))
+
+	l.WithContext(ctx).Info("traced")
+
+	got := lines(t, buf)[0]
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", got["trace_id"])
+	assert.Equal(t, "00f067aa0ba902b7", got["span_id"])
 }
 
-func TestLogger_Fatal(t *testing.T) {
-	if !testing.Short() {
-		t.Skip("Skipping Fatal test that calls os.Exit")
-	}
+func TestLogger_WithContext_Empty(t *testing.T) {
+	l := NewLogger("error")
+	assert.Same(t, l, l.WithContext(context.Background()))
 }
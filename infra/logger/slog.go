@@ -0,0 +1,78 @@
+package logger
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/rs/zerolog"
+)
+
+// Handler is a slog.Handler writing through a Logger, so that libraries
+// logging with log/slog share its output, level and request metadata.
+// Groups are flattened into dotted keys.
+type Handler struct {
+	l      *Logger
+	prefix string
+}
+
+var _ slog.Handler = (*Handler)(nil)
+
+// NewHandler returns a Handler writing to l.
+func NewHandler(l *Logger) *Handler {
+	return &Handler{l: l}
+}
+
+// Slog returns a slog.Logger writing to l.
+func (l *Logger) Slog() *slog.Logger {
+	return slog.New(NewHandler(l))
+}
+
+func (h *Handler) Enabled(_ context.Context, level slog.Level) bool {
+	return h.l.level.enabled(zerologLevel(level))
+}
+
+func (h *Handler) Handle(ctx context.Context, r slog.Record) error {
+	as := make([]slog.Attr, 0, r.NumAttrs())
+	r.Attrs(func(a slog.Attr) bool {
+		as = append(as, h.prefixed(a))
+		return true
+	})
+	h.l.withContext(ctx).write(zerologLevel(r.Level), r.PC, r.Message, as)
+	return nil
+}
+
+func (h *Handler) WithAttrs(as []slog.Attr) slog.Handler {
+	prefixed := make([]slog.Attr, len(as))
+	for i, a := range as {
+		prefixed[i] = h.prefixed(a)
+	}
+	return &Handler{l: h.l.with(prefixed), prefix: h.prefix}
+}
+
+func (h *Handler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	return &Handler{l: h.l, prefix: h.prefix + name + "."}
+}
+
+func (h *Handler) prefixed(a slog.Attr) slog.Attr {
+	if h.prefix == "" || a.Key == "" {
+		return a
+	}
+	a.Key = h.prefix + a.Key
+	return a
+}
+
+func zerologLevel(level slog.Level) zerolog.Level {
+	switch {
+	case level < slog.LevelInfo:
+		return zerolog.DebugLevel
+	case level < slog.LevelWarn:
+		return zerolog.InfoLevel
+	case level < slog.LevelError:
+		return zerolog.WarnLevel
+	default:
+		return zerolog.ErrorLevel
+	}
+}
@@ -0,0 +1,40 @@
+package logger
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/reqctx"
+)
+
+func TestHandler(t *testing.T) {
+	l, buf := newTestLogger("info")
+	s := l.Slog().With("lib", "pgx").WithGroup("conn")
+
+	ctx := reqctx.WithRequestID(context.Background(), "req-1")
+	s.DebugContext(ctx, "hidden")
+	s.WarnContext(ctx, "reconnecting", "attempt", 2, "err", errors.New("reset"))
+
+	got := lines(t, buf)
+	require.Len(t, got, 1)
+	assert.Equal(t, "warn", got[0]["level"])
+	assert.Equal(t, "reconnecting", got[0]["message"])
+	assert.Equal(t, "pgx", got[0]["lib"])
+	assert.Equal(t, float64(2), got[0]["conn.attempt"])
+	assert.Equal(t, "reset", got[0]["conn.err"])
+	assert.Equal(t, "req-1", got[0]["request_id"])
+	assert.Contains(t, got[0]["caller"], "slog_test.go:")
+}
+
+func TestHandler_Enabled(t *testing.T) {
+	h := NewHandler(NewLogger("warn"))
+
+	assert.False(t, h.Enabled(context.Background(), slog.LevelInfo))
+	assert.True(t, h.Enabled(context.Background(), slog.LevelWarn))
+	assert.True(t, h.Enabled(context.Background(), slog.LevelError+4))
+}
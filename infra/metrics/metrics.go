@@ -0,0 +1,111 @@
+// Package metrics collects the Prometheus metrics of the application.
+package metrics
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/apperror"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/usecase"
+)
+
+const namespace = "app"
+
+// Metrics holds the metrics of the application in a registry of its own,
+// with the Go runtime and process collectors.
+type Metrics struct {
+	registry        *prometheus.Registry
+	httpRequests    *prometheus.CounterVec
+	httpDuration    *prometheus.HistogramVec
+	useCaseCalls    *prometheus.CounterVec
+	useCaseErrors   *prometheus.CounterVec
+	useCaseDuration *prometheus.HistogramVec
+}
+
+var _ usecase.Observer = (*Metrics)(nil)
+
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		httpRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "http_requests_total",
+			Help:      "HTTP requests by method, route template and status code.",
+		}, []string{"method", "route", "status"}),
+		httpDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "HTTP request latency by method, route template and status code.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route", "status"}),
+		useCaseCalls: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "usecase_calls_total",
+			Help:      "Use case calls by use case and method.",
+		}, []string{"usecase", "method"}),
+		useCaseErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "usecase_errors_total",
+			Help:      "Use case calls that failed, by use case, method and error kind.",
+		}, []string{"usecase", "method", "kind"}),
+		useCaseDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "usecase_duration_seconds",
+			Help:      "Use case latency by use case and method.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"usecase", "method"}),
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.httpRequests,
+		m.httpDuration,
+		m.useCaseCalls,
+		m.useCaseErrors,
+		m.useCaseDuration,
+	)
+
+	return m
+}
+
+// Handler serves the metrics in the Prometheus exposition format.
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{Registry: m.registry})
+}
+
+// RegisterDB exports the connection pool statistics of db, such as open,
+// in-use and idle connections and the waits for one, as go_sql_* metrics
+// labeled with name.
+func (m *Metrics) RegisterDB(name string, db *sql.DB) error {
+	return m.registry.Register(collectors.NewDBStatsCollector(db, name))
+}
+
+// ObserveHTTP records a served request. route is the route template, such
+// as /v0/user/:id, so that the number of series stays bounded.
+func (m *Metrics) ObserveHTTP(method, route string, status int, elapsed time.Duration) {
+	code := strconv.Itoa(status)
+	m.httpRequests.WithLabelValues(method, route, code).Inc()
+	m.httpDuration.WithLabelValues(method, route, code).Observe(elapsed.Seconds())
+}
+
+// Start counts a use case call and times it. Errors are counted by their
+// apperror kind.
+func (m *Metrics) Start(ctx context.Context, useCase, method string) (context.Context, func(error)) {
+	m.useCaseCalls.WithLabelValues(useCase, method).Inc()
+	begin := time.Now()
+	return ctx, func(err error) {
+		m.useCaseDuration.WithLabelValues(useCase, method).Observe(time.Since(begin).Seconds())
+		if err == nil {
+			return
+		}
+		m.useCaseErrors.WithLabelValues(useCase, method, apperror.KindOf(err).String()).Inc()
+	}
+}
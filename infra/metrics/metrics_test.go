@@ -0,0 +1,67 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/apperror"
+)
+
+func scrape(t *testing.T, m *Metrics) string {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	m.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", http.NoBody))
+	require.Equal(t, http.StatusOK, rec.Code)
+	return rec.Body.String()
+}
+
+func TestMetrics_HTTP(t *testing.T) {
+	m := New()
+
+	m.ObserveHTTP(http.MethodGet, "/v0/user/:id", http.StatusOK, 20*time.Millisecond)
+	m.ObserveHTTP(http.MethodGet, "/v0/user/:id", http.StatusOK, 30*time.Millisecond)
+	m.ObserveHTTP(http.MethodPost, "/v0/user", http.StatusConflict, time.Millisecond)
+
+	body := scrape(t, m)
+	assert.Contains(t, body, `app_http_requests_total{method="GET",route="/v0/user/:id",status="200"} 2`)
+	assert.Contains(t, body, `app_http_requests_total{method="POST",route="/v0/user",status="409"} 1`)
+	assert.Contains(t, body, `app_http_request_duration_seconds_count{method="GET",route="/v0/user/:id",status="200"} 2`)
+	assert.Contains(t, body, "go_goroutines")
+}
+
+func TestMetrics_UseCase(t *testing.T) {
+	m := New()
+
+	for _, err := range []error{nil, apperror.NotFound("user not found", nil), errors.New("connection reset")} {
+		_, done := m.Start(context.Background(), "user", "GetUserById")
+		done(err)
+	}
+
+	body := scrape(t, m)
+	assert.Contains(t, body, `app_usecase_calls_total{method="GetUserById",usecase="user"} 3`)
+	assert.Contains(t, body, `app_usecase_errors_total{kind="not_found",method="GetUserById",usecase="user"} 1`)
+	assert.Contains(t, body, `app_usecase_errors_total{kind="internal_error",method="GetUserById",usecase="user"} 1`)
+	assert.Contains(t, body, `app_usecase_duration_seconds_count{method="GetUserById",usecase="user"} 3`)
+}
+
+func TestMetrics_RegisterDB(t *testing.T) {
+	db, _, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+	m := New()
+
+	require.NoError(t, m.RegisterDB("postgres", db))
+
+	body := scrape(t, m)
+	for _, name := range []string{"go_sql_open_connections", "go_sql_in_use_connections", "go_sql_idle_connections", "go_sql_wait_count_total", "go_sql_wait_duration_seconds_total"} {
+		assert.Contains(t, body, name+`{db_name="postgres"}`)
+	}
+}
@@ -0,0 +1,95 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"time"
+
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+
+	"github.com/DeSouzaRafael/go-clean-architecture-template/infra/logger"
+)
+
+// slowQueryThreshold is the duration above which a query is logged.
+const slowQueryThreshold = 200 * time.Millisecond
+
+// explainedPlaceholder matches the "$1$" GORM leaves in place of a filtered
+// out parameter.
+var explainedPlaceholder = regexp.MustCompile(`\$(\d+)\$`)
+
+// queryLogger writes GORM logs through logger.Interface, with the request
+// metadata of the query context, so a failing or slow query can be tied to
+// the request that ran it. Query errors are warnings: the repository decides
+// whether they are expected, such as a unique violation, and the transport
+// logs the internal ones again.
+type queryLogger struct {
+	l     logger.Interface
+	level gormlogger.LogLevel
+}
+
+var (
+	_ gormlogger.Interface = (*queryLogger)(nil)
+	_ gorm.ParamsFilter    = (*queryLogger)(nil)
+)
+
+func newQueryLogger(l logger.Interface) *queryLogger {
+	return &queryLogger{l: l, level: gormlogger.Warn}
+}
+
+func (q *queryLogger) LogMode(level gormlogger.LogLevel) gormlogger.Interface {
+	return &queryLogger{l: q.l, level: level}
+}
+
+func (q *queryLogger) Info(ctx context.Context, msg string, data ...interface{}) {
+	if q.level >= gormlogger.Info {
+		q.l.WithContext(ctx).Info(fmt.Sprintf(msg, data...))
+	}
+}
+
+func (q *queryLogger) Warn(ctx context.Context, msg string, data ...interface{}) {
+	if q.level >= gormlogger.Warn {
+		q.l.WithContext(ctx).Warn(fmt.Sprintf(msg, data...))
+	}
+}
+
+func (q *queryLogger) Error(ctx context.Context, msg string, data ...interface{}) {
+	if q.level >= gormlogger.Error {
+		q.l.WithContext(ctx).Error(fmt.Sprintf(msg, data...))
+	}
+}
+
+func (q *queryLogger) Trace(ctx context.Context, begin time.Time, fc func() (string, int64), err error) {
+	if q.level <= gormlogger.Silent {
+		return
+	}
+
+	elapsed := time.Since(begin)
+	fc = explained(fc)
+	switch {
+	case err != nil && !errors.Is(err, gorm.ErrRecordNotFound) && q.level >= gormlogger.Error:
+		sql, rows := fc()
+		q.l.WithContext(ctx).Warn("postgres - query failed", logger.Err(err), "elapsed", elapsed, "rows", rows, "sql", sql)
+	case elapsed > slowQueryThreshold && q.level >= gormlogger.Warn:
+		sql, rows := fc()
+		q.l.WithContext(ctx).Warn("postgres - slow query", "elapsed", elapsed, "rows", rows, "sql", sql)
+	case q.level >= gormlogger.Info:
+		sql, rows := fc()
+		q.l.WithContext(ctx).Debug("postgres - query", "elapsed", elapsed, "rows", rows, "sql", sql)
+	}
+}
+
+func explained(fc func() (string, int64)) func() (string, int64) {
+	return func() (string, int64) {
+		sql, rows := fc()
+		return explainedPlaceholder.ReplaceAllString(sql, "$$$1"), rows
+	}
+}
+
+// ParamsFilter keeps query parameters, which may hold personal data, out of
+// the logs.
+func (q *queryLogger) ParamsFilter(_ context.Context, sql string, _ ...interface{}) (string, []interface{}) {
+	return sql, nil
+}
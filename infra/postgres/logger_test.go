@@ -0,0 +1,47 @@
+package postgres
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gormpostgres "gorm.io/driver/postgres"
+	"gorm.io/gorm"
+
+	"github.com/DeSouzaRafael/go-clean-architecture-template/infra/logger"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/reqctx"
+)
+
+func TestQueryLogger(t *testing.T) {
+	sqlDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer sqlDB.Close()
+	buf := &bytes.Buffer{}
+	db, err := gorm.Open(gormpostgres.New(gormpostgres.Config{Conn: sqlDB}), &gorm.Config{Logger: newQueryLogger(logger.New(logger.Options{Output: buf}))})
+	require.NoError(t, err)
+
+	mock.ExpectQuery(`SELECT`).WillReturnError(errors.New("connection reset"))
+	mock.ExpectQuery(`SELECT`).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	ctx := reqctx.WithRequestID(context.Background(), "req-1")
+	var users []struct{ ID string }
+	db.WithContext(ctx).Table("user").Where("email = ?", "ana@example.com").Find(&users)
+	db.WithContext(ctx).Table("user").Where("email = ?", "ana@example.com").Find(&users)
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 1)
+	var line map[string]any
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &line))
+	assert.Equal(t, "warn", line["level"])
+	assert.Equal(t, "postgres - query failed", line["message"])
+	assert.Equal(t, "req-1", line["request_id"])
+	assert.Equal(t, `SELECT * FROM "user" WHERE email = $1`, line["sql"])
+	assert.Equal(t, "connection reset", line["error"])
+	assert.NotContains(t, buf.String(), "ana@example.com")
+}
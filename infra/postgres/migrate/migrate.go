@@ -0,0 +1,261 @@
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/fs"
+	"sort"
+	"time"
+)
+
+// lockID is the Postgres advisory lock key held while migrating, so that
+// replicas starting together apply each migration once.
+const lockID int64 = 7_265_636_572_617_465
+
+const createTable = `CREATE TABLE IF NOT EXISTS schema_migrations (
+    version    bigint PRIMARY KEY,
+    name       text NOT NULL,
+    checksum   text NOT NULL,
+    applied_at timestamptz NOT NULL DEFAULT now()
+)`
+
+var ErrChecksumMismatch = errors.New("migration changed after it was applied")
+
+type State string
+
+const (
+	StatePending  State = "pending"
+	StateApplied  State = "applied"
+	StateModified State = "modified"
+	// StateUnknown is a version applied to the database but missing from
+	// this build, typically after a rollback to an older release.
+	StateUnknown State = "unknown"
+)
+
+type Status struct {
+	Version   int64
+	Name      string
+	State     State
+	AppliedAt *time.Time
+}
+
+type record struct {
+	version   int64
+	name      string
+	checksum  string
+	appliedAt time.Time
+}
+
+type Migrator struct {
+	db         *sql.DB
+	migrations []Migration
+}
+
+func New(db *sql.DB, fsys fs.FS) (*Migrator, error) {
+	migrations, err := Load(fsys)
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{db: db, migrations: migrations}, nil
+}
+
+// Up applies all pending migrations in order, each in its own transaction,
+// and returns the applied ones. Nothing is applied if an applied migration
+// was modified or is unknown.
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+	var applied []Migration
+	err := m.withLock(ctx, func(conn *sql.Conn, records map[int64]record) error {
+		if err := m.verify(records); err != nil {
+			return err
+		}
+		for _, mig := range m.migrations {
+			if _, ok := records[mig.Version]; ok {
+				continue
+			}
+			err := inTx(ctx, conn, mig.Up,
+				`INSERT INTO schema_migrations (version, name, checksum) VALUES ($1, $2, $3)`, mig.Version, mig.Name, mig.Checksum())
+			if err != nil {
+				return fmt.Errorf("migration %d_%s: %w", mig.Version, mig.Name, err)
+			}
+			applied = append(applied, mig)
+		}
+		return nil
+	})
+	if err != nil {
+		return applied, fmt.Errorf("migrate - Up: %w", err)
+	}
+	return applied, nil
+}
+
+// Down reverts the last steps applied migrations, newest first, and returns
+// the reverted ones.
+func (m *Migrator) Down(ctx context.Context, steps int) ([]Migration, error) {
+	var reverted []Migration
+	err := m.withLock(ctx, func(conn *sql.Conn, records map[int64]record) error {
+		if err := m.verify(records); err != nil {
+			return err
+		}
+		for i := len(m.migrations) - 1; i >= 0 && len(reverted) < steps; i-- {
+			mig := m.migrations[i]
+			if _, ok := records[mig.Version]; !ok {
+				continue
+			}
+			if mig.Down == "" {
+				return fmt.Errorf("migration %d_%s has no down script", mig.Version, mig.Name)
+			}
+			err := inTx(ctx, conn, mig.Down, `DELETE FROM schema_migrations WHERE version = $1`, mig.Version)
+			if err != nil {
+				return fmt.Errorf("migration %d_%s: %w", mig.Version, mig.Name, err)
+			}
+			reverted = append(reverted, mig)
+		}
+		return nil
+	})
+	if err != nil {
+		return reverted, fmt.Errorf("migrate - Down: %w", err)
+	}
+	return reverted, nil
+}
+
+// Status lists every known or applied migration by version.
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	var statuses []Status
+	err := m.withLock(ctx, func(_ *sql.Conn, records map[int64]record) error {
+		known := make(map[int64]bool, len(m.migrations))
+		for _, mig := range m.migrations {
+			known[mig.Version] = true
+			s := Status{Version: mig.Version, Name: mig.Name, State: StatePending}
+			if r, ok := records[mig.Version]; ok {
+				s.State = StateApplied
+				if r.checksum != mig.Checksum() {
+					s.State = StateModified
+				}
+				s.AppliedAt = &r.appliedAt
+			}
+			statuses = append(statuses, s)
+		}
+		for _, r := range records {
+			if !known[r.version] {
+				appliedAt := r.appliedAt
+				statuses = append(statuses, Status{Version: r.version, Name: r.name, State: StateUnknown, AppliedAt: &appliedAt})
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("migrate - Status: %w", err)
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Version < statuses[j].Version })
+	return statuses, nil
+}
+
+// Verify returns an error unless every migration of this build is applied
+// unchanged. It reads schema_migrations without taking the lock, so it is
+// cheap enough for a readiness check. Versions unknown to this build are
+// ignored, as an older release keeps working while a newer one rolls out.
+func (m *Migrator) Verify(ctx context.Context) error {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("migrate - Verify: %w", err)
+	}
+	defer conn.Close()
+
+	records, err := readRecords(ctx, conn)
+	if err != nil {
+		return fmt.Errorf("migrate - Verify: %w", err)
+	}
+	for _, mig := range m.migrations {
+		r, ok := records[mig.Version]
+		if !ok {
+			return fmt.Errorf("migrate - Verify: %d_%s is pending", mig.Version, mig.Name)
+		}
+		if r.checksum != mig.Checksum() {
+			return fmt.Errorf("migrate - Verify: %w: %d_%s", ErrChecksumMismatch, mig.Version, mig.Name)
+		}
+	}
+	return nil
+}
+
+func (m *Migrator) verify(records map[int64]record) error {
+	known := make(map[int64]Migration, len(m.migrations))
+	for _, mig := range m.migrations {
+		known[mig.Version] = mig
+	}
+	for _, r := range records {
+		mig, ok := known[r.version]
+		if !ok {
+			return fmt.Errorf("applied migration %d_%s is unknown to this build", r.version, r.name)
+		}
+		if r.checksum != mig.Checksum() {
+			return fmt.Errorf("%w: %d_%s", ErrChecksumMismatch, mig.Version, mig.Name)
+		}
+	}
+	return nil
+}
+
+// withLock runs fn on a dedicated connection holding the advisory lock, with
+// the applied migrations read after the lock was acquired.
+func (m *Migrator) withLock(ctx context.Context, fn func(*sql.Conn, map[int64]record) error) error {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, `SELECT pg_advisory_lock($1)`, lockID); err != nil {
+		return fmt.Errorf("acquire lock: %w", err)
+	}
+	defer func() {
+		// Closing the session would release it too, but the connection
+		// goes back to the pool.
+		_, _ = conn.ExecContext(context.WithoutCancel(ctx), `SELECT pg_advisory_unlock($1)`, lockID)
+	}()
+
+	if _, err := conn.ExecContext(ctx, createTable); err != nil {
+		return fmt.Errorf("create schema_migrations: %w", err)
+	}
+
+	records, err := readRecords(ctx, conn)
+	if err != nil {
+		return err
+	}
+
+	return fn(conn, records)
+}
+
+func readRecords(ctx context.Context, conn *sql.Conn) (map[int64]record, error) {
+	rows, err := conn.QueryContext(ctx, `SELECT version, name, checksum, applied_at FROM schema_migrations`)
+	if err != nil {
+		return nil, fmt.Errorf("read schema_migrations: %w", err)
+	}
+	defer rows.Close()
+
+	records := make(map[int64]record)
+	for rows.Next() {
+		var r record
+		if err := rows.Scan(&r.version, &r.name, &r.checksum, &r.appliedAt); err != nil {
+			return nil, fmt.Errorf("read schema_migrations: %w", err)
+		}
+		records[r.version] = r
+	}
+	return records, rows.Err()
+}
+
+// inTx runs script and then the bookkeeping statement in one transaction.
+func inTx(ctx context.Context, conn *sql.Conn, script, bookkeeping string, args ...any) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, script); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, bookkeeping, args...); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
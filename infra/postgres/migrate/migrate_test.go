@@ -0,0 +1,196 @@
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testFS = fstest.MapFS{
+	"0001_create_a.up.sql":   {Data: []byte("CREATE TABLE a ();")},
+	"0001_create_a.down.sql": {Data: []byte("DROP TABLE a;")},
+	"0002_create_b.up.sql":   {Data: []byte("CREATE TABLE b ();")},
+	"0002_create_b.down.sql": {Data: []byte("DROP TABLE b;")},
+}
+
+func newMigrator(t *testing.T) (*Migrator, sqlmock.Sqlmock, []Migration) {
+	t.Helper()
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	m, err := New(db, testFS)
+	require.NoError(t, err)
+	return m, mock, m.migrations
+}
+
+func q(sql string) string { return regexp.QuoteMeta(sql) }
+
+// expectLocked expects the lock, table creation and the read of rows.
+func expectLocked(mock sqlmock.Sqlmock, rows *sqlmock.Rows) {
+	mock.ExpectExec(q(`SELECT pg_advisory_lock($1)`)).WithArgs(lockID).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(q(`CREATE TABLE IF NOT EXISTS schema_migrations`)).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(q(`SELECT version, name, checksum, applied_at FROM schema_migrations`)).WillReturnRows(rows)
+}
+
+func expectUnlock(mock sqlmock.Sqlmock) {
+	mock.ExpectExec(q(`SELECT pg_advisory_unlock($1)`)).WithArgs(lockID).WillReturnResult(sqlmock.NewResult(0, 0))
+}
+
+func appliedRows(ms ...Migration) *sqlmock.Rows {
+	rows := sqlmock.NewRows([]string{"version", "name", "checksum", "applied_at"})
+	for _, m := range ms {
+		rows.AddRow(m.Version, m.Name, m.Checksum(), time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	}
+	return rows
+}
+
+func TestMigrator_Up(t *testing.T) {
+	m, mock, ms := newMigrator(t)
+
+	expectLocked(mock, appliedRows(ms[0]))
+	mock.ExpectBegin()
+	mock.ExpectExec(q("CREATE TABLE b ();")).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(q(`INSERT INTO schema_migrations`)).WithArgs(int64(2), "create_b", ms[1].Checksum()).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	expectUnlock(mock)
+
+	applied, err := m.Up(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, []Migration{ms[1]}, applied)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMigrator_Up_FailureRollsBack(t *testing.T) {
+	m, mock, _ := newMigrator(t)
+
+	expectLocked(mock, appliedRows())
+	mock.ExpectBegin()
+	mock.ExpectExec(q("CREATE TABLE a ();")).WillReturnError(sql.ErrConnDone)
+	mock.ExpectRollback()
+	expectUnlock(mock)
+
+	applied, err := m.Up(context.Background())
+
+	assert.ErrorIs(t, err, sql.ErrConnDone)
+	assert.Contains(t, err.Error(), "migration 1_create_a")
+	assert.Empty(t, applied)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMigrator_Up_ChecksumMismatch(t *testing.T) {
+	m, mock, ms := newMigrator(t)
+
+	modified := ms[0]
+	modified.Up = "CREATE TABLE a (id int);"
+	expectLocked(mock, appliedRows(modified))
+	expectUnlock(mock)
+
+	_, err := m.Up(context.Background())
+
+	assert.ErrorIs(t, err, ErrChecksumMismatch)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMigrator_Up_UnknownVersion(t *testing.T) {
+	m, mock, _ := newMigrator(t)
+
+	expectLocked(mock, appliedRows(Migration{Version: 3, Name: "future", Up: "x"}))
+	expectUnlock(mock)
+
+	_, err := m.Up(context.Background())
+
+	assert.ErrorContains(t, err, "applied migration 3_future is unknown to this build")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMigrator_Down(t *testing.T) {
+	m, mock, ms := newMigrator(t)
+
+	expectLocked(mock, appliedRows(ms...))
+	mock.ExpectBegin()
+	mock.ExpectExec(q("DROP TABLE b;")).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(q(`DELETE FROM schema_migrations WHERE version = $1`)).WithArgs(int64(2)).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	expectUnlock(mock)
+
+	reverted, err := m.Down(context.Background(), 1)
+
+	require.NoError(t, err)
+	assert.Equal(t, []Migration{ms[1]}, reverted)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMigrator_Status(t *testing.T) {
+	m, mock, ms := newMigrator(t)
+
+	modified := ms[1]
+	modified.Up = "changed"
+	expectLocked(mock, appliedRows(ms[0], modified, Migration{Version: 7, Name: "future", Up: "x"}))
+	expectUnlock(mock)
+
+	statuses, err := m.Status(context.Background())
+
+	require.NoError(t, err)
+	require.Len(t, statuses, 3)
+	assert.Equal(t, StateApplied, statuses[0].State)
+	assert.Equal(t, StateModified, statuses[1].State)
+	assert.Equal(t, Status{Version: 7, Name: "future", State: StateUnknown, AppliedAt: statuses[2].AppliedAt}, statuses[2])
+	assert.NotNil(t, statuses[0].AppliedAt)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMigrator_Status_Pending(t *testing.T) {
+	m, mock, _ := newMigrator(t)
+
+	expectLocked(mock, appliedRows())
+	expectUnlock(mock)
+
+	statuses, err := m.Status(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, []Status{
+		{Version: 1, Name: "create_a", State: StatePending},
+		{Version: 2, Name: "create_b", State: StatePending},
+	}, statuses)
+}
+
+func TestMigrator_Verify(t *testing.T) {
+	m, mock, ms := newMigrator(t)
+
+	mock.ExpectQuery(q(`SELECT version, name, checksum, applied_at FROM schema_migrations`)).
+		WillReturnRows(appliedRows(ms[0], ms[1], Migration{Version: 7, Name: "future", Up: "x"}))
+
+	assert.NoError(t, m.Verify(context.Background()))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMigrator_Verify_Pending(t *testing.T) {
+	m, mock, ms := newMigrator(t)
+
+	mock.ExpectQuery(q(`SELECT version, name, checksum, applied_at FROM schema_migrations`)).WillReturnRows(appliedRows(ms[0]))
+
+	err := m.Verify(context.Background())
+
+	assert.ErrorContains(t, err, "2_create_b is pending")
+}
+
+func TestMigrator_Verify_ChecksumMismatch(t *testing.T) {
+	m, mock, ms := newMigrator(t)
+
+	modified := ms[0]
+	modified.Up = "changed"
+	mock.ExpectQuery(q(`SELECT version, name, checksum, applied_at FROM schema_migrations`)).WillReturnRows(appliedRows(modified, ms[1]))
+
+	err := m.Verify(context.Background())
+
+	assert.ErrorIs(t, err, ErrChecksumMismatch)
+}
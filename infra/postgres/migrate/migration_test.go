@@ -0,0 +1,63 @@
+package migrate
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/DeSouzaRafael/go-clean-architecture-template/infra/postgres/migrations"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad(t *testing.T) {
+	fsys := fstest.MapFS{
+		"0010_add_email.up.sql":     {Data: []byte("ALTER TABLE t ADD email text;")},
+		"0002_create_t.up.sql":      {Data: []byte("CREATE TABLE t ();")},
+		"0002_create_t.down.sql":    {Data: []byte("DROP TABLE t;")},
+		"README.md":                 {Data: []byte("ignored")},
+		"0003_not_sql.up.txt":       {Data: []byte("ignored")},
+		"nested/0004_x.up.sql":      {Data: []byte("ignored")},
+		"0010_add_email.down.sql.b": {Data: []byte("ignored")},
+	}
+
+	ms, err := Load(fsys)
+
+	require.NoError(t, err)
+	assert.Equal(t, []Migration{
+		{Version: 2, Name: "create_t", Up: "CREATE TABLE t ();", Down: "DROP TABLE t;"},
+		{Version: 10, Name: "add_email", Up: "ALTER TABLE t ADD email text;"},
+	}, ms)
+}
+
+func TestLoad_Invalid(t *testing.T) {
+	tests := map[string]fstest.MapFS{
+		"missing up": {"0001_a.down.sql": {Data: []byte("x")}},
+		"duplicate":  {"0001_a.up.sql": {Data: []byte("x")}, "0001_b.up.sql": {Data: []byte("y")}},
+		"zero":       {"0000_a.up.sql": {Data: []byte("x")}},
+	}
+	for name, fsys := range tests {
+		_, err := Load(fsys)
+		assert.Error(t, err, name)
+	}
+}
+
+func TestLoad_Embedded(t *testing.T) {
+	ms, err := Load(migrations.FS)
+
+	require.NoError(t, err)
+	require.NotEmpty(t, ms)
+	for i, m := range ms {
+		assert.Equal(t, int64(i+1), m.Version, "migrations are numbered without gaps")
+		assert.NotEmpty(t, m.Down, "migration %d_%s has a down script", m.Version, m.Name)
+	}
+}
+
+func TestMigration_Checksum(t *testing.T) {
+	a := Migration{Up: "CREATE TABLE t ();", Down: "DROP TABLE t;"}
+	b := Migration{Up: "CREATE TABLE t ();"}
+	c := Migration{Up: "CREATE TABLE t (id int);"}
+
+	assert.Len(t, a.Checksum(), 64)
+	assert.Equal(t, a.Checksum(), b.Checksum())
+	assert.NotEqual(t, a.Checksum(), c.Checksum())
+}
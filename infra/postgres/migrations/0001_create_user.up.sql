@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS "user" (
+    "id"         uuid DEFAULT gen_random_uuid(),
+    "name"       text,
+    "phone"      text,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+
+CREATE INDEX IF NOT EXISTS "idx_user_deleted_at" ON "user" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_user_created_at_id" ON "user" ("created_at", "id");
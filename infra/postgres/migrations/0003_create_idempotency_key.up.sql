@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS "idempotency_key" (
+    "key"         varchar(255),
+    "fingerprint" text NOT NULL,
+    "status_code" bigint,
+    "header"      jsonb,
+    "body"        bytea,
+    "created_at"  timestamptz NOT NULL,
+    "expires_at"  timestamptz NOT NULL,
+    PRIMARY KEY ("key")
+);
+
+CREATE INDEX IF NOT EXISTS "idx_idempotency_key_expires_at" ON "idempotency_key" ("expires_at");
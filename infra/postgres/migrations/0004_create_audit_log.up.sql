@@ -0,0 +1,28 @@
+CREATE TABLE IF NOT EXISTS "audit_log" (
+    "id"          uuid DEFAULT gen_random_uuid(),
+    "entity_type" text NOT NULL,
+    "entity_id"   uuid NOT NULL,
+    "action"      text NOT NULL,
+    "actor"       text NOT NULL,
+    "request_id"  text,
+    "changes"     jsonb NOT NULL,
+    "created_at"  timestamptz NOT NULL,
+    PRIMARY KEY ("id")
+);
+
+CREATE INDEX IF NOT EXISTS "idx_audit_log_entity" ON "audit_log" ("entity_type", "entity_id", "created_at");
+
+-- The audit log is append-only: rows can be inserted but never changed or removed.
+CREATE OR REPLACE FUNCTION "audit_log_append_only"() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'audit_log is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER "audit_log_no_update_delete"
+    BEFORE UPDATE OR DELETE ON "audit_log"
+    FOR EACH ROW EXECUTE FUNCTION "audit_log_append_only"();
+
+CREATE TRIGGER "audit_log_no_truncate"
+    BEFORE TRUNCATE ON "audit_log"
+    FOR EACH STATEMENT EXECUTE FUNCTION "audit_log_append_only"();
@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS "outbox" (
+    "id"              uuid NOT NULL,
+    "event_type"      text NOT NULL,
+    "aggregate_type"  text NOT NULL,
+    "aggregate_id"    uuid NOT NULL,
+    "payload"         jsonb NOT NULL,
+    "occurred_at"     timestamptz NOT NULL,
+    "attempts"        integer NOT NULL DEFAULT 0,
+    "next_attempt_at" timestamptz NOT NULL,
+    "published_at"    timestamptz,
+    "last_error"      text,
+    PRIMARY KEY ("id")
+);
+
+-- The relay only ever scans unpublished messages.
+CREATE INDEX IF NOT EXISTS "idx_outbox_pending" ON "outbox" ("next_attempt_at") WHERE "published_at" IS NULL;
+CREATE INDEX IF NOT EXISTS "idx_outbox_aggregate" ON "outbox" ("aggregate_id", "occurred_at") WHERE "published_at" IS NULL;
+CREATE INDEX IF NOT EXISTS "idx_outbox_published_at" ON "outbox" ("published_at") WHERE "published_at" IS NOT NULL;
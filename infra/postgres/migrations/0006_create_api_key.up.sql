@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS "api_key" (
+    "id"           uuid DEFAULT gen_random_uuid(),
+    "name"         text NOT NULL,
+    "prefix"       text NOT NULL,
+    "hash"         bytea NOT NULL,
+    "scopes"       jsonb NOT NULL,
+    "expires_at"   timestamptz,
+    "last_used_at" timestamptz,
+    "revoked_at"   timestamptz,
+    "created_by"   text NOT NULL,
+    "created_at"   timestamptz NOT NULL,
+    PRIMARY KEY ("id")
+);
+
+-- Keys are looked up by prefix on every request that presents one.
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_api_key_prefix" ON "api_key" ("prefix");
@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS "rate_limit_bucket" (
+    "key"        text,
+    "tokens"     double precision NOT NULL,
+    "updated_at" timestamptz NOT NULL,
+    "expires_at" timestamptz NOT NULL,
+    PRIMARY KEY ("key")
+);
+
+-- A bucket is full again at expires_at and can be purged from then on.
+CREATE INDEX IF NOT EXISTS "idx_rate_limit_bucket_expires_at" ON "rate_limit_bucket" ("expires_at");
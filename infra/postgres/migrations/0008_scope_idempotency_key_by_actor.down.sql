@@ -0,0 +1,7 @@
+ALTER TABLE "idempotency_key" DROP CONSTRAINT IF EXISTS "idempotency_key_pkey";
+
+-- Records are short-lived: keep one per key rather than fail on collisions.
+DELETE FROM "idempotency_key" a USING "idempotency_key" b WHERE a."key" = b."key" AND a."actor" > b."actor";
+
+ALTER TABLE "idempotency_key" ADD PRIMARY KEY ("key");
+ALTER TABLE "idempotency_key" DROP COLUMN IF EXISTS "actor";
@@ -0,0 +1,5 @@
+ALTER TABLE "idempotency_key" ADD COLUMN IF NOT EXISTS "actor" text NOT NULL DEFAULT '';
+
+-- Keys are chosen by clients, so they are only unique per actor.
+ALTER TABLE "idempotency_key" DROP CONSTRAINT IF EXISTS "idempotency_key_pkey";
+ALTER TABLE "idempotency_key" ADD PRIMARY KEY ("actor", "key");
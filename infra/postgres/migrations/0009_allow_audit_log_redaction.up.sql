@@ -0,0 +1,20 @@
+-- audit_log_redacted keeps the field names of changes and erases their values.
+CREATE OR REPLACE FUNCTION "audit_log_redacted"("changes" jsonb) RETURNS jsonb AS $$
+    SELECT COALESCE(jsonb_agg(jsonb_build_object('field', c->'field', 'old', NULL, 'new', NULL) ORDER BY i), '[]'::jsonb)
+    FROM jsonb_array_elements("changes") WITH ORDINALITY AS t(c, i);
+$$ LANGUAGE sql IMMUTABLE;
+
+-- The audit log stays append-only, except for erasing the values of recorded
+-- changes, so that purged data does not live on in it. Who changed which
+-- field and when is kept.
+CREATE OR REPLACE FUNCTION "audit_log_append_only"() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP = 'UPDATE'
+        AND (NEW."id", NEW."entity_type", NEW."entity_id", NEW."action", NEW."actor", NEW."request_id", NEW."created_at")
+            IS NOT DISTINCT FROM (OLD."id", OLD."entity_type", OLD."entity_id", OLD."action", OLD."actor", OLD."request_id", OLD."created_at")
+        AND NEW."changes" = "audit_log_redacted"(OLD."changes") THEN
+        RETURN NEW;
+    END IF;
+    RAISE EXCEPTION 'audit_log is append-only';
+END;
+$$ LANGUAGE plpgsql;
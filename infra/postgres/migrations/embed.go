@@ -0,0 +1,10 @@
+// Package migrations holds the versioned SQL schema, applied in order by
+// infra/postgres/migrate. Files are named <version>_<name>.up.sql and
+// <version>_<name>.down.sql; never edit a migration once it is released,
+// add a new one instead.
+package migrations
+
+import "embed"
+
+//go:embed *.sql
+var FS embed.FS
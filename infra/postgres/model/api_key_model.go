@@ -0,0 +1,61 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/entity"
+	"github.com/google/uuid"
+)
+
+type APIKeyModel struct {
+	ID         uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	Name       string    `gorm:"not null"`
+	Prefix     string    `gorm:"not null;uniqueIndex"`
+	Hash       []byte    `gorm:"type:bytea;not null"`
+	Scopes     []byte    `gorm:"type:jsonb;not null"`
+	ExpiresAt  *time.Time
+	LastUsedAt *time.Time
+	RevokedAt  *time.Time
+	CreatedBy  string    `gorm:"not null"`
+	CreatedAt  time.Time `gorm:"not null"`
+}
+
+func (APIKeyModel) TableName() string { return "api_key" }
+
+func ToAPIKeyModel(e entity.APIKey) APIKeyModel {
+	scopes := e.Scopes
+	if scopes == nil {
+		scopes = []string{}
+	}
+	b, _ := json.Marshal(scopes)
+	return APIKeyModel{
+		ID:         e.ID,
+		Name:       e.Name,
+		Prefix:     e.Prefix,
+		Hash:       e.Hash,
+		Scopes:     b,
+		ExpiresAt:  e.ExpiresAt,
+		LastUsedAt: e.LastUsedAt,
+		RevokedAt:  e.RevokedAt,
+		CreatedBy:  e.CreatedBy,
+		CreatedAt:  e.CreatedAt,
+	}
+}
+
+func ToAPIKey(m APIKeyModel) entity.APIKey {
+	var scopes []string
+	_ = json.Unmarshal(m.Scopes, &scopes)
+	return entity.APIKey{
+		ID:         m.ID,
+		Name:       m.Name,
+		Prefix:     m.Prefix,
+		Hash:       m.Hash,
+		Scopes:     scopes,
+		ExpiresAt:  m.ExpiresAt,
+		LastUsedAt: m.LastUsedAt,
+		RevokedAt:  m.RevokedAt,
+		CreatedBy:  m.CreatedBy,
+		CreatedAt:  m.CreatedAt,
+	}
+}
@@ -0,0 +1,39 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/entity"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIKeyModel_RoundTrip(t *testing.T) {
+	now := time.Now().UTC().Truncate(time.Second)
+	expires := now.Add(time.Hour)
+	e := entity.APIKey{
+		ID:         uuid.New(),
+		Name:       "partner",
+		Prefix:     "ak_0123456789ab",
+		Hash:       []byte{1, 2, 3},
+		Scopes:     []string{"users:read", "users:write"},
+		ExpiresAt:  &expires,
+		LastUsedAt: &now,
+		CreatedBy:  "admin-1",
+		CreatedAt:  now,
+	}
+
+	m := ToAPIKeyModel(e)
+	assert.JSONEq(t, `["users:read","users:write"]`, string(m.Scopes))
+	assert.Equal(t, e, ToAPIKey(m))
+}
+
+func TestAPIKeyModel_NoScopes(t *testing.T) {
+	m := ToAPIKeyModel(entity.APIKey{})
+	assert.Equal(t, `[]`, string(m.Scopes))
+}
+
+func TestAPIKeyModel_TableName(t *testing.T) {
+	assert.Equal(t, "api_key", APIKeyModel{}.TableName())
+}
@@ -0,0 +1,65 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/entity"
+	"github.com/google/uuid"
+)
+
+type AuditLogModel struct {
+	ID         uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	EntityType string    `gorm:"not null"`
+	EntityID   uuid.UUID `gorm:"type:uuid;not null"`
+	Action     string    `gorm:"not null"`
+	Actor      string    `gorm:"not null"`
+	RequestID  string
+	Changes    []byte    `gorm:"type:jsonb;not null"`
+	CreatedAt  time.Time `gorm:"not null"`
+}
+
+func (AuditLogModel) TableName() string { return "audit_log" }
+
+type fieldChange struct {
+	Field string `json:"field"`
+	Old   any    `json:"old"`
+	New   any    `json:"new"`
+}
+
+func ToAuditLogModel(e entity.AuditEvent) AuditLogModel {
+	changes := make([]fieldChange, 0, len(e.Changes))
+	for _, c := range e.Changes {
+		changes = append(changes, fieldChange(c))
+	}
+	b, _ := json.Marshal(changes)
+	return AuditLogModel{
+		ID:         e.ID,
+		EntityType: e.EntityType,
+		EntityID:   e.EntityID,
+		Action:     string(e.Action),
+		Actor:      e.Actor,
+		RequestID:  e.RequestID,
+		Changes:    b,
+		CreatedAt:  e.CreatedAt,
+	}
+}
+
+func ToAuditEvent(m AuditLogModel) entity.AuditEvent {
+	var changes []fieldChange
+	_ = json.Unmarshal(m.Changes, &changes)
+	e := entity.AuditEvent{
+		ID:         m.ID,
+		EntityType: m.EntityType,
+		EntityID:   m.EntityID,
+		Action:     entity.AuditAction(m.Action),
+		Actor:      m.Actor,
+		RequestID:  m.RequestID,
+		Changes:    make([]entity.FieldChange, 0, len(changes)),
+		CreatedAt:  m.CreatedAt,
+	}
+	for _, c := range changes {
+		e.Changes = append(e.Changes, entity.FieldChange(c))
+	}
+	return e
+}
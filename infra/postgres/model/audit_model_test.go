@@ -0,0 +1,33 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/entity"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuditLogModel_RoundTrip(t *testing.T) {
+	e := entity.AuditEvent{
+		ID:         uuid.New(),
+		EntityType: "user",
+		EntityID:   uuid.New(),
+		Action:     entity.AuditUpdate,
+		Actor:      "cli:ops",
+		RequestID:  "req-1",
+		Changes:    []entity.FieldChange{{Field: "name", Old: "Ana", New: "Bia"}, {Field: "phone", Old: nil, New: "1"}},
+		CreatedAt:  time.Now().UTC(),
+	}
+
+	m := ToAuditLogModel(e)
+	assert.JSONEq(t, `[{"field":"name","old":"Ana","new":"Bia"},{"field":"phone","old":null,"new":"1"}]`, string(m.Changes))
+	assert.Equal(t, e, ToAuditEvent(m))
+}
+
+func TestAuditLogModel_NoChanges(t *testing.T) {
+	m := ToAuditLogModel(entity.AuditEvent{Action: entity.AuditUpdate})
+	assert.Equal(t, `[]`, string(m.Changes))
+	assert.Equal(t, []entity.FieldChange{}, ToAuditEvent(m).Changes)
+}
@@ -0,0 +1,55 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/entity"
+)
+
+type IdempotencyKeyModel struct {
+	Actor       string `gorm:"primaryKey"`
+	Key         string `gorm:"primaryKey;size:255"`
+	Fingerprint string `gorm:"not null"`
+	StatusCode  int
+	Header      []byte    `gorm:"type:jsonb"`
+	Body        []byte    `gorm:"type:bytea"`
+	CreatedAt   time.Time `gorm:"not null"`
+	ExpiresAt   time.Time `gorm:"not null;index"`
+}
+
+func (IdempotencyKeyModel) TableName() string { return "idempotency_key" }
+
+func ToIdempotencyKeyModel(e entity.IdempotencyRecord) IdempotencyKeyModel {
+	var header []byte
+	if e.Header != nil {
+		header, _ = json.Marshal(e.Header)
+	}
+	return IdempotencyKeyModel{
+		Actor:       e.Actor,
+		Key:         e.Key,
+		Fingerprint: e.Fingerprint,
+		StatusCode:  e.StatusCode,
+		Header:      header,
+		Body:        e.Body,
+		CreatedAt:   e.CreatedAt,
+		ExpiresAt:   e.ExpiresAt,
+	}
+}
+
+func ToIdempotencyRecord(m IdempotencyKeyModel) entity.IdempotencyRecord {
+	var header map[string][]string
+	if len(m.Header) > 0 {
+		_ = json.Unmarshal(m.Header, &header)
+	}
+	return entity.IdempotencyRecord{
+		Actor:       m.Actor,
+		Key:         m.Key,
+		Fingerprint: m.Fingerprint,
+		StatusCode:  m.StatusCode,
+		Header:      header,
+		Body:        m.Body,
+		CreatedAt:   m.CreatedAt,
+		ExpiresAt:   m.ExpiresAt,
+	}
+}
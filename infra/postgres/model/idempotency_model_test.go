@@ -0,0 +1,40 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIdempotencyKeyModel_RoundTrip(t *testing.T) {
+	now := time.Now().UTC().Truncate(time.Second)
+	e := entity.IdempotencyRecord{
+		Actor:       "user-1",
+		Key:         "k1",
+		Fingerprint: "f1",
+		StatusCode:  201,
+		Header:      map[string][]string{"Content-Type": {"application/json"}},
+		Body:        []byte(`{"id":"1"}`),
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(time.Hour),
+	}
+
+	m := ToIdempotencyKeyModel(e)
+	assert.JSONEq(t, `{"Content-Type":["application/json"]}`, string(m.Header))
+	assert.Equal(t, e, ToIdempotencyRecord(m))
+}
+
+func TestIdempotencyKeyModel_InFlight(t *testing.T) {
+	m := ToIdempotencyKeyModel(entity.IdempotencyRecord{Key: "k1"})
+	assert.Nil(t, m.Header)
+
+	e := ToIdempotencyRecord(m)
+	assert.Nil(t, e.Header)
+	assert.False(t, e.Completed())
+}
+
+func TestIdempotencyKeyModel_TableName(t *testing.T) {
+	assert.Equal(t, "idempotency_key", IdempotencyKeyModel{}.TableName())
+}
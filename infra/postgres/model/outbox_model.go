@@ -0,0 +1,62 @@
+package model
+
+import (
+	"time"
+
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/entity"
+	"github.com/google/uuid"
+)
+
+type OutboxModel struct {
+	ID            uuid.UUID `gorm:"type:uuid;primaryKey"`
+	EventType     string    `gorm:"not null"`
+	AggregateType string    `gorm:"not null"`
+	AggregateID   uuid.UUID `gorm:"type:uuid;not null"`
+	Payload       []byte    `gorm:"type:jsonb;not null"`
+	OccurredAt    time.Time `gorm:"not null"`
+	Attempts      int       `gorm:"not null;default:0"`
+	NextAttemptAt time.Time `gorm:"not null"`
+	PublishedAt   *time.Time
+	LastError     string
+}
+
+func (OutboxModel) TableName() string { return "outbox" }
+
+func ToOutboxModel(e entity.Event) OutboxModel {
+	return OutboxModel{
+		ID:            e.ID,
+		EventType:     string(e.Type),
+		AggregateType: e.AggregateType,
+		AggregateID:   e.AggregateID,
+		Payload:       e.Payload,
+		OccurredAt:    e.OccurredAt,
+		NextAttemptAt: e.OccurredAt,
+	}
+}
+
+// ToOutboxMessageModel keeps the delivery state of msg.
+func ToOutboxMessageModel(msg entity.OutboxMessage) OutboxModel {
+	m := ToOutboxModel(msg.Event)
+	m.Attempts = msg.Attempts
+	m.NextAttemptAt = msg.NextAttemptAt
+	m.PublishedAt = msg.PublishedAt
+	m.LastError = msg.LastError
+	return m
+}
+
+func ToOutboxMessage(m OutboxModel) entity.OutboxMessage {
+	return entity.OutboxMessage{
+		Event: entity.Event{
+			ID:            m.ID,
+			Type:          entity.EventType(m.EventType),
+			AggregateType: m.AggregateType,
+			AggregateID:   m.AggregateID,
+			Payload:       m.Payload,
+			OccurredAt:    m.OccurredAt,
+		},
+		Attempts:      m.Attempts,
+		NextAttemptAt: m.NextAttemptAt,
+		PublishedAt:   m.PublishedAt,
+		LastError:     m.LastError,
+	}
+}
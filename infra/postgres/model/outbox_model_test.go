@@ -0,0 +1,43 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/entity"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOutboxModel_RoundTrip(t *testing.T) {
+	e := entity.Event{
+		ID:            uuid.New(),
+		Type:          entity.UserCreated,
+		AggregateType: "user",
+		AggregateID:   uuid.New(),
+		Payload:       []byte(`{"name":"Ana"}`),
+		OccurredAt:    time.Now().UTC(),
+	}
+
+	m := ToOutboxModel(e)
+	assert.Equal(t, e.OccurredAt, m.NextAttemptAt)
+	assert.Nil(t, m.PublishedAt)
+
+	msg := ToOutboxMessage(m)
+	assert.Equal(t, e, msg.Event)
+	assert.Zero(t, msg.Attempts)
+	assert.Equal(t, e.OccurredAt, msg.NextAttemptAt)
+}
+
+func TestOutboxModel_Delivery(t *testing.T) {
+	now := time.Now().UTC()
+	msg := entity.OutboxMessage{
+		Event:         entity.Event{ID: uuid.New(), Type: entity.UserDeleted, OccurredAt: now},
+		Attempts:      3,
+		NextAttemptAt: now.Add(time.Minute),
+		PublishedAt:   &now,
+		LastError:     "broker down",
+	}
+
+	assert.Equal(t, msg, ToOutboxMessage(ToOutboxMessageModel(msg)))
+}
@@ -0,0 +1,34 @@
+package model
+
+import (
+	"time"
+
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/entity"
+)
+
+type RateLimitBucketModel struct {
+	Key       string    `gorm:"primaryKey"`
+	Tokens    float64   `gorm:"not null"`
+	UpdatedAt time.Time `gorm:"not null;autoUpdateTime:false"`
+	ExpiresAt time.Time `gorm:"not null;index"`
+}
+
+func (RateLimitBucketModel) TableName() string { return "rate_limit_bucket" }
+
+// ToRateLimitBucketModel maps the bucket of key, which is full again at
+// expiresAt.
+func ToRateLimitBucketModel(key string, b entity.TokenBucket, expiresAt time.Time) RateLimitBucketModel {
+	return RateLimitBucketModel{
+		Key:       key,
+		Tokens:    b.Tokens,
+		UpdatedAt: b.UpdatedAt,
+		ExpiresAt: expiresAt,
+	}
+}
+
+func ToTokenBucket(m RateLimitBucketModel) entity.TokenBucket {
+	return entity.TokenBucket{
+		Tokens:    m.Tokens,
+		UpdatedAt: m.UpdatedAt,
+	}
+}
@@ -0,0 +1,24 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimitBucketModel_RoundTrip(t *testing.T) {
+	now := time.Now().UTC()
+	b := entity.TokenBucket{Tokens: 2.5, UpdatedAt: now}
+
+	m := ToRateLimitBucketModel("write:ip:10.0.0.1", b, now.Add(time.Minute))
+
+	assert.Equal(t, "write:ip:10.0.0.1", m.Key)
+	assert.Equal(t, now.Add(time.Minute), m.ExpiresAt)
+	assert.Equal(t, b, ToTokenBucket(m))
+}
+
+func TestRateLimitBucketModel_TableName(t *testing.T) {
+	assert.Equal(t, "rate_limit_bucket", RateLimitBucketModel{}.TableName())
+}
@@ -9,12 +9,13 @@ import (
 )
 
 type UserModel struct {
-	ID        uuid.UUID      `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	ID        uuid.UUID      `gorm:"type:uuid;primaryKey;default:gen_random_uuid();index:idx_user_created_at_id,priority:2"`
 	Name      string
 	Phone     string
-	CreatedAt time.Time      `gorm:"<-:create"`
+	CreatedAt time.Time      `gorm:"<-:create;index:idx_user_created_at_id,priority:1"`
 	UpdatedAt time.Time      `gorm:"autoUpdateTime"`
 	DeletedAt gorm.DeletedAt `gorm:"index"`
+	Version   int64          `gorm:"not null;default:1"`
 }
 
 func (UserModel) TableName() string { return "user" }
@@ -31,6 +32,7 @@ func ToUserModel(e entity.UserEntity) UserModel {
 		CreatedAt: e.CreatedAt,
 		UpdatedAt: e.UpdatedAt,
 		DeletedAt: deletedAt,
+		Version:   e.Version,
 	}
 }
 
@@ -47,5 +49,6 @@ func ToUserEntity(m UserModel) entity.UserEntity {
 		CreatedAt: m.CreatedAt,
 		UpdatedAt: m.UpdatedAt,
 		DeletedAt: deletedAt,
+		Version:   m.Version,
 	}
 }
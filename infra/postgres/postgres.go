@@ -5,8 +5,11 @@ import (
 	"fmt"
 	"time"
 
+	"go.opentelemetry.io/otel/trace"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
+
+	"github.com/DeSouzaRafael/go-clean-architecture-template/infra/logger"
 )
 
 const (
@@ -20,6 +23,12 @@ type Options struct {
 	MaxOpenConns    int
 	MaxIdleConns    int
 	ConnMaxLifetime time.Duration
+	// Logger receives failed and slow queries, with the request metadata
+	// of their context. GORM's default logger is used when nil.
+	Logger logger.Interface
+	// TracerProvider, when set, traces every query as a child of the span
+	// in the query context.
+	TracerProvider trace.TracerProvider
 }
 
 type Postgres struct {
@@ -27,10 +36,20 @@ type Postgres struct {
 }
 
 func NewPostgres(opts Options) (*Postgres, error) {
-	gormDB, err := gorm.Open(postgres.Open(opts.URL), &gorm.Config{})
+	gormCfg := &gorm.Config{}
+	if opts.Logger != nil {
+		gormCfg.Logger = newQueryLogger(opts.Logger)
+	}
+
+	gormDB, err := gorm.Open(postgres.Open(opts.URL), gormCfg)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open DB connection: %w", err)
 	}
+	if opts.TracerProvider != nil {
+		if err := gormDB.Use(newTracingPlugin(opts.TracerProvider)); err != nil {
+			return nil, fmt.Errorf("failed to install tracing: %w", err)
+		}
+	}
 
 	sqlDB, err := gormDB.DB()
 	if err != nil {
@@ -72,3 +91,15 @@ func (p *Postgres) Close() {
 		}
 	}
 }
+
+// Ping verifies a connection to the database is still alive.
+func (p *Postgres) Ping(ctx context.Context) error {
+	sqlDB, err := p.DB.DB()
+	if err != nil {
+		return fmt.Errorf("postgres - Ping: %w", err)
+	}
+	if err := sqlDB.PingContext(ctx); err != nil {
+		return fmt.Errorf("postgres - Ping: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/DeSouzaRafael/go-clean-architecture-template/infra/postgres/model"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/entity"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type APIKeyRepo struct {
+	*BaseRepo[model.APIKeyModel]
+}
+
+func NewAPIKeyRepo(db *gorm.DB) *APIKeyRepo {
+	return &APIKeyRepo{BaseRepo: NewBaseRepo[model.APIKeyModel](db)}
+}
+
+func (r *APIKeyRepo) Create(ctx context.Context, e entity.APIKey) (entity.APIKey, error) {
+	m, err := r.BaseRepo.Create(ctx, model.ToAPIKeyModel(e))
+	return model.ToAPIKey(m), err
+}
+
+func (r *APIKeyRepo) GetByPrefix(ctx context.Context, prefix string) (entity.APIKey, error) {
+	m, err := r.BaseRepo.Get(ctx, clause.Eq{Column: clause.Column{Name: "prefix"}, Value: prefix})
+	return model.ToAPIKey(m), err
+}
+
+// List returns every key, revoked and expired ones included, oldest first.
+func (r *APIKeyRepo) List(ctx context.Context) ([]entity.APIKey, error) {
+	var ms []model.APIKeyModel
+	if err := r.conn(ctx).Clauses(clause.OrderBy{Columns: defaultOrder}).Find(&ms).Error; err != nil {
+		return nil, translateError(err)
+	}
+
+	keys := make([]entity.APIKey, len(ms))
+	for i, m := range ms {
+		keys[i] = model.ToAPIKey(m)
+	}
+	return keys, nil
+}
+
+// Revoke marks the key revoked at t. It returns NotFound unless the key
+// exists and is not revoked yet.
+func (r *APIKeyRepo) Revoke(ctx context.Context, id uuid.UUID, t time.Time) error {
+	res := r.conn(ctx).Model(&model.APIKeyModel{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Update("revoked_at", t)
+	if res.Error != nil {
+		return translateError(res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return translateError(gorm.ErrRecordNotFound)
+	}
+	return nil
+}
+
+// Touch records that the key was used at t, unless a use after since is
+// already recorded, which spares a write on most requests.
+func (r *APIKeyRepo) Touch(ctx context.Context, id uuid.UUID, t, since time.Time) error {
+	res := r.conn(ctx).Model(&model.APIKeyModel{}).
+		Where("id = ? AND (last_used_at IS NULL OR last_used_at < ?)", id, since).
+		Update("last_used_at", t)
+	return translateError(res.Error)
+}
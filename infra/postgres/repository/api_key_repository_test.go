@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/apperror"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/entity"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIKeyRepo_Create(t *testing.T) {
+	db, stmts := captureSQL(t)
+
+	_, err := NewAPIKeyRepo(db).Create(context.Background(), entity.APIKey{Name: "partner", Prefix: "ak_1", Hash: []byte{1}, CreatedAt: time.Now()})
+
+	require.NoError(t, err)
+	require.Len(t, *stmts, 1)
+	assert.Equal(t, `INSERT INTO "api_key" ("name","prefix","hash","scopes","expires_at","last_used_at","revoked_at","created_by","created_at") `+
+		`VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9) RETURNING "id"`, (*stmts)[0])
+}
+
+func TestAPIKeyRepo_GetByPrefix(t *testing.T) {
+	db, stmts := captureSQL(t)
+
+	_, _ = NewAPIKeyRepo(db).GetByPrefix(context.Background(), "ak_1")
+
+	require.Len(t, *stmts, 1)
+	assert.Equal(t, `SELECT * FROM "api_key" WHERE "prefix" = $1 ORDER BY "api_key"."id" LIMIT $2`, (*stmts)[0])
+}
+
+func TestAPIKeyRepo_List(t *testing.T) {
+	db, stmts := captureSQL(t)
+
+	_, err := NewAPIKeyRepo(db).List(context.Background())
+
+	require.NoError(t, err)
+	require.Len(t, *stmts, 1)
+	assert.Equal(t, `SELECT * FROM "api_key" ORDER BY "created_at","id"`, (*stmts)[0])
+}
+
+func TestAPIKeyRepo_Revoke(t *testing.T) {
+	db, stmts := captureSQL(t)
+
+	err := NewAPIKeyRepo(db).Revoke(context.Background(), uuid.New(), time.Now())
+
+	assert.ErrorIs(t, err, apperror.ErrNotFound)
+	require.Len(t, *stmts, 1)
+	assert.Equal(t, `UPDATE "api_key" SET "revoked_at"=$1 WHERE id = $2 AND revoked_at IS NULL`, (*stmts)[0])
+}
+
+func TestAPIKeyRepo_Touch(t *testing.T) {
+	db, stmts := captureSQL(t)
+	now := time.Now()
+
+	require.NoError(t, NewAPIKeyRepo(db).Touch(context.Background(), uuid.New(), now, now.Add(-time.Minute)))
+
+	require.Len(t, *stmts, 1)
+	assert.Equal(t, `UPDATE "api_key" SET "last_used_at"=$1 WHERE id = $2 AND (last_used_at IS NULL OR last_used_at < $3)`, (*stmts)[0])
+}
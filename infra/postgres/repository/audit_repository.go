@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/DeSouzaRafael/go-clean-architecture-template/infra/postgres/model"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/entity"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/usecase"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// auditOrder lists the most recent events first.
+var auditOrder = []clause.OrderByColumn{
+	{Column: clause.Column{Name: "created_at"}, Desc: true},
+	{Column: clause.Column{Name: "id"}, Desc: true},
+}
+
+// AuditRepo appends and reads events, and redacts them. The table rejects
+// any other update, and deletes, on its own.
+type AuditRepo struct {
+	*BaseRepo[model.AuditLogModel]
+}
+
+func NewAuditRepo(db *gorm.DB) *AuditRepo {
+	return &AuditRepo{BaseRepo: NewBaseRepo[model.AuditLogModel](db)}
+}
+
+func (r *AuditRepo) Append(ctx context.Context, e entity.AuditEvent) error {
+	_, err := r.BaseRepo.Create(ctx, model.ToAuditLogModel(e))
+	return err
+}
+
+// Redact erases the old and new values of the changes recorded for an
+// entity, keeping the changed field names.
+func (r *AuditRepo) Redact(ctx context.Context, entityType string, entityID uuid.UUID) error {
+	err := r.conn(ctx).Model(&model.AuditLogModel{}).
+		Where("entity_type = ? AND entity_id = ?", entityType, entityID).
+		Update("changes", gorm.Expr("audit_log_redacted(changes)")).Error
+	return translateError(err)
+}
+
+func (r *AuditRepo) List(ctx context.Context, entityType string, entityID uuid.UUID, req usecase.PageRequest) (usecase.Page[entity.AuditEvent], error) {
+	res, err := r.BaseRepo.List(ctx, ListOptions{
+		Limit:  req.Limit,
+		Offset: req.Offset,
+		Count:  req.WithTotal,
+		Where: []clause.Expression{
+			clause.Eq{Column: clause.Column{Name: "entity_type"}, Value: entityType},
+			clause.Eq{Column: clause.Column{Name: "entity_id"}, Value: entityID},
+		},
+		Order: auditOrder,
+	})
+	if err != nil {
+		return usecase.Page[entity.AuditEvent]{}, err
+	}
+
+	page := usecase.Page[entity.AuditEvent]{
+		Items:   make([]entity.AuditEvent, 0, len(res.Items)),
+		Total:   res.Total,
+		HasMore: res.HasMore,
+	}
+	for _, m := range res.Items {
+		page.Items = append(page.Items, model.ToAuditEvent(m))
+	}
+
+	return page, nil
+}
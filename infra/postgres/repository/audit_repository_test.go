@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/entity"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/usecase"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuditRepo_Append(t *testing.T) {
+	db, stmts := captureSQL(t)
+
+	err := NewAuditRepo(db).Append(context.Background(), entity.AuditEvent{
+		EntityType: "user", EntityID: uuid.New(), Action: entity.AuditCreate, Actor: "anonymous", CreatedAt: time.Now(),
+	})
+
+	require.NoError(t, err)
+	require.Len(t, *stmts, 1)
+	assert.Equal(t, `INSERT INTO "audit_log" ("entity_type","entity_id","action","actor","request_id","changes","created_at") VALUES ($1,$2,$3,$4,$5,$6,$7) RETURNING "id"`, (*stmts)[0])
+}
+
+func TestAuditRepo_Redact(t *testing.T) {
+	db, stmts := captureSQL(t)
+
+	err := NewAuditRepo(db).Redact(context.Background(), "user", uuid.New())
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{`UPDATE "audit_log" SET "changes"=audit_log_redacted(changes) WHERE entity_type = $1 AND entity_id = $2`}, *stmts)
+}
+
+func TestAuditRepo_List(t *testing.T) {
+	db, stmts := captureSQL(t)
+
+	_, err := NewAuditRepo(db).List(context.Background(), "user", uuid.New(), usecase.PageRequest{Limit: 10, Offset: 20})
+
+	require.NoError(t, err)
+	require.Len(t, *stmts, 1)
+	assert.Equal(t, `SELECT * FROM "audit_log" WHERE "entity_type" = $1 AND "entity_id" = $2 ORDER BY "created_at" DESC,"id" DESC LIMIT $3 OFFSET $4`, (*stmts)[0])
+}
@@ -2,10 +2,23 @@ package repository
 
 import (
 	"context"
+	"time"
 
+	"github.com/DeSouzaRafael/go-clean-architecture-template/infra/postgres"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+const (
+	versionColumn   = "version"
+	deletedAtColumn = "deleted_at"
+)
+
+var defaultOrder = []clause.OrderByColumn{
+	{Column: clause.Column{Name: "created_at"}},
+	{Column: clause.Column{Name: "id"}},
+}
+
 type BaseRepo[T any] struct {
 	DB *gorm.DB
 }
@@ -14,22 +27,198 @@ func NewBaseRepo[T any](db *gorm.DB) *BaseRepo[T] {
 	return &BaseRepo[T]{DB: db}
 }
 
+// hasColumn reports whether T maps a column named name.
+func (repo *BaseRepo[T]) hasColumn(name string) bool {
+	stmt := &gorm.Statement{DB: repo.DB}
+	if err := stmt.Parse(new(T)); err != nil {
+		return false
+	}
+	return stmt.Schema.LookUpField(name) != nil
+}
+
+// conn returns the transaction carried by ctx, if any, so that every method
+// joins a transaction started with postgres.TxManager.
+func (repo *BaseRepo[T]) conn(ctx context.Context) *gorm.DB {
+	return postgres.Conn(ctx, repo.DB)
+}
+
 func (repo *BaseRepo[T]) Get(ctx context.Context, id interface{}) (T, error) {
 	var entity T
-	err := repo.DB.WithContext(ctx).First(&entity, id).Error
-	return entity, err
+	err := repo.conn(ctx).First(&entity, id).Error
+	return entity, translateError(err)
 }
 
 func (repo *BaseRepo[T]) Create(ctx context.Context, entity T) (T, error) {
-	err := repo.DB.WithContext(ctx).Create(&entity).Error
-	return entity, err
+	err := repo.conn(ctx).Create(&entity).Error
+	return entity, translateError(err)
 }
 
 func (repo *BaseRepo[T]) Update(ctx context.Context, entity T) error {
-	return repo.DB.WithContext(ctx).Save(&entity).Error
+	return translateError(repo.conn(ctx).Save(&entity).Error)
+}
+
+// UpdateColumns writes only the given columns of entity, identified by its
+// primary key, and returns it with hook-managed columns such as updated_at set.
+func (repo *BaseRepo[T]) UpdateColumns(ctx context.Context, entity T, columns ...string) (T, error) {
+	res := repo.conn(ctx).Model(&entity).Select(columns).Updates(&entity)
+	if res.Error != nil {
+		return entity, translateError(res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return entity, translateError(gorm.ErrRecordNotFound)
+	}
+	return entity, nil
+}
+
+// UpdateColumnsIfVersion is UpdateColumns guarded by optimistic locking: the
+// row is only written while its version column still equals version. The
+// caller sets the next version on entity and lists "version" in columns.
+func (repo *BaseRepo[T]) UpdateColumnsIfVersion(ctx context.Context, entity T, version int64, columns ...string) (T, error) {
+	res := repo.conn(ctx).Model(&entity).Where(versionColumn+" = ?", version).Select(columns).Updates(&entity)
+	if res.Error != nil {
+		return entity, translateError(res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return entity, errVersionMismatch
+	}
+	return entity, nil
 }
 
 func (repo *BaseRepo[T]) Delete(ctx context.Context, id interface{}) error {
 	var entity T
-	return repo.DB.WithContext(ctx).Delete(&entity, id).Error
+	return translateError(repo.conn(ctx).Delete(&entity, id).Error)
+}
+
+// DeleteIfVersion deletes the row only while its version column still equals version.
+func (repo *BaseRepo[T]) DeleteIfVersion(ctx context.Context, id interface{}, version int64) error {
+	var entity T
+	res := repo.conn(ctx).Where(versionColumn+" = ?", version).Delete(&entity, id)
+	if res.Error != nil {
+		return translateError(res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return errVersionMismatch
+	}
+	return nil
+}
+
+// deleted scopes a query to soft-deleted rows. T must have a gorm.DeletedAt field.
+func (repo *BaseRepo[T]) deleted(ctx context.Context) *gorm.DB {
+	return repo.conn(ctx).Unscoped().Where(deletedAtColumn + " IS NOT NULL")
+}
+
+// GetDeleted returns a soft-deleted row by primary key.
+func (repo *BaseRepo[T]) GetDeleted(ctx context.Context, id interface{}) (T, error) {
+	var entity T
+	err := repo.deleted(ctx).First(&entity, id).Error
+	return entity, translateError(err)
+}
+
+// Restore clears the soft delete of a row, bumping its version column if T
+// has one. It returns NotFound unless the row exists and is deleted.
+func (repo *BaseRepo[T]) Restore(ctx context.Context, id interface{}) error {
+	values := map[string]interface{}{deletedAtColumn: nil}
+	if repo.hasColumn(versionColumn) {
+		values[versionColumn] = gorm.Expr(versionColumn + " + 1")
+	}
+
+	res := repo.deleted(ctx).Model(new(T)).Where(clause.Eq{Column: clause.PrimaryColumn, Value: id}).Updates(values)
+	if res.Error != nil {
+		return translateError(res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return translateError(gorm.ErrRecordNotFound)
+	}
+	return nil
+}
+
+// Purge permanently deletes a soft-deleted row. Rows that are not deleted
+// yet are left alone and reported as NotFound.
+func (repo *BaseRepo[T]) Purge(ctx context.Context, id interface{}) error {
+	res := repo.deleted(ctx).Delete(new(T), id)
+	if res.Error != nil {
+		return translateError(res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return translateError(gorm.ErrRecordNotFound)
+	}
+	return nil
+}
+
+// PurgeDeletedBefore permanently deletes the rows soft-deleted before cutoff
+// and returns them.
+func (repo *BaseRepo[T]) PurgeDeletedBefore(ctx context.Context, cutoff time.Time) ([]T, error) {
+	var rows []T
+	err := repo.conn(ctx).Unscoped().Clauses(clause.Returning{}).Where(deletedAtColumn+" < ?", cutoff).Delete(&rows).Error
+	return rows, translateError(err)
+}
+
+type ListOptions struct {
+	Limit  int
+	Offset int
+	Count  bool
+	// Where filters both the page and the total count.
+	Where []clause.Expression
+	// Order defaults to (created_at, id) when empty.
+	Order []clause.OrderByColumn
+	// After is a keyset condition applied to the page only.
+	After clause.Expression
+}
+
+type ListResult[T any] struct {
+	Items   []T
+	HasMore bool
+	Total   *int64
+}
+
+// List returns one page of rows. When After is set the page is read with a
+// keyset condition instead of OFFSET, which stays fast on large tables as
+// long as the order columns are indexed.
+func (repo *BaseRepo[T]) List(ctx context.Context, opts ListOptions) (ListResult[T], error) {
+	return repo.list(repo.conn(ctx), opts)
+}
+
+// ListDeleted is List over soft-deleted rows only.
+func (repo *BaseRepo[T]) ListDeleted(ctx context.Context, opts ListOptions) (ListResult[T], error) {
+	return repo.list(repo.deleted(ctx), opts)
+}
+
+func (repo *BaseRepo[T]) list(db *gorm.DB, opts ListOptions) (ListResult[T], error) {
+	var result ListResult[T]
+
+	db = db.Model(new(T))
+	if len(opts.Where) > 0 {
+		db = db.Clauses(clause.Where{Exprs: opts.Where})
+	}
+
+	if opts.Count {
+		var total int64
+		if err := db.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+			return result, translateError(err)
+		}
+		result.Total = &total
+	}
+
+	order := opts.Order
+	if len(order) == 0 {
+		order = defaultOrder
+	}
+
+	query := db.Clauses(clause.OrderBy{Columns: order}).Limit(opts.Limit + 1)
+	if opts.After != nil {
+		query = query.Clauses(clause.Where{Exprs: []clause.Expression{opts.After}})
+	} else if opts.Offset > 0 {
+		query = query.Offset(opts.Offset)
+	}
+
+	if err := query.Find(&result.Items).Error; err != nil {
+		return result, translateError(err)
+	}
+
+	if len(result.Items) > opts.Limit {
+		result.Items = result.Items[:opts.Limit]
+		result.HasMore = true
+	}
+
+	return result, nil
 }
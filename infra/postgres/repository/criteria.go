@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/apperror"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/usecase"
+	"gorm.io/gorm/clause"
+)
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// Columns maps the public field names of an entity to table columns. Only
+// mapped fields can be filtered or sorted on, so client input never ends
+// up in SQL as an identifier.
+type Columns map[string]string
+
+func (cols Columns) column(field string) (clause.Column, error) {
+	name, ok := cols[field]
+	if !ok {
+		return clause.Column{}, apperror.Validation(fmt.Sprintf("unknown field %q", field), nil)
+	}
+	return clause.Column{Name: name}, nil
+}
+
+// conditions translates criteria conditions into parameterized expressions.
+func (cols Columns) conditions(conds []usecase.Condition) ([]clause.Expression, error) {
+	exprs := make([]clause.Expression, 0, len(conds))
+	for _, cond := range conds {
+		col, err := cols.column(cond.Field)
+		if err != nil {
+			return nil, err
+		}
+
+		switch cond.Op {
+		case usecase.OpEq:
+			exprs = append(exprs, clause.Eq{Column: col, Value: cond.Value})
+		case usecase.OpNe:
+			exprs = append(exprs, clause.Neq{Column: col, Value: cond.Value})
+		case usecase.OpGt:
+			exprs = append(exprs, clause.Gt{Column: col, Value: cond.Value})
+		case usecase.OpGte:
+			exprs = append(exprs, clause.Gte{Column: col, Value: cond.Value})
+		case usecase.OpLt:
+			exprs = append(exprs, clause.Lt{Column: col, Value: cond.Value})
+		case usecase.OpLte:
+			exprs = append(exprs, clause.Lte{Column: col, Value: cond.Value})
+		case usecase.OpLike:
+			s, ok := cond.Value.(string)
+			if !ok {
+				return nil, apperror.Validation(fmt.Sprintf("operator like requires a text value on field %q", cond.Field), nil)
+			}
+			exprs = append(exprs, clause.Expr{SQL: "? ILIKE ?", Vars: []interface{}{col, likePattern(s)}})
+		default:
+			return nil, apperror.Validation(fmt.Sprintf("unsupported operator %q", cond.Op), nil)
+		}
+	}
+	return exprs, nil
+}
+
+// order translates the sort into ORDER BY columns, with id as tie-breaker.
+func (cols Columns) order(sort []usecase.Order) ([]clause.OrderByColumn, error) {
+	order := make([]clause.OrderByColumn, 0, len(sort)+1)
+	for _, o := range sort {
+		col, err := cols.column(o.Field)
+		if err != nil {
+			return nil, err
+		}
+		order = append(order, clause.OrderByColumn{Column: col, Desc: o.Desc})
+	}
+	return append(order, clause.OrderByColumn{Column: clause.Column{Name: "id"}}), nil
+}
+
+// keyset builds the condition selecting rows strictly after the cursor for
+// the given order: (a > x) OR (a = x AND b > y) OR ..., flipping the
+// comparison on descending columns.
+func keyset(order []clause.OrderByColumn, values []interface{}) clause.Expression {
+	ors := make([]clause.Expression, 0, len(order))
+	for i, o := range order {
+		ands := make([]clause.Expression, 0, i+1)
+		for j := 0; j < i; j++ {
+			ands = append(ands, clause.Eq{Column: order[j].Column, Value: values[j]})
+		}
+		if o.Desc {
+			ands = append(ands, clause.Lt{Column: o.Column, Value: values[i]})
+		} else {
+			ands = append(ands, clause.Gt{Column: o.Column, Value: values[i]})
+		}
+		ors = append(ors, clause.And(ands...))
+	}
+	return clause.Or(ors...)
+}
+
+// likePattern turns a client pattern where * is the only wildcard into a
+// LIKE pattern, escaping LIKE metacharacters.
+func likePattern(s string) string {
+	return strings.ReplaceAll(likeEscaper.Replace(s), "*", "%")
+}
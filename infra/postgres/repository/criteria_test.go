@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DeSouzaRafael/go-clean-architecture-template/infra/postgres/model"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/apperror"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/usecase"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// dryRunDB builds statements without a database connection.
+func dryRunDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost"}), &gorm.Config{DryRun: true, DisableAutomaticPing: true})
+	require.NoError(t, err)
+	return db
+}
+
+func toSQL(db *gorm.DB, exprs []clause.Expression, order []clause.OrderByColumn) (string, []interface{}) {
+	stmt := db.Session(&gorm.Session{DryRun: true}).Model(&model.UserModel{}).
+		Clauses(clause.Where{Exprs: exprs}).Clauses(clause.OrderBy{Columns: order}).
+		Find(&[]model.UserModel{}).Statement
+	return stmt.SQL.String(), stmt.Vars
+}
+
+func TestColumns_Conditions(t *testing.T) {
+	since := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	where, err := userColumns.conditions([]usecase.Condition{
+		{Field: "name", Op: usecase.OpLike, Value: "ana_50%*"},
+		{Field: "created_at", Op: usecase.OpGte, Value: since},
+		{Field: "phone", Op: usecase.OpNe, Value: "1"},
+	})
+	require.NoError(t, err)
+	order, err := userColumns.order([]usecase.Order{{Field: "name", Desc: true}})
+	require.NoError(t, err)
+
+	sql, vars := toSQL(dryRunDB(t), where, order)
+
+	assert.Equal(t, `SELECT * FROM "user" WHERE "name" ILIKE $1 AND "created_at" >= $2 AND "phone" <> $3 AND "user"."deleted_at" IS NULL ORDER BY "name" DESC,"id"`, sql)
+	assert.Equal(t, []interface{}{`ana\_50\%%`, since, "1"}, vars)
+}
+
+func TestColumns_UnknownField(t *testing.T) {
+	_, err := userColumns.conditions([]usecase.Condition{{Field: "deleted_at", Op: usecase.OpEq, Value: "x"}})
+	assert.ErrorIs(t, err, apperror.ErrValidation)
+
+	_, err = userColumns.order([]usecase.Order{{Field: "name; DROP TABLE user"}})
+	assert.ErrorIs(t, err, apperror.ErrValidation)
+}
+
+func TestKeyset(t *testing.T) {
+	order, err := userColumns.order([]usecase.Order{{Field: "created_at", Desc: true}, {Field: "name"}})
+	require.NoError(t, err)
+	since := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	id := uuid.New()
+
+	sql, vars := toSQL(dryRunDB(t), []clause.Expression{keyset(order, []interface{}{since, "Ana", id})}, order)
+
+	assert.Equal(t, `SELECT * FROM "user" WHERE ("created_at" < $1 OR ("created_at" = $2 AND "name" > $3) OR ("created_at" = $4 AND "name" = $5 AND "id" > $6)) AND "user"."deleted_at" IS NULL ORDER BY "created_at" DESC,"name","id"`, sql)
+	assert.Equal(t, []interface{}{since, since, "Ana", since, "Ana", id}, vars)
+}
@@ -0,0 +1,48 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/apperror"
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+)
+
+// Postgres SQLSTATE codes, see https://www.postgresql.org/docs/current/errcodes-appendix.html
+const (
+	pgUniqueViolation     = "23505"
+	pgForeignKeyViolation = "23503"
+	pgNotNullViolation    = "23502"
+	pgCheckViolation      = "23514"
+	pgInvalidTextRepr     = "22P02"
+)
+
+// errVersionMismatch is returned when an optimistic lock finds the row
+// changed (or gone) since it was read.
+var errVersionMismatch = apperror.PreconditionFailed("record was modified concurrently", nil)
+
+// translateError maps driver and GORM errors to domain errors so that SQL
+// details never leave the repository layer as client-facing messages.
+func translateError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return apperror.NotFound("record not found", err)
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		switch pgErr.Code {
+		case pgUniqueViolation:
+			return apperror.Conflict("record already exists", err)
+		case pgForeignKeyViolation:
+			return apperror.Conflict("record is referenced by or references another record", err)
+		case pgNotNullViolation, pgCheckViolation, pgInvalidTextRepr:
+			return apperror.Validation("invalid record data", err)
+		}
+	}
+
+	return err
+}
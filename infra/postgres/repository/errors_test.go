@@ -0,0 +1,39 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/apperror"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestTranslateError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		kind apperror.Kind
+	}{
+		{"record not found", gorm.ErrRecordNotFound, apperror.KindNotFound},
+		{"unique violation", &pgconn.PgError{Code: pgUniqueViolation}, apperror.KindConflict},
+		{"foreign key violation", fmt.Errorf("wrapped: %w", &pgconn.PgError{Code: pgForeignKeyViolation}), apperror.KindConflict},
+		{"not null violation", &pgconn.PgError{Code: pgNotNullViolation}, apperror.KindValidation},
+		{"other pg error", &pgconn.PgError{Code: "42P01"}, apperror.KindInternal},
+		{"other error", errors.New("boom"), apperror.KindInternal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := translateError(tt.err)
+			assert.Equal(t, tt.kind, apperror.KindOf(err))
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+}
+
+func TestTranslateError_Nil(t *testing.T) {
+	assert.NoError(t, translateError(nil))
+}
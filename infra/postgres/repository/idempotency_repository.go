@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/DeSouzaRafael/go-clean-architecture-template/infra/postgres/model"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/entity"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type IdempotencyRepo struct {
+	*BaseRepo[model.IdempotencyKeyModel]
+}
+
+func NewIdempotencyRepo(db *gorm.DB) *IdempotencyRepo {
+	return &IdempotencyRepo{BaseRepo: NewBaseRepo[model.IdempotencyKeyModel](db)}
+}
+
+// Acquire inserts the record unless a live record with the same actor and
+// key exists.
+// An expired record is taken over in the same statement, so two concurrent
+// requests can never both acquire a key.
+func (r *IdempotencyRepo) Acquire(ctx context.Context, e entity.IdempotencyRecord) (bool, error) {
+	m := model.ToIdempotencyKeyModel(e)
+	res := r.conn(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "actor"}, {Name: "key"}},
+		DoUpdates: clause.AssignmentColumns([]string{"fingerprint", "status_code", "header", "body", "created_at", "expires_at"}),
+		Where: clause.Where{Exprs: []clause.Expression{
+			clause.Lte{Column: clause.Column{Table: m.TableName(), Name: "expires_at"}, Value: e.CreatedAt},
+		}},
+	}).Create(&m)
+	if res.Error != nil {
+		return false, translateError(res.Error)
+	}
+	return res.RowsAffected == 1, nil
+}
+
+func (r *IdempotencyRepo) Get(ctx context.Context, actor, key string) (entity.IdempotencyRecord, error) {
+	m, err := r.BaseRepo.Get(ctx, idempotencyKey(actor, key))
+	return model.ToIdempotencyRecord(m), err
+}
+
+// SaveResponse stores the response of an acquired record.
+func (r *IdempotencyRepo) SaveResponse(ctx context.Context, e entity.IdempotencyRecord) error {
+	_, err := r.BaseRepo.UpdateColumns(ctx, model.ToIdempotencyKeyModel(e), "status_code", "header", "body")
+	return err
+}
+
+func (r *IdempotencyRepo) Delete(ctx context.Context, actor, key string) error {
+	return r.BaseRepo.Delete(ctx, idempotencyKey(actor, key))
+}
+
+// DeleteExpired removes the records that expired before t.
+func (r *IdempotencyRepo) DeleteExpired(ctx context.Context, t time.Time) (int64, error) {
+	res := r.conn(ctx).Where("expires_at <= ?", t).Delete(&model.IdempotencyKeyModel{})
+	return res.RowsAffected, translateError(res.Error)
+}
+
+func idempotencyKey(actor, key string) clause.Expression {
+	return clause.And(
+		clause.Eq{Column: clause.Column{Name: "actor"}, Value: actor},
+		clause.Eq{Column: clause.Column{Name: "key"}, Value: key},
+	)
+}
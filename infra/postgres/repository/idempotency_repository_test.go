@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIdempotencyRepo_Acquire(t *testing.T) {
+	db, stmts := captureSQL(t)
+	now := time.Now()
+
+	acquired, err := NewIdempotencyRepo(db).Acquire(context.Background(), entity.IdempotencyRecord{
+		Actor: "user-1", Key: "k1", Fingerprint: "f", CreatedAt: now, ExpiresAt: now.Add(time.Hour),
+	})
+
+	require.NoError(t, err)
+	assert.False(t, acquired)
+	require.Len(t, *stmts, 1)
+	assert.Equal(t, `INSERT INTO "idempotency_key" ("actor","key","fingerprint","status_code","header","body","created_at","expires_at") VALUES ($1,$2,$3,$4,$5,$6,$7,$8) `+
+		`ON CONFLICT ("actor","key") DO UPDATE SET "fingerprint"="excluded"."fingerprint","status_code"="excluded"."status_code","header"="excluded"."header",`+
+		`"body"="excluded"."body","created_at"="excluded"."created_at","expires_at"="excluded"."expires_at" WHERE "idempotency_key"."expires_at" <= $9`, (*stmts)[0])
+}
+
+func TestIdempotencyRepo_Get(t *testing.T) {
+	db, stmts := captureSQL(t)
+
+	_, _ = NewIdempotencyRepo(db).Get(context.Background(), "user-1", "k1")
+
+	require.Len(t, *stmts, 1)
+	assert.Equal(t, `SELECT * FROM "idempotency_key" WHERE "actor" = $1 AND "key" = $2 ORDER BY "idempotency_key"."actor" LIMIT $3`, (*stmts)[0])
+}
+
+func TestIdempotencyRepo_Delete(t *testing.T) {
+	db, stmts := captureSQL(t)
+	repo := NewIdempotencyRepo(db)
+
+	require.NoError(t, repo.Delete(context.Background(), "user-1", "k1"))
+	_, err := repo.DeleteExpired(context.Background(), time.Now())
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		`DELETE FROM "idempotency_key" WHERE "actor" = $1 AND "key" = $2`,
+		`DELETE FROM "idempotency_key" WHERE expires_at <= $1`,
+	}, *stmts)
+}
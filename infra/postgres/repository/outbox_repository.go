@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/DeSouzaRafael/go-clean-architecture-template/infra/postgres/model"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/entity"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// outboxDue selects the pending messages that are due and have no older
+// pending message of the same aggregate, so each aggregate's events are
+// published in the order they occurred even when one of them is retried.
+const outboxDue = `published_at IS NULL AND next_attempt_at <= ? AND NOT EXISTS (
+	SELECT 1 FROM outbox AS prior
+	WHERE prior.aggregate_id = outbox.aggregate_id AND prior.published_at IS NULL AND prior.occurred_at < outbox.occurred_at)`
+
+type OutboxRepo struct {
+	*BaseRepo[model.OutboxModel]
+}
+
+func NewOutboxRepo(db *gorm.DB) *OutboxRepo {
+	return &OutboxRepo{BaseRepo: NewBaseRepo[model.OutboxModel](db)}
+}
+
+func (r *OutboxRepo) Append(ctx context.Context, e entity.Event) error {
+	_, err := r.BaseRepo.Create(ctx, model.ToOutboxModel(e))
+	return err
+}
+
+// Redact replaces the payload of every message of an aggregate, published
+// or not.
+func (r *OutboxRepo) Redact(ctx context.Context, aggregateType string, aggregateID uuid.UUID, payload []byte) error {
+	err := r.conn(ctx).Model(&model.OutboxModel{}).
+		Where("aggregate_type = ? AND aggregate_id = ?", aggregateType, aggregateID).
+		Update("payload", payload).Error
+	return translateError(err)
+}
+
+// Claim locks up to limit messages due at now. Rows locked by another relay
+// are skipped, so relays running side by side never claim the same message.
+// The locks are held until the transaction carried by ctx ends.
+func (r *OutboxRepo) Claim(ctx context.Context, now time.Time, limit int) ([]entity.OutboxMessage, error) {
+	var models []model.OutboxModel
+	err := r.conn(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+		Where(outboxDue, now).
+		Order("occurred_at").Order("id").
+		Limit(limit).
+		Find(&models).Error
+	if err != nil {
+		return nil, translateError(err)
+	}
+
+	msgs := make([]entity.OutboxMessage, 0, len(models))
+	for _, m := range models {
+		msgs = append(msgs, model.ToOutboxMessage(m))
+	}
+	return msgs, nil
+}
+
+// SaveDelivery stores the delivery state of a message.
+func (r *OutboxRepo) SaveDelivery(ctx context.Context, msg entity.OutboxMessage) error {
+	m := model.ToOutboxMessageModel(msg)
+	_, err := r.BaseRepo.UpdateColumns(ctx, m, "attempts", "next_attempt_at", "published_at", "last_error")
+	return err
+}
+
+// DeletePublished removes the messages published before t.
+func (r *OutboxRepo) DeletePublished(ctx context.Context, t time.Time) (int64, error) {
+	res := r.conn(ctx).Where("published_at < ?", t).Delete(&model.OutboxModel{})
+	return res.RowsAffected, translateError(res.Error)
+}
@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/entity"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutboxRepo_Append(t *testing.T) {
+	db, stmts := captureSQL(t)
+
+	err := NewOutboxRepo(db).Append(context.Background(), entity.Event{
+		ID: uuid.New(), Type: entity.UserCreated, AggregateType: "user", AggregateID: uuid.New(), Payload: []byte(`{}`), OccurredAt: time.Now(),
+	})
+
+	require.NoError(t, err)
+	require.Len(t, *stmts, 1)
+	assert.Equal(t, `INSERT INTO "outbox" ("id","event_type","aggregate_type","aggregate_id","payload","occurred_at","attempts","next_attempt_at","published_at","last_error") `+
+		`VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10)`, (*stmts)[0])
+}
+
+func TestOutboxRepo_Claim(t *testing.T) {
+	db, stmts := captureSQL(t)
+
+	msgs, err := NewOutboxRepo(db).Claim(context.Background(), time.Now(), 50)
+
+	require.NoError(t, err)
+	assert.Empty(t, msgs)
+	require.Len(t, *stmts, 1)
+	assert.Equal(t, `SELECT * FROM "outbox" WHERE published_at IS NULL AND next_attempt_at <= $1 AND NOT EXISTS (
+	SELECT 1 FROM outbox AS prior
+	WHERE prior.aggregate_id = outbox.aggregate_id AND prior.published_at IS NULL AND prior.occurred_at < outbox.occurred_at) `+
+		`ORDER BY occurred_at,id LIMIT $2 FOR UPDATE SKIP LOCKED`, (*stmts)[0])
+}
+
+func TestOutboxRepo_SaveDelivery(t *testing.T) {
+	db, stmts := captureSQL(t)
+	now := time.Now()
+
+	err := NewOutboxRepo(db).SaveDelivery(context.Background(), entity.OutboxMessage{
+		Event: entity.Event{ID: uuid.New()}, Attempts: 1, NextAttemptAt: now, PublishedAt: &now,
+	})
+
+	// DryRun affects no rows.
+	assert.Error(t, err)
+	require.Len(t, *stmts, 1)
+	assert.Equal(t, `UPDATE "outbox" SET "attempts"=$1,"next_attempt_at"=$2,"published_at"=$3,"last_error"=$4 WHERE "id" = $5`, (*stmts)[0])
+}
+
+func TestOutboxRepo_Redact(t *testing.T) {
+	db, stmts := captureSQL(t)
+
+	err := NewOutboxRepo(db).Redact(context.Background(), "user", uuid.New(), []byte(`{}`))
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{`UPDATE "outbox" SET "payload"=$1 WHERE aggregate_type = $2 AND aggregate_id = $3`}, *stmts)
+}
+
+func TestOutboxRepo_DeletePublished(t *testing.T) {
+	db, stmts := captureSQL(t)
+
+	_, err := NewOutboxRepo(db).DeletePublished(context.Background(), time.Now())
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{`DELETE FROM "outbox" WHERE published_at < $1`}, *stmts)
+}
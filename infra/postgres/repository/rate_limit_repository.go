@@ -0,0 +1,53 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/DeSouzaRafael/go-clean-architecture-template/infra/postgres/model"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/entity"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// RateLimitRepo keeps token buckets in Postgres, so that replicas share
+// the limits of a client.
+type RateLimitRepo struct {
+	*BaseRepo[model.RateLimitBucketModel]
+	now func() time.Time
+}
+
+func NewRateLimitRepo(db *gorm.DB) *RateLimitRepo {
+	return &RateLimitRepo{BaseRepo: NewBaseRepo[model.RateLimitBucketModel](db), now: time.Now}
+}
+
+// Take takes a token from the bucket of key. The bucket is created full if
+// needed, then locked until the transaction ends, so that two replicas never
+// take the same token.
+func (r *RateLimitRepo) Take(ctx context.Context, key string, l entity.RateLimit) (entity.RateLimitDecision, error) {
+	now := r.now()
+	var d entity.RateLimitDecision
+	err := r.conn(ctx).Transaction(func(tx *gorm.DB) error {
+		full := model.RateLimitBucketModel{Key: key, Tokens: float64(l.Burst), UpdatedAt: now, ExpiresAt: now}
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&full).Error; err != nil {
+			return err
+		}
+		var m model.RateLimitBucketModel
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Take(&m, clause.Eq{Column: clause.Column{Name: "key"}, Value: key}).Error; err != nil {
+			return err
+		}
+
+		var b entity.TokenBucket
+		b, d = model.ToTokenBucket(m).Take(l, now)
+		return tx.Model(&model.RateLimitBucketModel{Key: key}).
+			Select("tokens", "updated_at", "expires_at").
+			Updates(model.ToRateLimitBucketModel(key, b, b.UpdatedAt.Add(d.Reset))).Error
+	})
+	return d, translateError(err)
+}
+
+// DeleteExpired removes the buckets that are full again by t.
+func (r *RateLimitRepo) DeleteExpired(ctx context.Context, t time.Time) (int64, error) {
+	res := r.conn(ctx).Where("expires_at <= ?", t).Delete(&model.RateLimitBucketModel{})
+	return res.RowsAffected, translateError(res.Error)
+}
@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func TestRateLimitRepo_Take(t *testing.T) {
+	sqlDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer sqlDB.Close()
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{})
+	require.NoError(t, err)
+
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	repo := NewRateLimitRepo(db)
+	repo.now = func() time.Time { return now }
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`INSERT INTO "rate_limit_bucket" \("key","tokens","updated_at","expires_at"\) VALUES \(\$1,\$2,\$3,\$4\) ON CONFLICT DO NOTHING`).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(`SELECT \* FROM "rate_limit_bucket" WHERE "key" = \$1 LIMIT \$2 FOR UPDATE`).
+		WithArgs("write:ip:10.0.0.1", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"key", "tokens", "updated_at", "expires_at"}).
+			AddRow("write:ip:10.0.0.1", 0.5, now.Add(-time.Second), now.Add(4*time.Second)))
+	mock.ExpectExec(`UPDATE "rate_limit_bucket" SET "tokens"=\$1,"updated_at"=\$2,"expires_at"=\$3 WHERE "key" = \$4`).
+		WithArgs(0.5, now, now.Add(4500*time.Millisecond), "write:ip:10.0.0.1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	d, err := repo.Take(context.Background(), "write:ip:10.0.0.1", entity.RateLimit{Rate: 1, Burst: 5})
+
+	require.NoError(t, err)
+	assert.True(t, d.Allowed)
+	assert.Equal(t, 0, d.Remaining)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRateLimitRepo_DeleteExpired(t *testing.T) {
+	db, stmts := captureSQL(t)
+
+	_, err := NewRateLimitRepo(db).DeleteExpired(context.Background(), time.Now())
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{`DELETE FROM "rate_limit_bucket" WHERE expires_at <= $1`}, *stmts)
+}
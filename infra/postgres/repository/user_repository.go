@@ -2,12 +2,30 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/DeSouzaRafael/go-clean-architecture-template/infra/postgres/model"
 	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/entity"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/usecase"
+	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
+var userColumns = Columns{
+	"name":       "name",
+	"phone":      "phone",
+	"created_at": "created_at",
+	"updated_at": "updated_at",
+}
+
+var deletedUserColumns = Columns{
+	"name":       "name",
+	"phone":      "phone",
+	"created_at": "created_at",
+	"updated_at": "updated_at",
+	"deleted_at": deletedAtColumn,
+}
+
 type UserRepo struct {
 	*BaseRepo[model.UserModel]
 }
@@ -26,10 +44,107 @@ func (r *UserRepo) GetById(ctx context.Context, e entity.UserEntity) (entity.Use
 	return model.ToUserEntity(m), err
 }
 
+// Update writes the mutable fields of e if the stored version is still e.Version.
 func (r *UserRepo) Update(ctx context.Context, e entity.UserEntity) error {
-	return r.BaseRepo.Update(ctx, model.ToUserModel(e))
+	_, err := r.UpdateFields(ctx, e, "name", "phone")
+	return err
+}
+
+// UpdateFields persists only the given entity fields, plus updated_at, if the
+// stored version is still e.Version, and bumps the version.
+func (r *UserRepo) UpdateFields(ctx context.Context, e entity.UserEntity, fields ...string) (entity.UserEntity, error) {
+	columns := make([]string, 0, len(fields)+2)
+	for _, f := range fields {
+		col, err := userColumns.column(f)
+		if err != nil {
+			return entity.UserEntity{}, err
+		}
+		columns = append(columns, col.Name)
+	}
+
+	m := model.ToUserModel(e)
+	m.Version = e.Version + 1
+
+	m, err := r.BaseRepo.UpdateColumnsIfVersion(ctx, m, e.Version, append(columns, "updated_at", versionColumn)...)
+	if err != nil {
+		return entity.UserEntity{}, err
+	}
+	return model.ToUserEntity(m), nil
 }
 
+// DeleteById deletes the user if the stored version is still e.Version.
 func (r *UserRepo) DeleteById(ctx context.Context, e entity.UserEntity) error {
-	return r.BaseRepo.Delete(ctx, e.ID)
+	return r.BaseRepo.DeleteIfVersion(ctx, e.ID, e.Version)
+}
+
+// Restore undeletes a soft-deleted user and bumps its version.
+func (r *UserRepo) Restore(ctx context.Context, e entity.UserEntity) error {
+	return r.BaseRepo.Restore(ctx, e.ID)
+}
+
+// Purge permanently deletes a soft-deleted user.
+func (r *UserRepo) Purge(ctx context.Context, e entity.UserEntity) error {
+	return r.BaseRepo.Purge(ctx, e.ID)
+}
+
+// PurgeDeletedBefore permanently deletes the users soft-deleted before
+// cutoff and returns their IDs.
+func (r *UserRepo) PurgeDeletedBefore(ctx context.Context, cutoff time.Time) ([]uuid.UUID, error) {
+	rows, err := r.BaseRepo.PurgeDeletedBefore(ctx, cutoff)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]uuid.UUID, len(rows))
+	for i, m := range rows {
+		ids[i] = m.ID
+	}
+	return ids, nil
+}
+
+func (r *UserRepo) List(ctx context.Context, criteria usecase.Criteria, req usecase.PageRequest) (usecase.Page[entity.UserEntity], error) {
+	return r.list(ctx, userColumns, criteria, req, r.BaseRepo.List)
+}
+
+// ListDeleted lists soft-deleted users, which may also be filtered and
+// sorted on deleted_at.
+func (r *UserRepo) ListDeleted(ctx context.Context, criteria usecase.Criteria, req usecase.PageRequest) (usecase.Page[entity.UserEntity], error) {
+	return r.list(ctx, deletedUserColumns, criteria, req, r.BaseRepo.ListDeleted)
+}
+
+func (r *UserRepo) list(
+	ctx context.Context,
+	columns Columns,
+	criteria usecase.Criteria,
+	req usecase.PageRequest,
+	list func(context.Context, ListOptions) (ListResult[model.UserModel], error),
+) (usecase.Page[entity.UserEntity], error) {
+	where, err := columns.conditions(criteria.Conditions)
+	if err != nil {
+		return usecase.Page[entity.UserEntity]{}, err
+	}
+	order, err := columns.order(criteria.Sort)
+	if err != nil {
+		return usecase.Page[entity.UserEntity]{}, err
+	}
+
+	opts := ListOptions{Limit: req.Limit, Offset: req.Offset, Count: req.WithTotal, Where: where, Order: order}
+	if req.Cursor != nil {
+		opts.After = keyset(order, append(append([]interface{}{}, req.Cursor.Values...), req.Cursor.ID))
+	}
+
+	res, err := list(ctx, opts)
+	if err != nil {
+		return usecase.Page[entity.UserEntity]{}, err
+	}
+
+	page := usecase.Page[entity.UserEntity]{
+		Items:   make([]entity.UserEntity, 0, len(res.Items)),
+		Total:   res.Total,
+		HasMore: res.HasMore,
+	}
+	for _, m := range res.Items {
+		page.Items = append(page.Items, model.ToUserEntity(m))
+	}
+
+	return page, nil
 }
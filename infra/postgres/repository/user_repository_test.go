@@ -0,0 +1,109 @@
+package repository
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/apperror"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/entity"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/usecase"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// captureSQL records the statements built by a dry run session. Dry runs
+// never affect rows, so versioned writes always report a mismatch.
+func captureSQL(t *testing.T) (*gorm.DB, *[]string) {
+	t.Helper()
+	db := dryRunDB(t).Session(&gorm.Session{SkipDefaultTransaction: true})
+	var stmts []string
+	capture := func(tx *gorm.DB) { stmts = append(stmts, strings.TrimSpace(tx.Statement.SQL.String())) }
+	require.NoError(t, db.Callback().Create().After("gorm:create").Register("test:capture", capture))
+	require.NoError(t, db.Callback().Query().After("gorm:query").Register("test:capture", capture))
+	require.NoError(t, db.Callback().Update().After("gorm:update").Register("test:capture", capture))
+	require.NoError(t, db.Callback().Delete().After("gorm:delete").Register("test:capture", capture))
+	return db, &stmts
+}
+
+func TestUserRepo_UpdateFields_Version(t *testing.T) {
+	db, stmts := captureSQL(t)
+
+	_, err := NewUserRepo(db).UpdateFields(context.Background(), entity.UserEntity{ID: uuid.New(), Name: "Ana", Version: 3}, "name")
+
+	assert.ErrorIs(t, err, apperror.ErrPreconditionFailed)
+	require.Len(t, *stmts, 1)
+	assert.Equal(t, `UPDATE "user" SET "name"=$1,"updated_at"=$2,"version"=$3 WHERE version = $4 AND "user"."deleted_at" IS NULL AND "id" = $5`, (*stmts)[0])
+}
+
+func TestUserRepo_DeleteById_Version(t *testing.T) {
+	db, stmts := captureSQL(t)
+
+	err := NewUserRepo(db).DeleteById(context.Background(), entity.UserEntity{ID: uuid.New(), Version: 3})
+
+	assert.ErrorIs(t, err, apperror.ErrPreconditionFailed)
+	require.Len(t, *stmts, 1)
+	assert.Equal(t, `UPDATE "user" SET "deleted_at"=$1 WHERE version = $2 AND "user"."id" = $3 AND "user"."deleted_at" IS NULL`, (*stmts)[0])
+}
+
+func TestUserRepo_Restore(t *testing.T) {
+	db, stmts := captureSQL(t)
+
+	err := NewUserRepo(db).Restore(context.Background(), entity.UserEntity{ID: uuid.New()})
+
+	assert.ErrorIs(t, err, apperror.ErrNotFound)
+	require.Len(t, *stmts, 1)
+	assert.Equal(t, `UPDATE "user" SET "deleted_at"=$1,"version"=version + 1,"updated_at"=$2 WHERE deleted_at IS NOT NULL AND "user"."id" = $3`, (*stmts)[0])
+}
+
+func TestUserRepo_Purge(t *testing.T) {
+	db, stmts := captureSQL(t)
+
+	err := NewUserRepo(db).Purge(context.Background(), entity.UserEntity{ID: uuid.New()})
+
+	assert.ErrorIs(t, err, apperror.ErrNotFound)
+	require.Len(t, *stmts, 1)
+	assert.Equal(t, `DELETE FROM "user" WHERE deleted_at IS NOT NULL AND "user"."id" = $1`, (*stmts)[0])
+}
+
+func TestUserRepo_PurgeDeletedBefore(t *testing.T) {
+	db, stmts := captureSQL(t)
+
+	_, err := NewUserRepo(db).PurgeDeletedBefore(context.Background(), time.Now())
+
+	require.NoError(t, err)
+	require.Len(t, *stmts, 1)
+	assert.Equal(t, `DELETE FROM "user" WHERE deleted_at < $1 RETURNING *`, (*stmts)[0])
+}
+
+func TestUserRepo_ListDeleted(t *testing.T) {
+	db, stmts := captureSQL(t)
+
+	_, err := NewUserRepo(db).ListDeleted(context.Background(),
+		usecase.Criteria{
+			Conditions: []usecase.Condition{{Field: "deleted_at", Op: usecase.OpLt, Value: time.Now()}},
+			Sort:       []usecase.Order{{Field: "deleted_at", Desc: true}},
+		},
+		usecase.PageRequest{Limit: 10},
+	)
+
+	require.NoError(t, err)
+	require.Len(t, *stmts, 1)
+	assert.Equal(t, `SELECT * FROM "user" WHERE deleted_at IS NOT NULL AND "deleted_at" < $1 ORDER BY "deleted_at" DESC,"id" LIMIT $2`, (*stmts)[0])
+}
+
+func TestUserRepo_List_ExcludesDeleted(t *testing.T) {
+	db, stmts := captureSQL(t)
+
+	_, err := NewUserRepo(db).List(context.Background(), usecase.Criteria{}, usecase.PageRequest{Limit: 10})
+
+	require.NoError(t, err)
+	require.Len(t, *stmts, 1)
+	assert.Equal(t, `SELECT * FROM "user" WHERE "user"."deleted_at" IS NULL ORDER BY "id" LIMIT $1`, (*stmts)[0])
+
+	_, err = NewUserRepo(db).ListDeleted(context.Background(), usecase.Criteria{Sort: []usecase.Order{{Field: "unknown"}}}, usecase.PageRequest{Limit: 10})
+	assert.ErrorIs(t, err, apperror.ErrValidation)
+}
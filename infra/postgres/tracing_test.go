@@ -0,0 +1,51 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	gormpostgres "gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func TestTracingPlugin(t *testing.T) {
+	sqlDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer sqlDB.Close()
+	db, err := gorm.Open(gormpostgres.New(gormpostgres.Config{Conn: sqlDB}), &gorm.Config{})
+	require.NoError(t, err)
+
+	spans := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans))
+	require.NoError(t, db.Use(newTracingPlugin(tp)))
+
+	mock.ExpectQuery(`SELECT`).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("1"))
+	mock.ExpectQuery(`SELECT`).WillReturnError(errors.New("connection reset"))
+
+	ctx, parent := tp.Tracer("test").Start(context.Background(), "request")
+	var users []struct{ ID string }
+	db.WithContext(ctx).Table("user").Where("email = ?", "ana@example.com").Find(&users)
+	db.WithContext(ctx).Table("user").Where("email = ?", "ana@example.com").Find(&users)
+	parent.End()
+
+	ended := spans.Ended()
+	require.Len(t, ended, 3)
+	for _, s := range ended[:2] {
+		assert.Equal(t, "postgres.query", s.Name())
+		assert.Equal(t, parent.SpanContext().SpanID(), s.Parent().SpanID())
+		assert.Contains(t, s.Attributes(), attribute.String("db.query.text", `SELECT * FROM "user" WHERE email = $1`))
+		assert.Contains(t, s.Attributes(), attribute.String("db.collection.name", "user"))
+		assert.Contains(t, s.Attributes(), attribute.String("db.system.name", "postgresql"))
+	}
+	assert.Contains(t, ended[0].Attributes(), attribute.Int64("db.rows_affected", 1))
+	assert.Equal(t, codes.Unset, ended[0].Status().Code)
+	assert.Equal(t, codes.Error, ended[1].Status().Code)
+}
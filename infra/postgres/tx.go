@@ -0,0 +1,59 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/usecase"
+	"gorm.io/gorm"
+)
+
+type txKey struct{}
+
+// TxManager implements usecase.Transactor with GORM transactions.
+type TxManager struct {
+	db   *gorm.DB
+	opts usecase.TxOptions
+}
+
+// NewTxManager returns a TxManager whose transactions use opts unless a
+// RunInTx call overrides them.
+func NewTxManager(db *gorm.DB, opts ...usecase.TxOption) *TxManager {
+	return &TxManager{db: db, opts: usecase.ApplyTxOptions(usecase.TxOptions{}, opts...)}
+}
+
+func (m *TxManager) RunInTx(ctx context.Context, fn func(ctx context.Context) error, opts ...usecase.TxOption) error {
+	if tx, ok := ctx.Value(txKey{}).(*gorm.DB); ok {
+		// GORM runs a transaction started from a transaction in a savepoint.
+		return tx.WithContext(ctx).Transaction(func(sp *gorm.DB) error {
+			return fn(context.WithValue(ctx, txKey{}, sp))
+		})
+	}
+
+	o := usecase.ApplyTxOptions(m.opts, opts...)
+	return m.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(context.WithValue(ctx, txKey{}, tx))
+	}, &sql.TxOptions{Isolation: isolationLevel(o.Isolation), ReadOnly: o.ReadOnly})
+}
+
+// Conn returns the transaction carried by ctx, or db when there is none,
+// bound to ctx.
+func Conn(ctx context.Context, db *gorm.DB) *gorm.DB {
+	if tx, ok := ctx.Value(txKey{}).(*gorm.DB); ok {
+		return tx.WithContext(ctx)
+	}
+	return db.WithContext(ctx)
+}
+
+func isolationLevel(l usecase.IsolationLevel) sql.IsolationLevel {
+	switch l {
+	case usecase.IsolationReadCommitted:
+		return sql.LevelReadCommitted
+	case usecase.IsolationRepeatableRead:
+		return sql.LevelRepeatableRead
+	case usecase.IsolationSerializable:
+		return sql.LevelSerializable
+	default:
+		return sql.LevelDefault
+	}
+}
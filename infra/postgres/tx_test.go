@@ -0,0 +1,111 @@
+package postgres_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/infra/postgres"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/infra/postgres/repository"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/entity"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/usecase"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gormpostgres "gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func mockDB(t *testing.T) (*gorm.DB, sqlmock.Sqlmock) {
+	t.Helper()
+	sqlDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { sqlDB.Close() })
+
+	db, err := gorm.Open(gormpostgres.New(gormpostgres.Config{Conn: sqlDB}), &gorm.Config{})
+	require.NoError(t, err)
+	return db, mock
+}
+
+func TestTxManager_Commit(t *testing.T) {
+	db, mock := mockDB(t)
+	tm := postgres.NewTxManager(db, usecase.WithIsolation(usecase.IsolationSerializable))
+	repo := repository.NewUserRepo(db)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`UPDATE "user" SET "name"=\$1,"updated_at"=\$2,"version"=\$3 WHERE version = \$4`).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	err := tm.RunInTx(context.Background(), func(ctx context.Context) error {
+		_, err := repo.UpdateFields(ctx, entity.UserEntity{ID: uuid.New(), Name: "Ana", Version: 1}, "name")
+		return err
+	})
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTxManager_Rollback(t *testing.T) {
+	db, mock := mockDB(t)
+	tm := postgres.NewTxManager(db)
+	failure := errors.New("failure")
+
+	mock.ExpectBegin()
+	mock.ExpectRollback()
+
+	err := tm.RunInTx(context.Background(), func(ctx context.Context) error {
+		return failure
+	})
+
+	assert.ErrorIs(t, err, failure)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTxManager_RollbackOnPanic(t *testing.T) {
+	db, mock := mockDB(t)
+	tm := postgres.NewTxManager(db)
+
+	mock.ExpectBegin()
+	mock.ExpectRollback()
+
+	assert.Panics(t, func() {
+		_ = tm.RunInTx(context.Background(), func(ctx context.Context) error {
+			panic("boom")
+		})
+	})
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTxManager_NestedSavepoint(t *testing.T) {
+	db, mock := mockDB(t)
+	tm := postgres.NewTxManager(db)
+	failure := errors.New("failure")
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`SAVEPOINT sp`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`ROLLBACK TO SAVEPOINT sp`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	err := tm.RunInTx(context.Background(), func(ctx context.Context) error {
+		inner := tm.RunInTx(ctx, func(ctx context.Context) error {
+			return failure
+		})
+		assert.ErrorIs(t, inner, failure)
+		// The outer transaction survives a failed savepoint.
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestConn_WithoutTx(t *testing.T) {
+	db, _ := mockDB(t)
+	ctx := context.Background()
+
+	conn := postgres.Conn(ctx, db)
+
+	assert.Equal(t, db.Statement.ConnPool, conn.Statement.ConnPool)
+	assert.Equal(t, ctx, conn.Statement.Context)
+}
@@ -0,0 +1,51 @@
+package publisher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/entity"
+)
+
+// AllEvents subscribes a handler to every event type.
+const AllEvents entity.EventType = "*"
+
+type Handler func(context.Context, entity.Event) error
+
+// Bus publishes events to in-process subscribers. Handlers run synchronously
+// in subscription order; if any fails the event is published again later, so
+// handlers must tolerate redelivery.
+type Bus struct {
+	mu       sync.RWMutex
+	handlers map[entity.EventType][]Handler
+}
+
+func NewBus() *Bus {
+	return &Bus{handlers: make(map[entity.EventType][]Handler)}
+}
+
+// Subscribe registers h for events of type t, or of every type with AllEvents.
+func (b *Bus) Subscribe(t entity.EventType, h Handler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.handlers[t] = append(b.handlers[t], h)
+}
+
+func (b *Bus) Publish(ctx context.Context, e entity.Event) error {
+	b.mu.RLock()
+	handlers := append(append([]Handler(nil), b.handlers[e.Type]...), b.handlers[AllEvents]...)
+	b.mu.RUnlock()
+
+	var errs []error
+	for _, h := range handlers {
+		if err := h(ctx, e); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return fmt.Errorf("publisher - Bus - Publish: %w", err)
+	}
+	return nil
+}
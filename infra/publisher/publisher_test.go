@@ -0,0 +1,79 @@
+package publisher
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/entity"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBus_Publish(t *testing.T) {
+	bus := NewBus()
+	var got []string
+	bus.Subscribe(entity.UserCreated, func(_ context.Context, e entity.Event) error {
+		got = append(got, "created:"+string(e.Type))
+		return nil
+	})
+	bus.Subscribe(AllEvents, func(_ context.Context, e entity.Event) error {
+		got = append(got, "all:"+string(e.Type))
+		return nil
+	})
+
+	require.NoError(t, bus.Publish(context.Background(), entity.Event{Type: entity.UserCreated}))
+	require.NoError(t, bus.Publish(context.Background(), entity.Event{Type: entity.UserDeleted}))
+
+	assert.Equal(t, []string{"created:user.created", "all:user.created", "all:user.deleted"}, got)
+}
+
+func TestBus_PublishError(t *testing.T) {
+	bus := NewBus()
+	called := false
+	bus.Subscribe(AllEvents, func(context.Context, entity.Event) error { return errors.New("handler failed") })
+	bus.Subscribe(AllEvents, func(context.Context, entity.Event) error { called = true; return nil })
+
+	err := bus.Publish(context.Background(), entity.Event{Type: entity.UserCreated})
+
+	assert.EqualError(t, err, "publisher - Bus - Publish: handler failed")
+	assert.True(t, called)
+}
+
+func TestWriter_Publish(t *testing.T) {
+	var buf bytes.Buffer
+	e := entity.Event{
+		ID:            uuid.MustParse("8d3c1e0e-5b7a-4f2e-9a51-0f7c6a6b2d11"),
+		Type:          entity.UserCreated,
+		AggregateType: "user",
+		AggregateID:   uuid.MustParse("0b9e7a3c-2f44-4a4a-8c1d-6f1e5b7d9a20"),
+		Payload:       []byte(`{"name":"Ana"}`),
+		OccurredAt:    time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+	}
+
+	require.NoError(t, NewWriter(&buf).Publish(context.Background(), e))
+
+	assert.Equal(t, `{"id":"8d3c1e0e-5b7a-4f2e-9a51-0f7c6a6b2d11","type":"user.created","aggregate_type":"user",`+
+		`"aggregate_id":"0b9e7a3c-2f44-4a4a-8c1d-6f1e5b7d9a20","payload":{"name":"Ana"},"occurred_at":"2026-01-02T03:04:05Z"}`+"\n", buf.String())
+}
+
+func TestFile_Appends(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+	e := entity.Event{Type: entity.UserUpdated, Payload: []byte(`{}`)}
+
+	for range 2 {
+		w, err := NewFile(path)
+		require.NoError(t, err)
+		require.NoError(t, w.Publish(context.Background(), e))
+		require.NoError(t, w.Close())
+	}
+
+	b, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, 2, bytes.Count(b, []byte("\n")))
+}
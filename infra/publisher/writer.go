@@ -0,0 +1,83 @@
+package publisher
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/entity"
+	"github.com/google/uuid"
+)
+
+// Writer publishes events as JSON lines to w, for local use and debugging.
+type Writer struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+type event struct {
+	ID            uuid.UUID       `json:"id"`
+	Type          string          `json:"type"`
+	AggregateType string          `json:"aggregate_type"`
+	AggregateID   uuid.UUID       `json:"aggregate_id"`
+	Payload       json.RawMessage `json:"payload"`
+	OccurredAt    time.Time       `json:"occurred_at"`
+}
+
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{w: w}
+}
+
+func NewStdout() *Writer {
+	return NewWriter(os.Stdout)
+}
+
+// NewFile appends events to the file at path, creating it if needed.
+func NewFile(path string) (*Writer, error) {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("publisher - NewFile: %w", err)
+	}
+	return NewWriter(f), nil
+}
+
+// Publish writes one line per event. Files are synced before it returns, so
+// a published event survives a crash.
+func (p *Writer) Publish(_ context.Context, e entity.Event) error {
+	line, err := json.Marshal(event{
+		ID:            e.ID,
+		Type:          string(e.Type),
+		AggregateType: e.AggregateType,
+		AggregateID:   e.AggregateID,
+		Payload:       e.Payload,
+		OccurredAt:    e.OccurredAt,
+	})
+	if err != nil {
+		return fmt.Errorf("publisher - Writer - Publish: %w", err)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if _, err := p.w.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("publisher - Writer - Publish: %w", err)
+	}
+	if f, ok := p.w.(*os.File); ok && f != os.Stdout && f != os.Stderr {
+		if err := f.Sync(); err != nil {
+			return fmt.Errorf("publisher - Writer - Publish: %w", err)
+		}
+	}
+	return nil
+}
+
+// Close closes the underlying file. Standard output is left open.
+func (p *Writer) Close() error {
+	if c, ok := p.w.(io.Closer); ok && p.w != os.Stdout {
+		return c.Close()
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestStore returns a store whose clock only moves with advance.
+func newTestStore() (*MemoryStore, func(time.Duration)) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	s := NewMemoryStore()
+	s.now = func() time.Time { return now }
+	return s, func(d time.Duration) { now = now.Add(d) }
+}
+
+func take(t *testing.T, s *MemoryStore, key string, l entity.RateLimit) entity.RateLimitDecision {
+	t.Helper()
+	d, err := s.Take(context.Background(), key, l)
+	require.NoError(t, err)
+	return d
+}
+
+func TestMemoryStore_Take(t *testing.T) {
+	s, advance := newTestStore()
+	limit := entity.RateLimit{Rate: 1, Burst: 3}
+
+	for remaining := 2; remaining >= 0; remaining-- {
+		d := take(t, s, "write:ip:10.0.0.1", limit)
+		assert.True(t, d.Allowed)
+		assert.Equal(t, 3, d.Limit)
+		assert.Equal(t, remaining, d.Remaining)
+	}
+
+	d := take(t, s, "write:ip:10.0.0.1", limit)
+	assert.False(t, d.Allowed)
+	assert.Equal(t, time.Second, d.RetryAfter)
+	assert.Equal(t, 3*time.Second, d.Reset)
+
+	// Other clients have buckets of their own.
+	assert.True(t, take(t, s, "write:ip:10.0.0.2", limit).Allowed)
+
+	advance(500 * time.Millisecond)
+	d = take(t, s, "write:ip:10.0.0.1", limit)
+	assert.False(t, d.Allowed)
+	assert.Equal(t, 500*time.Millisecond, d.RetryAfter)
+
+	advance(500 * time.Millisecond)
+	d = take(t, s, "write:ip:10.0.0.1", limit)
+	assert.True(t, d.Allowed)
+	assert.Equal(t, 0, d.Remaining)
+}
+
+func TestMemoryStore_RefillCapped(t *testing.T) {
+	s, advance := newTestStore()
+	limit := entity.RateLimit{Rate: 10, Burst: 2}
+
+	take(t, s, "read:sub:1", limit)
+	advance(time.Hour)
+
+	d := take(t, s, "read:sub:1", limit)
+	assert.True(t, d.Allowed)
+	assert.Equal(t, 1, d.Remaining)
+}
+
+func TestMemoryStore_SweepsFullBuckets(t *testing.T) {
+	s, advance := newTestStore()
+	limit := entity.RateLimit{Rate: 1, Burst: 5}
+
+	take(t, s, "read:sub:1", limit)
+	advance(30 * time.Second)
+	take(t, s, "read:sub:2", limit)
+	advance(sweepInterval)
+	take(t, s, "read:sub:3", limit)
+
+	assert.Len(t, s.buckets, 1)
+	assert.Contains(t, s.buckets, "read:sub:3")
+}
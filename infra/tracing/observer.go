@@ -0,0 +1,41 @@
+package tracing
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/apperror"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/usecase"
+)
+
+const instrumentation = "github.com/DeSouzaRafael/go-clean-architecture-template"
+
+// Observer starts a span for every use case call, named after the use case
+// and method, such as usecase.user.GetUserById.
+type Observer struct {
+	tracer trace.Tracer
+}
+
+var _ usecase.Observer = (*Observer)(nil)
+
+func NewObserver(tp trace.TracerProvider) *Observer {
+	return &Observer{tracer: tp.Tracer(instrumentation)}
+}
+
+// Start ends the span with the error of the call. Internal errors mark the
+// span as failed; domain errors, such as not found, are recorded as events
+// only, since the use case handled them.
+func (o *Observer) Start(ctx context.Context, useCase, method string) (context.Context, func(error)) {
+	ctx, span := o.tracer.Start(ctx, "usecase."+useCase+"."+method)
+	return ctx, func(err error) {
+		if err != nil {
+			span.RecordError(err)
+			if apperror.KindOf(err) == apperror.KindInternal {
+				span.SetStatus(codes.Error, err.Error())
+			}
+		}
+		span.End()
+	}
+}
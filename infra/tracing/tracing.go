@@ -0,0 +1,104 @@
+// Package tracing sets up OpenTelemetry tracing.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.34.0"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+const (
+	ExporterNone   = "none"
+	ExporterStdout = "stdout"
+	ExporterOTLP   = "otlp"
+)
+
+type Options struct {
+	ServiceName    string
+	ServiceVersion string
+	// Exporter is none, stdout or otlp.
+	Exporter string
+	// Endpoint is the host:port of the OTLP gRPC collector.
+	Endpoint string
+	// Insecure sends spans to Endpoint without TLS.
+	Insecure bool
+	// SampleRatio is the share of new traces recorded, from 0 to 1. Traces
+	// started upstream follow the decision of their parent.
+	SampleRatio float64
+	// Output receives the stdout exporter spans. Defaults to os.Stdout.
+	Output io.Writer
+}
+
+// Provider is the tracer provider of the application.
+type Provider struct {
+	trace.TracerProvider
+	shutdown func(context.Context) error
+}
+
+// New returns a provider exporting spans as opts.Exporter says and makes
+// it, with the W3C trace context and baggage propagators, the global one.
+//
+// With the none exporter, spans are not recorded but the trace context of
+// incoming requests is still propagated, so logs carry its trace ID.
+func New(ctx context.Context, opts Options) (*Provider, error) {
+	var exporter sdktrace.SpanExporter
+	switch strings.ToLower(opts.Exporter) {
+	case ExporterNone, "":
+	case ExporterStdout:
+		w := opts.Output
+		if w == nil {
+			w = os.Stdout
+		}
+		var err error
+		if exporter, err = stdouttrace.New(stdouttrace.WithWriter(w)); err != nil {
+			return nil, fmt.Errorf("tracing - New - stdouttrace: %w", err)
+		}
+	case ExporterOTLP:
+		grpcOpts := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(opts.Endpoint)}
+		if opts.Insecure {
+			grpcOpts = append(grpcOpts, otlptracegrpc.WithInsecure())
+		}
+		var err error
+		if exporter, err = otlptracegrpc.New(ctx, grpcOpts...); err != nil {
+			return nil, fmt.Errorf("tracing - New - otlptracegrpc: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("tracing - New: unknown exporter %q", opts.Exporter)
+	}
+
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	if exporter == nil {
+		p := &Provider{TracerProvider: noop.NewTracerProvider(), shutdown: func(context.Context) error { return nil }}
+		otel.SetTracerProvider(p)
+		return p, nil
+	}
+
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(opts.SampleRatio))),
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.SchemaURL,
+			semconv.ServiceName(opts.ServiceName),
+			semconv.ServiceVersion(opts.ServiceVersion),
+		)),
+	)
+	otel.SetTracerProvider(tp)
+	return &Provider{TracerProvider: tp, shutdown: tp.Shutdown}, nil
+}
+
+// Shutdown exports the spans still buffered and stops the provider.
+func (p *Provider) Shutdown(ctx context.Context) error {
+	return p.shutdown(ctx)
+}
@@ -0,0 +1,65 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/apperror"
+)
+
+func TestNew_Stdout(t *testing.T) {
+	buf := &bytes.Buffer{}
+	p, err := New(context.Background(), Options{ServiceName: "users-api", Exporter: ExporterStdout, SampleRatio: 1, Output: buf})
+	require.NoError(t, err)
+
+	_, span := p.Tracer("test").Start(context.Background(), "work")
+	span.End()
+	require.NoError(t, p.Shutdown(context.Background()))
+
+	assert.Contains(t, buf.String(), `"Name":"work"`)
+	assert.Contains(t, buf.String(), "users-api")
+	assert.Same(t, p.TracerProvider, otel.GetTracerProvider())
+}
+
+func TestNew_None(t *testing.T) {
+	p, err := New(context.Background(), Options{Exporter: ExporterNone})
+	require.NoError(t, err)
+
+	_, span := p.Tracer("test").Start(context.Background(), "work")
+	assert.False(t, span.IsRecording())
+	assert.NoError(t, p.Shutdown(context.Background()))
+}
+
+func TestNew_UnknownExporter(t *testing.T) {
+	_, err := New(context.Background(), Options{Exporter: "zipkin"})
+	assert.ErrorContains(t, err, `unknown exporter "zipkin"`)
+}
+
+func TestObserver(t *testing.T) {
+	spans := tracetest.NewSpanRecorder()
+	obs := NewObserver(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans)))
+
+	for _, err := range []error{nil, apperror.NotFound("user not found", nil), errors.New("connection reset")} {
+		ctx, done := obs.Start(context.Background(), "user", "GetUserById")
+		assert.True(t, trace.SpanFromContext(ctx).IsRecording())
+		done(err)
+	}
+
+	ended := spans.Ended()
+	require.Len(t, ended, 3)
+	assert.Equal(t, "usecase.user.GetUserById", ended[0].Name())
+	assert.Equal(t, codes.Unset, ended[0].Status().Code)
+	assert.Equal(t, codes.Unset, ended[1].Status().Code)
+	assert.Len(t, ended[1].Events(), 1)
+	assert.Equal(t, codes.Error, ended[2].Status().Code)
+}
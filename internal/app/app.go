@@ -1,50 +1,153 @@
 package app
 
 import (
-	"fmt"
+	"context"
+	"log/slog"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"github.com/DeSouzaRafael/go-clean-architecture-template/config"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/infra/grpcserver"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/infra/health"
 	"github.com/DeSouzaRafael/go-clean-architecture-template/infra/httpserver"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/infra/lifecycle"
 	"github.com/DeSouzaRafael/go-clean-architecture-template/infra/logger"
 	"github.com/DeSouzaRafael/go-clean-architecture-template/infra/postgres"
-	postgresModel "github.com/DeSouzaRafael/go-clean-architecture-template/infra/postgres/model"
 	"github.com/DeSouzaRafael/go-clean-architecture-template/infra/postgres/repository"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/infra/tracing"
 	"github.com/DeSouzaRafael/go-clean-architecture-template/infra/validator"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/controller/grpc"
 	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/controller/rest"
+	restMiddleware "github.com/DeSouzaRafael/go-clean-architecture-template/internal/controller/rest/middleware"
 	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/usecase"
 	"github.com/labstack/echo/v4"
+	"go.opentelemetry.io/otel/trace"
 )
 
 func Run(cfg *config.Config) {
-	l := logger.NewLogger(cfg.Log.Level)
+	l := newLogger(cfg.Log)
+	slog.SetDefault(l.Slog())
 	v := validator.NewValidator()
+	lc := lifecycle.New(l, cfg.App.ShutdownTimeout)
 
-	pg, err := postgres.NewPostgres(postgres.Options{
-		URL:             cfg.PG.URL,
-		MaxOpenConns:    cfg.PG.MaxOpenConns,
-		MaxIdleConns:    cfg.PG.MaxIdleConns,
-		ConnMaxLifetime: cfg.PG.ConnMaxLifetime,
-	})
+	tp, stopTracing, err := newTracing(cfg)
+	if err != nil {
+		l.Fatal("app - Run - newTracing", logger.Err(err))
+	}
+	lc.Append(lifecycle.Hook{Name: "tracing", Stop: stopTracing})
+
+	pg, err := newPostgres(cfg, l, tp)
 	if err != nil {
-		l.Fatal(fmt.Errorf("app - Run - NewPostgres: %w", err))
+		l.Fatal("app - Run - NewPostgres", logger.Err(err))
 	}
-	defer pg.Close()
+	// Queries are traced, so tracing stops after the database.
+	lc.Append(lifecycle.Hook{Name: "postgres", DependsOn: []string{"tracing"}, Stop: func(context.Context) error {
+		pg.Close()
+		return nil
+	}})
 
-	if cfg.App.Env != "prd" {
-		if err := pg.DB.AutoMigrate(&postgresModel.UserModel{}); err != nil {
-			l.Fatal(fmt.Errorf("app - Run - AutoMigrate: %w", err))
+	if cfg.PG.MigrateOnStart {
+		if err := migrateUp(pg, l); err != nil {
+			l.Fatal("app - Run - migrateUp", logger.Err(err))
 		}
 	}
 
-	userUseCase := usecase.NewUser(repository.NewUserRepo(pg.DB))
-	appUseCases := usecase.NewAppUseCases(userUseCase)
+	txManager, err := newTxManager(cfg, pg)
+	if err != nil {
+		l.Fatal("app - Run - newTxManager", logger.Err(err))
+	}
+
+	eventPublisher, closePublisher, err := newPublisher(cfg.Outbox)
+	if err != nil {
+		l.Fatal("app - Run - newPublisher", logger.Err(err))
+	}
+	lc.Append(lifecycle.Hook{Name: "publisher", Stop: func(context.Context) error { return closePublisher() }})
+
+	verifier, err := newVerifier(cfg.Auth)
+	if err != nil {
+		l.Fatal("app - Run - newVerifier", logger.Err(err))
+	}
+	var (
+		restTokens restMiddleware.TokenVerifier
+		grpcTokens grpc.TokenVerifier
+		authz      usecase.Authorizer = usecase.DefaultPolicy
+	)
+	if verifier != nil {
+		restTokens, grpcTokens = verifier, verifier
+	} else {
+		l.Warn("app - Run - authentication is disabled")
+		authz = usecase.AllowAll
+	}
+
+	userUseCase := usecase.NewUser(repository.NewUserRepo(pg.DB), txManager, repository.NewAuditRepo(pg.DB), repository.NewOutboxRepo(pg.DB), authz)
+	outboxUseCase := usecase.NewOutbox(repository.NewOutboxRepo(pg.DB), txManager, eventPublisher, usecase.OutboxOptions{
+		BatchSize:  cfg.Outbox.BatchSize,
+		MaxBackoff: cfg.Outbox.MaxBackoff,
+		Retention:  cfg.Outbox.Retention,
+	})
+	idempotencyUseCase := usecase.NewIdempotency(repository.NewIdempotencyRepo(pg.DB), cfg.Idempotency.TTL)
+	apiKeyUseCase := usecase.NewAPIKey(repository.NewAPIKeyRepo(pg.DB), authz)
+	// Changing the log level always requires an admin, even with
+	// authentication disabled.
+	logLevelUseCase := usecase.NewLogLevel(l.Level(), usecase.DefaultPolicy)
+	appUseCases := usecase.NewAppUseCases(userUseCase, idempotencyUseCase, apiKeyUseCase, logLevelUseCase)
+
+	probes, err := newHealth(pg)
+	if err != nil {
+		l.Fatal("app - Run - newHealth", logger.Err(err))
+	}
+
+	appMetrics, err := newMetrics(cfg.Metrics, pg)
+	if err != nil {
+		l.Fatal("app - Run - newMetrics", logger.Err(err))
+	}
+	observers := []usecase.Observer{tracing.NewObserver(tp)}
+	if appMetrics != nil {
+		observers = append(observers, appMetrics)
+	}
+	appUseCases = usecase.ObserveUseCases(appUseCases, usecase.Observers(observers...))
+
+	lc.Append(purgeIdempotencyKeys(idempotencyUseCase, cfg.Idempotency.TTL, l, probes))
+	lc.Append(relayOutbox(outboxUseCase, cfg.Outbox.PollInterval, l, probes))
+
+	rateLimits, err := newRateLimits(cfg.RateLimit, pg, l, probes, lc)
+	if err != nil {
+		l.Fatal("app - Run - newRateLimits", logger.Err(err))
+	}
 
 	handler := echo.New()
-	rest.NewRouter(handler, l, v, appUseCases, cfg.App.Env)
-	httpServer := httpserver.New(handler, httpserver.Port(cfg.HTTP.Port))
+	router := rest.NewRouter(handler, l, v, appUseCases, cfg.App.Env, restTokens, rateLimits, restMetrics(cfg.Metrics, appMetrics), tp, probes)
+	router.SetCORSOrigins(cfg.HTTP.CORSOrigins)
+
+	lc.Append(watchConfig(config.NewWatcher(cfg), l, router))
+
+	httpServer := httpserver.New(handler, httpserver.Port(cfg.HTTP.Port), httpserver.ShutdownTimeout(cfg.HTTP.ShutdownTimeout))
+	grpcHandler, grpcHealth := grpc.NewServer(l, v, appUseCases, grpcTokens, probes)
+	grpcServer := grpcserver.New(grpcHandler, grpcserver.Port(cfg.GRPC.Port), grpcserver.ShutdownTimeout(cfg.GRPC.ShutdownTimeout))
+	lc.Append(followReadiness(grpcHealth))
+	lc.Append(serverHook("http", httpServer, "postgres"))
+	lc.Append(serverHook("grpc", grpcServer, "postgres"))
+	servers := []string{"http", "grpc"}
+	metricsServer := newMetricsServer(cfg.Metrics, appMetrics)
+	var metricsNotify <-chan error
+	if metricsServer != nil {
+		metricsNotify = metricsServer.Notify()
+		lc.Append(serverHook("metrics", metricsServer, "postgres"))
+		servers = append(servers, "metrics")
+	}
+	// Readiness and the gRPC health service fail before the servers stop, so
+	// that load balancers stop routing requests here while they drain.
+	lc.Append(lifecycle.Hook{Name: "readiness", DependsOn: servers, Stop: func(context.Context) error {
+		probes.Shutdown()
+		grpcHealth.Shutdown()
+		return nil
+	}})
+
+	if err := lc.Start(context.Background()); err != nil {
+		l.Fatal("app - Run - lc.Start", logger.Err(err))
+	}
 
 	interrupt := make(chan os.Signal, 1)
 	signal.Notify(interrupt, os.Interrupt, syscall.SIGTERM)
@@ -52,14 +155,82 @@ func Run(cfg *config.Config) {
 	l.Info("Server is running...")
 	select {
 	case s := <-interrupt:
-		l.Info("app - Run - signal: %s", s.String())
-		if err := httpServer.Shutdown(); err != nil {
-			l.Error(fmt.Errorf("app - Run - httpServer.Shutdown: %w", err))
-		}
+		l.Info("app - Run - signal", "signal", s.String())
 	case err = <-httpServer.Notify():
-		l.Error(fmt.Errorf("app - Run - httpServer.Notify: %w", err))
-		if err := httpServer.Shutdown(); err != nil {
-			l.Error(fmt.Errorf("app - Run - httpServer.Shutdown: %w", err))
-		}
+		l.Error("app - Run - httpServer.Notify", logger.Err(err))
+	case err = <-grpcServer.Notify():
+		l.Error("app - Run - grpcServer.Notify", logger.Err(err))
+	case err = <-metricsNotify:
+		l.Error("app - Run - metricsServer.Notify", logger.Err(err))
+	}
+
+	if err := lc.Stop(context.Background()); err != nil {
+		l.Error("app - Run - lc.Stop", logger.Err(err))
 	}
 }
+
+type server interface {
+	Start() error
+	Shutdown(ctx context.Context) error
+}
+
+// serverHook returns the hook starting s and draining it on shutdown.
+func serverHook(name string, s server, dependsOn ...string) lifecycle.Hook {
+	return lifecycle.Hook{
+		Name:      name,
+		DependsOn: dependsOn,
+		Start:     func(context.Context) error { return s.Start() },
+		Stop:      s.Shutdown,
+	}
+}
+
+// newLogger returns the application logger. Run also makes it the default
+// slog logger, so libraries logging with log/slog or log share its output.
+func newLogger(cfg config.Log) *logger.Logger {
+	return logger.New(logger.Options{Level: cfg.Level, Format: cfg.Format})
+}
+
+// newPostgres returns the database. Queries are traced when tp is not nil.
+func newPostgres(cfg *config.Config, l logger.Interface, tp trace.TracerProvider) (*postgres.Postgres, error) {
+	return postgres.NewPostgres(postgres.Options{
+		Logger:          l,
+		TracerProvider:  tp,
+		URL:             cfg.PG.URL,
+		MaxOpenConns:    cfg.PG.MaxOpenConns,
+		MaxIdleConns:    cfg.PG.MaxIdleConns,
+		ConnMaxLifetime: cfg.PG.ConnMaxLifetime,
+	})
+}
+
+func newTxManager(cfg *config.Config, pg *postgres.Postgres) (*postgres.TxManager, error) {
+	isolation, err := usecase.ParseIsolationLevel(cfg.PG.TxIsolation)
+	if err != nil {
+		return nil, err
+	}
+	return postgres.NewTxManager(pg.DB, usecase.WithIsolation(isolation)), nil
+}
+
+// purgeIdempotencyKeys periodically deletes expired idempotency keys.
+func purgeIdempotencyKeys(uc usecase.Idempotency, ttl time.Duration, l logger.Interface, probes *health.Registry) lifecycle.Hook {
+	interval := min(ttl, time.Hour)
+	if interval <= 0 {
+		interval = time.Hour
+	}
+	hb := workerHeartbeat(probes, "idempotency_purge", interval)
+
+	return lifecycle.Worker("idempotency_purge", func(ctx context.Context) {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if _, err := uc.PurgeExpired(ctx); err != nil {
+					l.Error("app - purgeIdempotencyKeys", logger.Err(err))
+				}
+				hb.Beat()
+			}
+		}
+	}, "postgres")
+}
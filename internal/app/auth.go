@@ -0,0 +1,32 @@
+package app
+
+import (
+	"os"
+
+	"github.com/DeSouzaRafael/go-clean-architecture-template/config"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/infra/auth"
+)
+
+// newVerifier returns the bearer token verifier, or nil when authentication
+// is disabled.
+func newVerifier(cfg config.Auth) (*auth.Verifier, error) {
+	if !cfg.Enabled {
+		return nil, nil
+	}
+
+	opts := auth.Options{
+		Issuer:     cfg.Issuer,
+		Audience:   cfg.Audience,
+		ClockSkew:  cfg.ClockSkew,
+		HMACSecret: []byte(cfg.HS256Secret),
+		JWKSFile:   cfg.JWKSFile,
+	}
+	if cfg.PublicKeyFile != "" {
+		pem, err := os.ReadFile(cfg.PublicKeyFile)
+		if err != nil {
+			return nil, err
+		}
+		opts.PublicKeyPEM = pem
+	}
+	return auth.New(opts)
+}
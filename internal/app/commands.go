@@ -0,0 +1,97 @@
+package app
+
+import (
+	"fmt"
+
+	"github.com/DeSouzaRafael/go-clean-architecture-template/config"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/infra/postgres"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/infra/postgres/repository"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/controller/cli"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/usecase"
+)
+
+// Commands wires the dependencies of the command line. The configuration
+// is only loaded when a command needs it, so --help works without one.
+type Commands struct {
+	loadConfig func(config.Sources) (*config.Config, error)
+	sources    config.Sources
+	cfg        *config.Config
+}
+
+var _ cli.Provider = (*Commands)(nil)
+
+func NewCommands(loadConfig func(config.Sources) (*config.Config, error)) *Commands {
+	return &Commands{loadConfig: loadConfig}
+}
+
+func (c *Commands) SetSources(src config.Sources) {
+	c.sources = src
+}
+
+func (c *Commands) Config() (*config.Config, error) {
+	return c.config()
+}
+
+func (c *Commands) Serve() error {
+	cfg, err := c.config()
+	if err != nil {
+		return err
+	}
+
+	Run(cfg)
+	return nil
+}
+
+func (c *Commands) Migrator() (cli.Migrator, func(), error) {
+	pg, err := c.postgres()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	m, err := newMigrator(pg)
+	if err != nil {
+		pg.Close()
+		return nil, nil, err
+	}
+
+	return m, pg.Close, nil
+}
+
+func (c *Commands) UserUseCase() (usecase.User, func(), error) {
+	pg, err := c.postgres()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	txManager, err := newTxManager(c.cfg, pg)
+	if err != nil {
+		pg.Close()
+		return nil, nil, err
+	}
+
+	return usecase.NewUser(repository.NewUserRepo(pg.DB), txManager, repository.NewAuditRepo(pg.DB), repository.NewOutboxRepo(pg.DB), usecase.DefaultPolicy), pg.Close, nil
+}
+
+func (c *Commands) config() (*config.Config, error) {
+	if c.cfg == nil {
+		cfg, err := c.loadConfig(c.sources)
+		if err != nil {
+			return nil, fmt.Errorf("config error: %w", err)
+		}
+		c.cfg = cfg
+	}
+	return c.cfg, nil
+}
+
+func (c *Commands) postgres() (*postgres.Postgres, error) {
+	cfg, err := c.config()
+	if err != nil {
+		return nil, err
+	}
+
+	pg, err := newPostgres(cfg, newLogger(cfg.Log), nil)
+	if err != nil {
+		return nil, fmt.Errorf("app - Commands - NewPostgres: %w", err)
+	}
+	return pg, nil
+}
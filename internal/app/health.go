@@ -0,0 +1,57 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	"github.com/DeSouzaRafael/go-clean-architecture-template/infra/health"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/infra/lifecycle"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/infra/postgres"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/controller/grpc"
+)
+
+// newHealth returns the health registry with the database checks: the
+// application is ready when Postgres answers and its migrations are applied.
+// The background workers register their own checks as they start.
+func newHealth(pg *postgres.Postgres) (*health.Registry, error) {
+	m, err := newMigrator(pg)
+	if err != nil {
+		return nil, err
+	}
+
+	probes := health.NewRegistry()
+	probes.Register("postgres", pg.Ping, health.CheckOptions{Critical: true, Timeout: time.Second})
+	// Applied migrations stay applied, so they are checked less often.
+	probes.Register("migrations", m.Verify, health.CheckOptions{Critical: true, CacheTTL: 30 * time.Second})
+	return probes, nil
+}
+
+// workerHeartbeat registers a liveness check named name that fails when the
+// worker running every interval made no progress for a few intervals, as
+// restarting is the way out of a stuck worker.
+func workerHeartbeat(probes *health.Registry, name string, interval time.Duration) *health.Heartbeat {
+	hb := health.NewHeartbeat(3*interval + time.Minute)
+	probes.Register(name, hb.Check, health.CheckOptions{Critical: true, Liveness: true})
+	return hb
+}
+
+// grpcHealthInterval is how often the gRPC health service follows readiness,
+// which caches most check results for as long.
+const grpcHealthInterval = 5 * time.Second
+
+// followReadiness keeps the gRPC health service in line with readiness, as
+// gRPC clients and load balancers watch it rather than the HTTP probes.
+func followReadiness(hs *grpc.Health) lifecycle.Hook {
+	return lifecycle.Worker("grpc_health", func(ctx context.Context) {
+		ticker := time.NewTicker(grpcHealthInterval)
+		defer ticker.Stop()
+		for {
+			hs.Update(ctx)
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}, "postgres")
+}
@@ -0,0 +1,52 @@
+package app
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/DeSouzaRafael/go-clean-architecture-template/config"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/infra/httpserver"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/infra/metrics"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/infra/postgres"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/controller/rest"
+)
+
+// newMetrics returns the metrics of the application, with the connection
+// pool statistics of pg, or nil when metrics are disabled.
+func newMetrics(cfg config.Metrics, pg *postgres.Postgres) (*metrics.Metrics, error) {
+	if !cfg.Enabled {
+		return nil, nil
+	}
+
+	m := metrics.New()
+	sqlDB, err := pg.DB.DB()
+	if err != nil {
+		return nil, fmt.Errorf("app - newMetrics - DB: %w", err)
+	}
+	if err := m.RegisterDB("postgres", sqlDB); err != nil {
+		return nil, fmt.Errorf("app - newMetrics - RegisterDB: %w", err)
+	}
+	return m, nil
+}
+
+// restMetrics returns the router configuration for m. /metrics is left out
+// of the router when it has a port of its own.
+func restMetrics(cfg config.Metrics, m *metrics.Metrics) rest.Metrics {
+	if m == nil {
+		return rest.Metrics{}
+	}
+	if cfg.Port != "" {
+		return rest.Metrics{HTTP: m}
+	}
+	return rest.Metrics{HTTP: m, Handler: m.Handler()}
+}
+
+// newMetricsServer serves m on cfg.Port, when set.
+func newMetricsServer(cfg config.Metrics, m *metrics.Metrics) *httpserver.Server {
+	if m == nil || cfg.Port == "" {
+		return nil
+	}
+	mux := http.NewServeMux()
+	mux.Handle("GET /metrics", m.Handler())
+	return httpserver.New(mux, httpserver.Port(cfg.Port))
+}
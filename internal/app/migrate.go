@@ -0,0 +1,32 @@
+package app
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/DeSouzaRafael/go-clean-architecture-template/infra/logger"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/infra/postgres"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/infra/postgres/migrate"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/infra/postgres/migrations"
+)
+
+func migrateUp(pg *postgres.Postgres, l logger.Interface) error {
+	m, err := newMigrator(pg)
+	if err != nil {
+		return err
+	}
+
+	applied, err := m.Up(context.Background())
+	for _, mig := range applied {
+		l.Info("app - migrateUp - applied", "version", mig.Version, "name", mig.Name)
+	}
+	return err
+}
+
+func newMigrator(pg *postgres.Postgres) (*migrate.Migrator, error) {
+	sqlDB, err := pg.DB.DB()
+	if err != nil {
+		return nil, fmt.Errorf("app - newMigrator: %w", err)
+	}
+	return migrate.New(sqlDB, migrations.FS)
+}
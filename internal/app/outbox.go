@@ -0,0 +1,73 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/DeSouzaRafael/go-clean-architecture-template/config"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/infra/health"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/infra/lifecycle"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/infra/logger"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/infra/publisher"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/usecase"
+)
+
+// newPublisher returns the publisher selected by cfg.Publisher and a
+// function that releases it.
+func newPublisher(cfg config.Outbox) (usecase.Publisher, func() error, error) {
+	switch cfg.Publisher {
+	case "stdout":
+		return publisher.NewStdout(), func() error { return nil }, nil
+	case "file":
+		p, err := publisher.NewFile(cfg.File)
+		if err != nil {
+			return nil, nil, err
+		}
+		return p, p.Close, nil
+	case "bus":
+		// Register in-process consumers on the bus with Subscribe.
+		return publisher.NewBus(), func() error { return nil }, nil
+	default:
+		return nil, nil, fmt.Errorf("unknown outbox publisher %q", cfg.Publisher)
+	}
+}
+
+// relayOutbox publishes outbox messages every interval, draining the outbox
+// before waiting again, and deletes old published messages hourly. Stopping
+// it waits for the batch in flight.
+func relayOutbox(uc usecase.Outbox, interval time.Duration, l logger.Interface, probes *health.Registry) lifecycle.Hook {
+	if interval <= 0 {
+		interval = time.Second
+	}
+	hb := workerHeartbeat(probes, "outbox_relay", interval)
+
+	return lifecycle.Worker("outbox_relay", func(ctx context.Context) {
+		relay := time.NewTicker(interval)
+		defer relay.Stop()
+		purge := time.NewTicker(time.Hour)
+		defer purge.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-relay.C:
+				for ctx.Err() == nil {
+					n, err := uc.Relay(ctx)
+					hb.Beat()
+					if err != nil {
+						l.Error("app - relayOutbox", logger.Err(err))
+					}
+					if err != nil || n == 0 {
+						break
+					}
+				}
+			case <-purge.C:
+				if _, err := uc.PurgePublished(ctx); err != nil {
+					l.Error("app - relayOutbox", logger.Err(err))
+				}
+				hb.Beat()
+			}
+		}
+	}, "postgres", "publisher")
+}
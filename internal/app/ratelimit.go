@@ -0,0 +1,63 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/DeSouzaRafael/go-clean-architecture-template/config"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/infra/health"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/infra/lifecycle"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/infra/logger"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/infra/postgres"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/infra/postgres/repository"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/infra/ratelimit"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/controller/rest"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/entity"
+)
+
+// newRateLimits returns the REST rate limits with the store selected by
+// cfg.Store. The postgres store is purged by a worker appended to lc.
+func newRateLimits(cfg config.RateLimit, pg *postgres.Postgres, l logger.Interface, probes *health.Registry, lc *lifecycle.Manager) (rest.RateLimits, error) {
+	if !cfg.Enabled {
+		return rest.RateLimits{}, nil
+	}
+
+	limits := rest.RateLimits{Read: rateLimit(cfg.Read), Write: rateLimit(cfg.Write), IP: rateLimit(cfg.IP)}
+	switch cfg.Store {
+	case "memory":
+		limits.Store = ratelimit.NewMemoryStore()
+		return limits, nil
+	case "postgres":
+		repo := repository.NewRateLimitRepo(pg.DB)
+		limits.Store = repo
+		lc.Append(purgeRateLimitBuckets(repo, l, probes))
+		return limits, nil
+	default:
+		return rest.RateLimits{}, fmt.Errorf("unknown rate limit store %q", cfg.Store)
+	}
+}
+
+func rateLimit(r config.RateLimitRule) entity.RateLimit {
+	return entity.RateLimit{Rate: float64(r.PerMinute) / 60, Burst: r.Burst}
+}
+
+// purgeRateLimitBuckets deletes full buckets every minute.
+func purgeRateLimitBuckets(repo *repository.RateLimitRepo, l logger.Interface, probes *health.Registry) lifecycle.Hook {
+	hb := workerHeartbeat(probes, "rate_limit_purge", time.Minute)
+	return lifecycle.Worker("rate_limit_purge", func(ctx context.Context) {
+		ticker := time.NewTicker(time.Minute)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if _, err := repo.DeleteExpired(ctx, time.Now()); err != nil {
+					l.Error("app - purgeRateLimitBuckets", logger.Err(err))
+				}
+				hb.Beat()
+			}
+		}
+	}, "postgres")
+}
@@ -0,0 +1,64 @@
+package app
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/DeSouzaRafael/go-clean-architecture-template/config"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/infra/lifecycle"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/infra/logger"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/controller/rest"
+)
+
+// watchConfig reloads the configuration on SIGHUP and whenever its files
+// change, and applies the reloadable settings: the log level, rate limits
+// and CORS origins. Feature flags are read from w.Config() where they are
+// used.
+func watchConfig(w *config.Watcher, l *logger.Logger, router *rest.Router) lifecycle.Hook {
+	config.Watch(w, func(c *config.Config) string { return c.Log.Level }, func(level string) {
+		if err := l.Level().Set(level); err != nil {
+			l.Error("app - watchConfig - log level", logger.Err(err))
+		}
+	})
+	config.Watch(w, func(c *config.Config) config.RateLimit { return c.RateLimit }, func(rl config.RateLimit) {
+		router.SetRateLimits(rateLimit(rl.Read), rateLimit(rl.Write), rateLimit(rl.IP))
+	})
+	config.Watch(w, func(c *config.Config) []string { return c.HTTP.CORSOrigins }, router.SetCORSOrigins)
+	config.Watch(w, func(c *config.Config) config.Features { return c.Features }, func(f config.Features) {
+		l.Info("app - watchConfig - feature flags", "flags", f.Flags)
+	})
+
+	report := func(r config.Reloaded, err error) {
+		if err != nil {
+			l.Error("app - watchConfig - reload", logger.Err(err))
+			return
+		}
+		if len(r.Applied) > 0 {
+			l.Info("app - watchConfig - configuration reloaded", "applied", r.Applied)
+		}
+		if len(r.Restart) > 0 {
+			l.Warn("app - watchConfig - restart to apply", "settings", r.Restart)
+		}
+	}
+
+	return lifecycle.Worker("config_watch", func(ctx context.Context) {
+		if err := w.WatchFiles(ctx, report); err != nil {
+			l.Warn("app - watchConfig - not watching configuration files", logger.Err(err))
+		}
+
+		hup := make(chan os.Signal, 1)
+		signal.Notify(hup, syscall.SIGHUP)
+		defer signal.Stop(hup)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-hup:
+				l.Info("app - watchConfig - SIGHUP, reloading configuration")
+				report(w.Reload())
+			}
+		}
+	})
+}
@@ -0,0 +1,35 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	"github.com/DeSouzaRafael/go-clean-architecture-template/config"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/infra/tracing"
+)
+
+// tracingShutdownTimeout bounds the export of the spans still buffered on
+// shutdown, so that an unreachable collector does not hold the process.
+const tracingShutdownTimeout = 5 * time.Second
+
+// newTracing returns the tracer provider selected by cfg.Tracing, and a
+// function that flushes and stops it.
+func newTracing(cfg *config.Config) (*tracing.Provider, func(context.Context) error, error) {
+	tp, err := tracing.New(context.Background(), tracing.Options{
+		ServiceName:    cfg.App.Name,
+		ServiceVersion: cfg.App.Version,
+		Exporter:       cfg.Tracing.Exporter,
+		Endpoint:       cfg.Tracing.OTLPEndpoint,
+		Insecure:       cfg.Tracing.OTLPInsecure,
+		SampleRatio:    cfg.Tracing.SampleRatio,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return tp, func(ctx context.Context) error {
+		ctx, cancel := context.WithTimeout(ctx, tracingShutdownTimeout)
+		defer cancel()
+		return tp.Shutdown(ctx)
+	}, nil
+}
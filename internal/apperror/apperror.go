@@ -0,0 +1,149 @@
+package apperror
+
+import (
+	"errors"
+)
+
+// Kind classifies a domain error independently of any transport.
+type Kind int
+
+const (
+	KindInternal Kind = iota
+	KindNotFound
+	KindConflict
+	KindValidation
+	KindUnauthorized
+	KindPreconditionFailed
+	KindUnprocessable
+	KindForbidden
+	KindRateLimited
+)
+
+func (k Kind) String() string {
+	switch k {
+	case KindNotFound:
+		return "not_found"
+	case KindConflict:
+		return "conflict"
+	case KindValidation:
+		return "validation_failed"
+	case KindUnauthorized:
+		return "unauthorized"
+	case KindPreconditionFailed:
+		return "precondition_failed"
+	case KindUnprocessable:
+		return "unprocessable_entity"
+	case KindForbidden:
+		return "forbidden"
+	case KindRateLimited:
+		return "rate_limited"
+	default:
+		return "internal_error"
+	}
+}
+
+// Error is the domain error returned by repositories and use cases.
+// Message is safe to expose to clients, Err keeps the underlying cause.
+type Error struct {
+	Kind    Kind
+	Code    string
+	Message string
+	Err     error
+}
+
+var (
+	ErrNotFound           = &Error{Kind: KindNotFound, Message: "resource not found"}
+	ErrConflict           = &Error{Kind: KindConflict, Message: "resource already exists"}
+	ErrValidation         = &Error{Kind: KindValidation, Message: "invalid request data"}
+	ErrUnauthorized       = &Error{Kind: KindUnauthorized, Message: "unauthorized"}
+	ErrPreconditionFailed = &Error{Kind: KindPreconditionFailed, Message: "precondition failed"}
+	ErrUnprocessable      = &Error{Kind: KindUnprocessable, Message: "unprocessable entity"}
+	ErrForbidden          = &Error{Kind: KindForbidden, Message: "forbidden"}
+	ErrRateLimited        = &Error{Kind: KindRateLimited, Message: "too many requests"}
+)
+
+func (e *Error) Error() string {
+	if e.Err != nil {
+		return e.Message + ": " + e.Err.Error()
+	}
+	return e.Message
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether target is a domain error of the same kind, so that
+// errors.Is(err, apperror.ErrNotFound) works for any not found error.
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	if !ok {
+		return false
+	}
+	return e.Kind == t.Kind
+}
+
+// ErrorCode returns the machine-readable code, defaulting to the kind name.
+func (e *Error) ErrorCode() string {
+	if e.Code != "" {
+		return e.Code
+	}
+	return e.Kind.String()
+}
+
+func New(kind Kind, code, message string) *Error {
+	return &Error{Kind: kind, Code: code, Message: message}
+}
+
+func Wrap(err error, kind Kind, code, message string) *Error {
+	return &Error{Kind: kind, Code: code, Message: message, Err: err}
+}
+
+func NotFound(message string, err error) *Error {
+	return Wrap(err, KindNotFound, "", message)
+}
+
+func Conflict(message string, err error) *Error {
+	return Wrap(err, KindConflict, "", message)
+}
+
+func Validation(message string, err error) *Error {
+	return Wrap(err, KindValidation, "", message)
+}
+
+func Unauthorized(message string, err error) *Error {
+	return Wrap(err, KindUnauthorized, "", message)
+}
+
+func Forbidden(message string, err error) *Error {
+	return Wrap(err, KindForbidden, "", message)
+}
+
+func RateLimited(message string, err error) *Error {
+	return Wrap(err, KindRateLimited, "", message)
+}
+
+func PreconditionFailed(message string, err error) *Error {
+	return Wrap(err, KindPreconditionFailed, "", message)
+}
+
+func Unprocessable(message string, err error) *Error {
+	return Wrap(err, KindUnprocessable, "", message)
+}
+
+// As returns the outermost domain error in the chain, if any.
+func As(err error) (*Error, bool) {
+	var e *Error
+	if errors.As(err, &e) {
+		return e, true
+	}
+	return nil, false
+}
+
+// KindOf returns the kind of the outermost domain error, or KindInternal.
+func KindOf(err error) Kind {
+	if e, ok := As(err); ok {
+		return e.Kind
+	}
+	return KindInternal
+}
@@ -0,0 +1,58 @@
+package apperror
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestError_Is(t *testing.T) {
+	err := fmt.Errorf("GetUserById: %w", NotFound("user not found", errors.New("record not found")))
+
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.NotErrorIs(t, err, ErrConflict)
+}
+
+func TestError_Error(t *testing.T) {
+	assert.Equal(t, "user not found", NotFound("user not found", nil).Error())
+	assert.Equal(t, "user not found: record not found", NotFound("user not found", errors.New("record not found")).Error())
+}
+
+func TestError_Unwrap(t *testing.T) {
+	cause := errors.New("cause")
+	err := Conflict("conflict", cause)
+
+	assert.ErrorIs(t, err, cause)
+}
+
+func TestError_ErrorCode(t *testing.T) {
+	assert.Equal(t, "not_found", NotFound("user not found", nil).ErrorCode())
+	assert.Equal(t, "user_not_found", Wrap(nil, KindNotFound, "user_not_found", "user not found").ErrorCode())
+}
+
+func TestKindOf(t *testing.T) {
+	assert.Equal(t, KindValidation, KindOf(fmt.Errorf("wrap: %w", Validation("bad", nil))))
+	assert.Equal(t, KindUnauthorized, KindOf(Unauthorized("no token", nil)))
+	assert.Equal(t, KindForbidden, KindOf(Forbidden("not allowed", nil)))
+	assert.Equal(t, KindRateLimited, KindOf(RateLimited("slow down", nil)))
+	assert.Equal(t, KindPreconditionFailed, KindOf(PreconditionFailed("stale", nil)))
+	assert.Equal(t, KindInternal, KindOf(errors.New("boom")))
+}
+
+func TestAs_Outermost(t *testing.T) {
+	inner := NotFound("record not found", nil)
+	outer := Wrap(inner, KindNotFound, "user_not_found", "user not found")
+
+	e, ok := As(fmt.Errorf("wrap: %w", outer))
+	assert.True(t, ok)
+	assert.Equal(t, "user_not_found", e.ErrorCode())
+}
+
+func TestKind_String(t *testing.T) {
+	assert.Equal(t, "internal_error", KindInternal.String())
+	assert.Equal(t, "conflict", KindConflict.String())
+	assert.Equal(t, "forbidden", KindForbidden.String())
+	assert.Equal(t, "rate_limited", KindRateLimited.String())
+}
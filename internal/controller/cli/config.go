@@ -0,0 +1,61 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/DeSouzaRafael/go-clean-architecture-template/config"
+	"github.com/spf13/cobra"
+)
+
+type configValueOutput struct {
+	Key    string `json:"key"`
+	Value  string `json:"value"`
+	Source string `json:"source"`
+}
+
+func newConfigCommand(opts *options) *cobra.Command {
+	return &cobra.Command{
+		Use:   "config",
+		Short: "Validate and print the effective configuration, with secrets redacted",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := opts.provider.Config()
+			if err != nil {
+				return err
+			}
+			return opts.printConfig(cmd, cfg.Values())
+		},
+	}
+}
+
+func (o *options) printConfig(cmd *cobra.Command, values []config.Value) error {
+	if o.output == outputJSON {
+		out := make([]configValueOutput, 0, len(values))
+		for _, v := range values {
+			out = append(out, configValueOutput{Key: v.Key, Value: v.Value, Source: v.Source})
+		}
+		return writeJSON(cmd.OutOrStdout(), out)
+	}
+
+	w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "KEY\tVALUE\tSOURCE")
+	for _, v := range values {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", v.Key, v.Value, v.Source)
+	}
+	return w.Flush()
+}
+
+// parseSettings parses the KEY=VALUE arguments of --set.
+func parseSettings(args []string) (map[string]string, error) {
+	settings := make(map[string]string, len(args))
+	for _, arg := range args {
+		key, value, ok := strings.Cut(arg, "=")
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid --set %q, use KEY=VALUE", arg)
+		}
+		settings[strings.ToUpper(key)] = value
+	}
+	return settings, nil
+}
@@ -0,0 +1,45 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/DeSouzaRafael/go-clean-architecture-template/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func loadConfig(t *testing.T) *config.Config {
+	t.Helper()
+	cfg, err := config.Load(config.Sources{Flags: map[string]string{
+		"PG_URL":            "postgres://app:secret@db/app",
+		"AUTH_HS256_SECRET": "secret",
+	}})
+	require.NoError(t, err)
+	return cfg
+}
+
+func TestConfig_Text(t *testing.T) {
+	p := &fakeProvider{cfg: loadConfig(t)}
+	code, stdout, _ := run(p, "config")
+
+	assert.Equal(t, 0, code)
+	lines := strings.Split(stdout, "\n")
+	assert.Equal(t, []string{"KEY", "VALUE", "SOURCE"}, strings.Fields(lines[0]))
+	assert.Contains(t, stdout, "postgres://app:xxxxx@db/app")
+	assert.Contains(t, stdout, "[redacted]")
+	assert.NotContains(t, stdout, "secret")
+}
+
+func TestConfig_JSON(t *testing.T) {
+	p := &fakeProvider{cfg: loadConfig(t)}
+	code, stdout, _ := run(p, "-o", "json", "config")
+
+	assert.Equal(t, 0, code)
+	assert.Contains(t, stdout, `"key": "PG_URL",
+    "value": "postgres://app:xxxxx@db/app",
+    "source": "flag"`)
+	assert.Contains(t, stdout, `"key": "HTTP_PORT",
+    "value": "8080",
+    "source": "default"`)
+}
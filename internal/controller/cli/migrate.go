@@ -0,0 +1,131 @@
+package cli
+
+import (
+	"fmt"
+	"text/tabwriter"
+	"time"
+
+	"github.com/DeSouzaRafael/go-clean-architecture-template/infra/postgres/migrate"
+	"github.com/spf13/cobra"
+)
+
+type migrationOutput struct {
+	Version   int64      `json:"version"`
+	Name      string     `json:"name"`
+	State     string     `json:"state,omitempty"`
+	AppliedAt *time.Time `json:"applied_at,omitempty"`
+}
+
+func newMigrateCommand(opts *options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "Manage the database schema",
+	}
+
+	var steps int
+	down := &cobra.Command{
+		Use:   "down",
+		Short: "Revert the last applied migrations",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if steps <= 0 {
+				return fmt.Errorf("steps must be positive, got %d", steps)
+			}
+			return opts.withMigrator(func(m Migrator) error {
+				reverted, err := m.Down(cmd.Context(), steps)
+				if printErr := opts.printMigrations(cmd, "reverted", reverted); printErr != nil && err == nil {
+					err = printErr
+				}
+				return err
+			})
+		},
+	}
+	down.Flags().IntVar(&steps, "steps", 1, "number of migrations to revert")
+
+	cmd.AddCommand(
+		&cobra.Command{
+			Use:   "up",
+			Short: "Apply all pending migrations",
+			Args:  cobra.NoArgs,
+			RunE: func(cmd *cobra.Command, args []string) error {
+				return opts.withMigrator(func(m Migrator) error {
+					applied, err := m.Up(cmd.Context())
+					if printErr := opts.printMigrations(cmd, "applied", applied); printErr != nil && err == nil {
+						err = printErr
+					}
+					return err
+				})
+			},
+		},
+		down,
+		&cobra.Command{
+			Use:   "status",
+			Short: "List migrations and whether they are applied",
+			Args:  cobra.NoArgs,
+			RunE: func(cmd *cobra.Command, args []string) error {
+				return opts.withMigrator(func(m Migrator) error {
+					statuses, err := m.Status(cmd.Context())
+					if err != nil {
+						return err
+					}
+					return opts.printStatus(cmd, statuses)
+				})
+			},
+		},
+	)
+
+	return cmd
+}
+
+func (o *options) withMigrator(fn func(Migrator) error) error {
+	m, closeFn, err := o.provider.Migrator()
+	if err != nil {
+		return err
+	}
+	defer closeFn()
+	return fn(m)
+}
+
+// printMigrations reports the migrations a run went through, which may be
+// a partial list when the run failed.
+func (o *options) printMigrations(cmd *cobra.Command, action string, ms []migrate.Migration) error {
+	if o.output == outputJSON {
+		out := make([]migrationOutput, 0, len(ms))
+		for _, m := range ms {
+			out = append(out, migrationOutput{Version: m.Version, Name: m.Name})
+		}
+		return writeJSON(cmd.OutOrStdout(), map[string][]migrationOutput{action: out})
+	}
+
+	if len(ms) == 0 {
+		_, err := fmt.Fprintf(cmd.OutOrStdout(), "no migrations %s\n", action)
+		return err
+	}
+	for _, m := range ms {
+		if _, err := fmt.Fprintf(cmd.OutOrStdout(), "%s %d_%s\n", action, m.Version, m.Name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (o *options) printStatus(cmd *cobra.Command, statuses []migrate.Status) error {
+	if o.output == outputJSON {
+		out := make([]migrationOutput, 0, len(statuses))
+		for _, s := range statuses {
+			out = append(out, migrationOutput{Version: s.Version, Name: s.Name, State: string(s.State), AppliedAt: s.AppliedAt})
+		}
+		return writeJSON(cmd.OutOrStdout(), out)
+	}
+
+	w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tNAME\tSTATE\tAPPLIED AT")
+	for _, s := range statuses {
+		appliedAt := "-"
+		if s.AppliedAt != nil {
+			appliedAt = s.AppliedAt.Format(time.RFC3339)
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\n", s.Version, s.Name, s.State, appliedAt)
+	}
+	return w.Flush()
+}
@@ -0,0 +1,88 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DeSouzaRafael/go-clean-architecture-template/infra/postgres/migrate"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeMigrator struct {
+	applied  []migrate.Migration
+	statuses []migrate.Status
+	steps    int
+	err      error
+}
+
+func (m *fakeMigrator) Up(context.Context) ([]migrate.Migration, error) {
+	return m.applied, m.err
+}
+
+func (m *fakeMigrator) Down(_ context.Context, steps int) ([]migrate.Migration, error) {
+	m.steps = steps
+	return m.applied, m.err
+}
+
+func (m *fakeMigrator) Status(context.Context) ([]migrate.Status, error) {
+	return m.statuses, m.err
+}
+
+func TestMigrateUp(t *testing.T) {
+	m := &fakeMigrator{applied: []migrate.Migration{{Version: 1, Name: "create_user"}}}
+	p := &fakeProvider{migrator: m}
+
+	code, stdout, _ := run(p, "migrate", "up")
+	assert.Equal(t, 0, code)
+	assert.Equal(t, "applied 1_create_user\n", stdout)
+	assert.Equal(t, 1, p.closed)
+
+	code, stdout, _ = run(p, "migrate", "up", "-o", "json")
+	assert.Equal(t, 0, code)
+	assert.JSONEq(t, `{"applied":[{"version":1,"name":"create_user"}]}`, stdout)
+}
+
+func TestMigrateUp_PartialFailure(t *testing.T) {
+	m := &fakeMigrator{applied: []migrate.Migration{{Version: 1, Name: "create_user"}}, err: errors.New("syntax error")}
+
+	code, stdout, stderr := run(&fakeProvider{migrator: m}, "migrate", "up")
+	assert.Equal(t, 1, code)
+	assert.Equal(t, "applied 1_create_user\n", stdout)
+	assert.Equal(t, "Error: syntax error\n", stderr)
+}
+
+func TestMigrateDown(t *testing.T) {
+	m := &fakeMigrator{}
+
+	code, stdout, _ := run(&fakeProvider{migrator: m}, "migrate", "down", "--steps", "2")
+	assert.Equal(t, 0, code)
+	assert.Equal(t, 2, m.steps)
+	assert.Equal(t, "no migrations reverted\n", stdout)
+
+	code, _, stderr := run(&fakeProvider{migrator: m}, "migrate", "down", "--steps", "0")
+	assert.Equal(t, 1, code)
+	assert.Contains(t, stderr, "steps must be positive")
+}
+
+func TestMigrateStatus(t *testing.T) {
+	appliedAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	m := &fakeMigrator{statuses: []migrate.Status{
+		{Version: 1, Name: "create_user", State: migrate.StateApplied, AppliedAt: &appliedAt},
+		{Version: 2, Name: "add_user_version", State: migrate.StatePending},
+	}}
+
+	code, stdout, _ := run(&fakeProvider{migrator: m}, "migrate", "status", "--output", "json")
+	assert.Equal(t, 0, code)
+	assert.JSONEq(t, `[
+		{"version":1,"name":"create_user","state":"applied","applied_at":"2026-01-02T03:04:05Z"},
+		{"version":2,"name":"add_user_version","state":"pending"}
+	]`, stdout)
+
+	code, stdout, _ = run(&fakeProvider{migrator: m}, "migrate", "status")
+	assert.Equal(t, 0, code)
+	assert.Equal(t, "VERSION  NAME              STATE    APPLIED AT\n"+
+		"1        create_user       applied  2026-01-02T03:04:05Z\n"+
+		"2        add_user_version  pending  -\n", stdout)
+}
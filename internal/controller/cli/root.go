@@ -0,0 +1,160 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os/user"
+
+	"github.com/DeSouzaRafael/go-clean-architecture-template/config"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/infra/postgres/migrate"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/infra/validator"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/apperror"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/entity"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/reqctx"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/usecase"
+	"github.com/spf13/cobra"
+)
+
+const (
+	outputText = "text"
+	outputJSON = "json"
+)
+
+type (
+	// Provider builds the dependencies of a command when it runs, so each
+	// command only connects to what it uses. The returned func releases them.
+	Provider interface {
+		// SetSources selects where the configuration is loaded from. It
+		// is called before any other method.
+		SetSources(config.Sources)
+		Config() (*config.Config, error)
+		Serve() error
+		Migrator() (Migrator, func(), error)
+		UserUseCase() (usecase.User, func(), error)
+	}
+
+	Migrator interface {
+		Up(context.Context) ([]migrate.Migration, error)
+		Down(ctx context.Context, steps int) ([]migrate.Migration, error)
+		Status(context.Context) ([]migrate.Status, error)
+	}
+)
+
+type options struct {
+	provider  Provider
+	validator *validator.Validator
+	output    string
+	sources   config.Sources
+	settings  []string
+}
+
+// NewRootCommand returns the command line of the binary. Without a
+// subcommand it starts the HTTP server, like serve.
+func NewRootCommand(p Provider) *cobra.Command {
+	opts := &options{provider: p, validator: validator.NewValidator()}
+
+	root := &cobra.Command{
+		Use:           "app",
+		Short:         "Run the API server and administrative tasks",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			if opts.output != outputText && opts.output != outputJSON {
+				return fmt.Errorf("invalid output %q, use %s or %s", opts.output, outputText, outputJSON)
+			}
+			flags, err := parseSettings(opts.settings)
+			if err != nil {
+				return err
+			}
+			opts.sources.Flags = flags
+			p.SetSources(opts.sources)
+			cmd.SetContext(reqctx.WithPrincipal(cmd.Context(), operator()))
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return p.Serve()
+		},
+	}
+	root.PersistentFlags().StringVarP(&opts.output, "output", "o", outputText, "output format: text or json")
+	root.PersistentFlags().StringVar(&opts.sources.File, "config", "", "YAML or TOML configuration file (default $CONFIG_FILE)")
+	root.PersistentFlags().StringVar(&opts.sources.EnvFile, "env-file", ".env", "dotenv file, skipped when missing")
+	root.PersistentFlags().StringArrayVar(&opts.settings, "set", nil, "override a setting, as KEY=VALUE (repeatable)")
+
+	root.AddCommand(
+		newServeCommand(opts),
+		newConfigCommand(opts),
+		newMigrateCommand(opts),
+		newSeedCommand(opts),
+		newUserCommand(opts),
+	)
+
+	return root
+}
+
+// Execute runs the command line with args and reports a failure on the
+// error stream in the selected output format. It returns the exit code.
+func Execute(ctx context.Context, root *cobra.Command, args []string) int {
+	root.SetArgs(args)
+	if err := root.ExecuteContext(ctx); err != nil {
+		format := outputText
+		if f := root.PersistentFlags().Lookup("output"); f != nil && f.Value.String() == outputJSON {
+			format = outputJSON
+		}
+		printError(root.ErrOrStderr(), format, err)
+		return 1
+	}
+	return 0
+}
+
+func newServeCommand(opts *options) *cobra.Command {
+	return &cobra.Command{
+		Use:   "serve",
+		Short: "Start the HTTP server",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return opts.provider.Serve()
+		},
+	}
+}
+
+// operator is the principal of the operating system user running a
+// command. Whoever can run the binary against the database administers it,
+// so it gets the admin role; its subject tells CLI changes apart from API
+// ones in audit records.
+func operator() entity.Principal {
+	subject := "cli"
+	if u, err := user.Current(); err == nil && u.Username != "" {
+		subject = "cli:" + u.Username
+	}
+	return entity.Principal{Subject: subject, Roles: []string{entity.RoleAdmin}}
+}
+
+type errorOutput struct {
+	Error struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+func printError(w io.Writer, format string, err error) {
+	if format != outputJSON {
+		fmt.Fprintf(w, "Error: %s\n", err)
+		return
+	}
+
+	var out errorOutput
+	out.Error.Code = apperror.KindInternal.String()
+	if appErr, ok := apperror.As(err); ok {
+		out.Error.Code = appErr.ErrorCode()
+	}
+	out.Error.Message = err.Error()
+	_ = writeJSON(w, out)
+}
+
+func writeJSON(w io.Writer, v any) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
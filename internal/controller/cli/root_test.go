@@ -0,0 +1,108 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DeSouzaRafael/go-clean-architecture-template/config"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/apperror"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/usecase"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeProvider struct {
+	served   bool
+	sources  config.Sources
+	cfg      *config.Config
+	migrator Migrator
+	user     usecase.User
+	closed   int
+}
+
+func (p *fakeProvider) SetSources(src config.Sources) {
+	p.sources = src
+}
+
+func (p *fakeProvider) Config() (*config.Config, error) {
+	return p.cfg, nil
+}
+
+func (p *fakeProvider) Serve() error {
+	p.served = true
+	return nil
+}
+
+func (p *fakeProvider) Migrator() (Migrator, func(), error) {
+	return p.migrator, func() { p.closed++ }, nil
+}
+
+func (p *fakeProvider) UserUseCase() (usecase.User, func(), error) {
+	return p.user, func() { p.closed++ }, nil
+}
+
+// run executes the command line and returns the exit code, stdout and stderr.
+func run(p Provider, args ...string) (int, string, string) {
+	root := NewRootCommand(p)
+	var stdout, stderr bytes.Buffer
+	root.SetOut(&stdout)
+	root.SetErr(&stderr)
+
+	code := Execute(context.Background(), root, args)
+	return code, stdout.String(), stderr.String()
+}
+
+func TestRoot_ServesByDefault(t *testing.T) {
+	for _, args := range [][]string{nil, {"serve"}} {
+		p := &fakeProvider{}
+		code, _, _ := run(p, args...)
+
+		assert.Equal(t, 0, code)
+		assert.True(t, p.served)
+	}
+}
+
+func TestRoot_InvalidOutput(t *testing.T) {
+	p := &fakeProvider{}
+	code, _, stderr := run(p, "--output", "yaml", "serve")
+
+	assert.Equal(t, 1, code)
+	assert.False(t, p.served)
+	assert.Equal(t, "Error: invalid output \"yaml\", use text or json\n", stderr)
+}
+
+func TestRoot_Sources(t *testing.T) {
+	p := &fakeProvider{}
+	code, _, _ := run(p, "--config", "app.yaml", "--set", "http_port=8081", "--set", "PG_URL=postgres://a=b", "serve")
+
+	assert.Equal(t, 0, code)
+	assert.Equal(t, config.Sources{
+		File:    "app.yaml",
+		EnvFile: ".env",
+		Flags:   map[string]string{"HTTP_PORT": "8081", "PG_URL": "postgres://a=b"},
+	}, p.sources)
+}
+
+func TestRoot_InvalidSet(t *testing.T) {
+	p := &fakeProvider{}
+	code, _, stderr := run(p, "--set", "HTTP_PORT", "serve")
+
+	assert.Equal(t, 1, code)
+	assert.False(t, p.served)
+	assert.Equal(t, "Error: invalid --set \"HTTP_PORT\", use KEY=VALUE\n", stderr)
+}
+
+func TestPrintError(t *testing.T) {
+	var buf bytes.Buffer
+	printError(&buf, outputJSON, apperror.NotFound("user not found", nil))
+	assert.JSONEq(t, `{"error":{"code":"not_found","message":"user not found"}}`, buf.String())
+
+	buf.Reset()
+	printError(&buf, outputJSON, errors.New("boom"))
+	assert.JSONEq(t, `{"error":{"code":"internal_error","message":"boom"}}`, buf.String())
+
+	buf.Reset()
+	printError(&buf, outputText, errors.New("boom"))
+	assert.Equal(t, "Error: boom\n", buf.String())
+}
@@ -0,0 +1,95 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/apperror"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/entity"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/usecase"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// seedFile is the fixture format accepted by seed, in YAML or JSON:
+//
+//	users:
+//	  - name: Ana
+//	    phone: "+5511999999999"
+type seedFile struct {
+	Users []userInput `json:"users" yaml:"users"`
+}
+
+func newSeedCommand(opts *options) *cobra.Command {
+	return &cobra.Command{
+		Use:   "seed <file>",
+		Short: "Create the users listed in a YAML or JSON fixture file",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			seed, err := readSeedFile(args[0])
+			if err != nil {
+				return err
+			}
+			for i, u := range seed.Users {
+				if err := opts.validator.Validate(u); err != nil {
+					return apperror.Validation(fmt.Sprintf("invalid user at index %d: %s", i, err), err)
+				}
+			}
+
+			return opts.withUserUseCase(func(uc usecase.User) error {
+				created := make([]entity.UserEntity, 0, len(seed.Users))
+				var err error
+				for _, u := range seed.Users {
+					var user entity.UserEntity
+					user, err = uc.CreateUser(cmd.Context(), entity.UserEntity{Name: u.Name, Phone: u.Phone})
+					if err != nil {
+						err = fmt.Errorf("seed user %q: %w", u.Name, err)
+						break
+					}
+					created = append(created, user)
+				}
+
+				if opts.output == outputJSON {
+					if printErr := writeJSON(cmd.OutOrStdout(), map[string][]userOutput{"created": toUserOutputs(created)}); printErr != nil && err == nil {
+						err = printErr
+					}
+					return err
+				}
+				if printErr := writeUserTable(cmd.OutOrStdout(), toUserOutputs(created)); printErr != nil && err == nil {
+					err = printErr
+				}
+				return err
+			})
+		},
+	}
+}
+
+func readSeedFile(path string) (seedFile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return seedFile{}, fmt.Errorf("read seed file: %w", err)
+	}
+
+	var seed seedFile
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".json":
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.DisallowUnknownFields()
+		err = dec.Decode(&seed)
+	case ".yaml", ".yml":
+		dec := yaml.NewDecoder(bytes.NewReader(data))
+		dec.KnownFields(true)
+		err = dec.Decode(&seed)
+	default:
+		return seedFile{}, apperror.Validation(fmt.Sprintf("unsupported seed file extension %q, use .yaml, .yml or .json", ext), nil)
+	}
+	if err != nil {
+		return seedFile{}, apperror.Validation("invalid seed file "+path, err)
+	}
+
+	return seed, nil
+}
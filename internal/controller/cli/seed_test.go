@@ -0,0 +1,76 @@
+package cli
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/entity"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func writeSeedFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestSeed(t *testing.T) {
+	files := map[string]string{
+		"users.yaml": "users:\n  - name: Ana\n    phone: \"+5511999999999\"\n  - name: Bia\n    phone: \"+5511888888888\"\n",
+		"users.json": `{"users":[{"name":"Ana","phone":"+5511999999999"},{"name":"Bia","phone":"+5511888888888"}]}`,
+	}
+
+	for name, content := range files {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockUser := mocks.NewMockUser(ctrl)
+			gomock.InOrder(
+				mockUser.EXPECT().CreateUser(gomock.Any(), entity.UserEntity{Name: "Ana", Phone: "+5511999999999"}).Return(testUser, nil),
+				mockUser.EXPECT().CreateUser(gomock.Any(), entity.UserEntity{Name: "Bia", Phone: "+5511888888888"}).Return(testUser, nil),
+			)
+
+			code, stdout, _ := run(&fakeProvider{user: mockUser}, "seed", writeSeedFile(t, name, content), "-o", "json")
+			assert.Equal(t, 0, code)
+			assert.JSONEq(t, `{"created":[`+testJSON+`,`+testJSON+`]}`, stdout)
+		})
+	}
+}
+
+func TestSeed_StopsOnError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockUser := mocks.NewMockUser(ctrl)
+	gomock.InOrder(
+		mockUser.EXPECT().CreateUser(gomock.Any(), gomock.Any()).Return(testUser, nil),
+		mockUser.EXPECT().CreateUser(gomock.Any(), gomock.Any()).Return(entity.UserEntity{}, errors.New("db down")),
+	)
+	path := writeSeedFile(t, "users.yml", "users:\n  - {name: Ana, phone: '1'}\n  - {name: Bia, phone: '2'}\n  - {name: Cid, phone: '3'}\n")
+
+	code, stdout, stderr := run(&fakeProvider{user: mockUser}, "seed", path, "-o", "json")
+	assert.Equal(t, 1, code)
+	assert.JSONEq(t, `{"created":[`+testJSON+`]}`, stdout)
+	assert.Contains(t, stderr, `seed user \"Bia\": db down`)
+}
+
+func TestSeed_InvalidFile(t *testing.T) {
+	for name, content := range map[string]string{
+		"users.txt":  "",
+		"users.json": `{"users":[{"name":"Ana","email":"x"}]}`,
+		"users.yaml": "users:\n  - name: Ana\n",
+	} {
+		p := &fakeProvider{}
+		code, _, stderr := run(p, "seed", writeSeedFile(t, name, content))
+		assert.Equal(t, 1, code, name)
+		assert.NotEmpty(t, stderr, name)
+		assert.Equal(t, 0, p.closed, name)
+	}
+
+	code, _, stderr := run(&fakeProvider{}, "seed", filepath.Join(t.TempDir(), "missing.yaml"))
+	assert.Equal(t, 1, code)
+	assert.Contains(t, stderr, "read seed file")
+}
@@ -0,0 +1,264 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/apperror"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/entity"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/query"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/usecase"
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+)
+
+type userInput struct {
+	Name  string `json:"name" yaml:"name" validate:"required"`
+	Phone string `json:"phone" yaml:"phone" validate:"required"`
+}
+
+type userOutput struct {
+	ID        uuid.UUID `json:"id"`
+	Name      string    `json:"name"`
+	Phone     string    `json:"phone"`
+	Version   int64     `json:"version"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type userListOutput struct {
+	Data       []userOutput `json:"data"`
+	Total      *int64       `json:"total,omitempty"`
+	NextCursor string       `json:"next_cursor,omitempty"`
+	HasMore    bool         `json:"has_more"`
+}
+
+func toUserOutput(user entity.UserEntity) userOutput {
+	return userOutput{
+		ID:        user.ID,
+		Name:      user.Name,
+		Phone:     user.Phone,
+		Version:   user.Version,
+		CreatedAt: user.CreatedAt,
+		UpdatedAt: user.UpdatedAt,
+	}
+}
+
+func toUserOutputs(users []entity.UserEntity) []userOutput {
+	out := make([]userOutput, 0, len(users))
+	for _, user := range users {
+		out = append(out, toUserOutput(user))
+	}
+	return out
+}
+
+func newUserCommand(opts *options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "user",
+		Short: "Manage users",
+	}
+
+	cmd.AddCommand(
+		newUserGetCommand(opts),
+		newUserCreateCommand(opts),
+		newUserDeleteCommand(opts),
+		newUserListCommand(opts),
+	)
+
+	return cmd
+}
+
+func newUserGetCommand(opts *options) *cobra.Command {
+	return &cobra.Command{
+		Use:   "get <id>",
+		Short: "Show a user",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := parseUserID(args[0])
+			if err != nil {
+				return err
+			}
+
+			return opts.withUserUseCase(func(uc usecase.User) error {
+				user, err := uc.GetUserById(cmd.Context(), entity.UserEntity{ID: id})
+				if err != nil {
+					return err
+				}
+				return opts.printUser(cmd.OutOrStdout(), user)
+			})
+		},
+	}
+}
+
+func newUserCreateCommand(opts *options) *cobra.Command {
+	var input userInput
+	cmd := &cobra.Command{
+		Use:   "create",
+		Short: "Create a user",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := opts.validator.Validate(input); err != nil {
+				return apperror.Validation("invalid user: "+err.Error(), err)
+			}
+
+			return opts.withUserUseCase(func(uc usecase.User) error {
+				user, err := uc.CreateUser(cmd.Context(), entity.UserEntity{Name: input.Name, Phone: input.Phone})
+				if err != nil {
+					return err
+				}
+				return opts.printUser(cmd.OutOrStdout(), user)
+			})
+		},
+	}
+	cmd.Flags().StringVar(&input.Name, "name", "", "user name")
+	cmd.Flags().StringVar(&input.Phone, "phone", "", "user phone")
+
+	return cmd
+}
+
+func newUserDeleteCommand(opts *options) *cobra.Command {
+	var version int64
+	cmd := &cobra.Command{
+		Use:   "delete <id>",
+		Short: "Delete a user",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := parseUserID(args[0])
+			if err != nil {
+				return err
+			}
+
+			return opts.withUserUseCase(func(uc usecase.User) error {
+				if err := uc.DeleteUser(cmd.Context(), entity.UserEntity{ID: id, Version: version}); err != nil {
+					return err
+				}
+				if opts.output == outputJSON {
+					return writeJSON(cmd.OutOrStdout(), map[string]uuid.UUID{"deleted": id})
+				}
+				_, err := fmt.Fprintf(cmd.OutOrStdout(), "deleted %s\n", id)
+				return err
+			})
+		},
+	}
+	cmd.Flags().Int64Var(&version, "version", 0, "only delete if the user is still at this version")
+
+	return cmd
+}
+
+func newUserListCommand(opts *options) *cobra.Command {
+	var (
+		req            usecase.PageRequest
+		cursor         string
+		filter, sortBy string
+	)
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List users",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if req.Limit < 1 || req.Limit > usecase.MaxPageLimit {
+				return apperror.Validation(fmt.Sprintf("limit must be between 1 and %d", usecase.MaxPageLimit), nil)
+			}
+			if req.Offset < 0 {
+				return apperror.Validation("offset must not be negative", nil)
+			}
+			if cursor != "" {
+				if req.Offset > 0 {
+					return apperror.Validation("offset and cursor cannot be combined", nil)
+				}
+				c, err := usecase.DecodeCursor(cursor)
+				if err != nil {
+					return err
+				}
+				req.Cursor = c
+			}
+
+			conds, err := query.ParseFilter(filter)
+			if err != nil {
+				return err
+			}
+			sort, err := query.ParseSort(sortBy)
+			if err != nil {
+				return err
+			}
+
+			return opts.withUserUseCase(func(uc usecase.User) error {
+				page, err := uc.ListUsers(cmd.Context(), usecase.Criteria{Conditions: conds, Sort: sort}, req)
+				if err != nil {
+					return err
+				}
+				return opts.printUserPage(cmd.OutOrStdout(), page)
+			})
+		},
+	}
+	cmd.Flags().IntVar(&req.Limit, "limit", usecase.DefaultPageLimit, "maximum number of users")
+	cmd.Flags().IntVar(&req.Offset, "offset", 0, "number of users to skip")
+	cmd.Flags().StringVar(&cursor, "cursor", "", "next_cursor of the previous page")
+	cmd.Flags().StringVar(&filter, "filter", "", `filter expression, e.g. "name=like=ana*"`)
+	cmd.Flags().StringVar(&sortBy, "sort", "", `sort fields, e.g. "-created_at,name"`)
+	cmd.Flags().BoolVar(&req.WithTotal, "total", false, "count all matching users")
+
+	return cmd
+}
+
+func parseUserID(s string) (uuid.UUID, error) {
+	id, err := uuid.Parse(s)
+	if err != nil {
+		return uuid.Nil, apperror.Validation("invalid user id "+s, err)
+	}
+	return id, nil
+}
+
+func (o *options) withUserUseCase(fn func(usecase.User) error) error {
+	uc, closeFn, err := o.provider.UserUseCase()
+	if err != nil {
+		return err
+	}
+	defer closeFn()
+	return fn(uc)
+}
+
+func (o *options) printUser(w io.Writer, user entity.UserEntity) error {
+	out := toUserOutput(user)
+	if o.output == outputJSON {
+		return writeJSON(w, out)
+	}
+	return writeUserTable(w, []userOutput{out})
+}
+
+func (o *options) printUserPage(w io.Writer, page usecase.Page[entity.UserEntity]) error {
+	out := userListOutput{
+		Data:    toUserOutputs(page.Items),
+		Total:   page.Total,
+		HasMore: page.HasMore,
+	}
+	if page.NextCursor != nil {
+		out.NextCursor = page.NextCursor.Encode()
+	}
+
+	if o.output == outputJSON {
+		return writeJSON(w, out)
+	}
+
+	if err := writeUserTable(w, out.Data); err != nil {
+		return err
+	}
+	if out.Total != nil {
+		fmt.Fprintf(w, "\ntotal: %d\n", *out.Total)
+	}
+	if out.NextCursor != "" {
+		fmt.Fprintf(w, "\nnext cursor: %s\n", out.NextCursor)
+	}
+	return nil
+}
+
+func writeUserTable(w io.Writer, users []userOutput) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tNAME\tPHONE\tVERSION\tCREATED AT")
+	for _, u := range users {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%d\t%s\n", u.ID, u.Name, u.Phone, u.Version, u.CreatedAt.Format(time.RFC3339))
+	}
+	return tw.Flush()
+}
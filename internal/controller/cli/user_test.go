@@ -0,0 +1,135 @@
+package cli
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/apperror"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/entity"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/reqctx"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/usecase"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+var (
+	testUserID = uuid.MustParse("0b4f3c2e-8a55-4f7e-9a0d-6d2b7c1e5f10")
+	testTime   = time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	testUser   = entity.UserEntity{ID: testUserID, Name: "Ana", Phone: "+5511999999999", Version: 3, CreatedAt: testTime, UpdatedAt: testTime}
+	testJSON   = `{"id":"0b4f3c2e-8a55-4f7e-9a0d-6d2b7c1e5f10","name":"Ana","phone":"+5511999999999","version":3,` +
+		`"created_at":"2026-01-02T03:04:05Z","updated_at":"2026-01-02T03:04:05Z"}`
+)
+
+func TestUserGet(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockUser := mocks.NewMockUser(ctrl)
+	mockUser.EXPECT().GetUserById(gomock.Any(), entity.UserEntity{ID: testUserID}).Return(testUser, nil).Times(2)
+	p := &fakeProvider{user: mockUser}
+
+	code, stdout, _ := run(p, "user", "get", testUserID.String(), "-o", "json")
+	assert.Equal(t, 0, code)
+	assert.JSONEq(t, testJSON, stdout)
+
+	code, stdout, _ = run(p, "user", "get", testUserID.String())
+	assert.Equal(t, 0, code)
+	assert.Contains(t, stdout, "ID")
+	assert.Contains(t, stdout, testUserID.String()+"  Ana   +5511999999999  3")
+	assert.Equal(t, 2, p.closed)
+}
+
+func TestUserGet_Errors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockUser := mocks.NewMockUser(ctrl)
+	mockUser.EXPECT().GetUserById(gomock.Any(), gomock.Any()).Return(entity.UserEntity{}, apperror.NotFound("user not found", nil))
+	p := &fakeProvider{user: mockUser}
+
+	code, _, stderr := run(p, "user", "get", testUserID.String(), "-o", "json")
+	assert.Equal(t, 1, code)
+	assert.JSONEq(t, `{"error":{"code":"not_found","message":"user not found"}}`, stderr)
+
+	code, _, stderr = run(p, "user", "get", "not-a-uuid")
+	assert.Equal(t, 1, code)
+	assert.Contains(t, stderr, "invalid user id not-a-uuid")
+}
+
+func TestUserCreate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockUser := mocks.NewMockUser(ctrl)
+	fromCLI := gomock.Cond(func(ctx any) bool {
+		p, ok := reqctx.Principal(ctx.(context.Context))
+		return ok && p.HasRole(entity.RoleAdmin) && strings.HasPrefix(reqctx.Actor(ctx.(context.Context)), "cli")
+	})
+	mockUser.EXPECT().CreateUser(fromCLI, entity.UserEntity{Name: "Ana", Phone: "+5511999999999"}).Return(testUser, nil)
+
+	code, stdout, _ := run(&fakeProvider{user: mockUser}, "user", "create", "--name", "Ana", "--phone", "+5511999999999", "-o", "json")
+	assert.Equal(t, 0, code)
+	assert.JSONEq(t, testJSON, stdout)
+}
+
+func TestUserCreate_InvalidInput(t *testing.T) {
+	p := &fakeProvider{}
+
+	code, _, stderr := run(p, "user", "create", "--name", "Ana")
+	assert.Equal(t, 1, code)
+	assert.Contains(t, stderr, "Field validation for 'Phone' failed on the 'required' tag.")
+	assert.Equal(t, 0, p.closed)
+}
+
+func TestUserDelete(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockUser := mocks.NewMockUser(ctrl)
+	mockUser.EXPECT().DeleteUser(gomock.Any(), entity.UserEntity{ID: testUserID, Version: 3}).Return(nil)
+	mockUser.EXPECT().DeleteUser(gomock.Any(), entity.UserEntity{ID: testUserID}).Return(nil)
+	p := &fakeProvider{user: mockUser}
+
+	code, stdout, _ := run(p, "user", "delete", testUserID.String(), "--version", "3", "-o", "json")
+	assert.Equal(t, 0, code)
+	assert.JSONEq(t, `{"deleted":"0b4f3c2e-8a55-4f7e-9a0d-6d2b7c1e5f10"}`, stdout)
+
+	code, stdout, _ = run(p, "user", "delete", testUserID.String())
+	assert.Equal(t, 0, code)
+	assert.Equal(t, "deleted "+testUserID.String()+"\n", stdout)
+}
+
+func TestUserList(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockUser := mocks.NewMockUser(ctrl)
+	total := int64(7)
+	next := &usecase.Cursor{Sort: "name", Values: []any{"Ana"}, ID: testUserID}
+	mockUser.EXPECT().ListUsers(gomock.Any(),
+		usecase.Criteria{
+			Conditions: []usecase.Condition{{Field: "name", Op: usecase.OpLike, Value: "an*"}},
+			Sort:       []usecase.Order{{Field: "name", Desc: true}},
+		},
+		usecase.PageRequest{Limit: 1, WithTotal: true},
+	).Return(usecase.Page[entity.UserEntity]{Items: []entity.UserEntity{testUser}, Total: &total, NextCursor: next, HasMore: true}, nil)
+
+	code, stdout, _ := run(&fakeProvider{user: mockUser}, "user", "list", "--limit", "1", "--total",
+		"--filter", "name=like=an*", "--sort", "-name", "-o", "json")
+	assert.Equal(t, 0, code)
+	assert.JSONEq(t, `{"data":[`+testJSON+`],"total":7,"next_cursor":"`+next.Encode()+`","has_more":true}`, stdout)
+}
+
+func TestUserList_InvalidParams(t *testing.T) {
+	cursor := usecase.Cursor{Sort: "created_at", Values: []any{"x"}, ID: testUserID}.Encode()
+
+	for _, args := range [][]string{
+		{"--limit", "0"},
+		{"--limit", "101"},
+		{"--offset", "-1"},
+		{"--offset", "1", "--cursor", cursor},
+		{"--cursor", "%%%"},
+		{"--filter", "name~x"},
+		{"--sort", "--name"},
+	} {
+		p := &fakeProvider{}
+		code, _, stderr := run(p, append([]string{"user", "list", "-o", "json"}, args...)...)
+		assert.Equal(t, 1, code, args)
+		assert.NotContains(t, stderr, "internal_error", args)
+		assert.Equal(t, 0, p.closed, args)
+	}
+}
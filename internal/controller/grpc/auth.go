@@ -0,0 +1,75 @@
+package grpc
+
+import (
+	"context"
+	"strings"
+
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/apperror"
+	userv1 "github.com/DeSouzaRafael/go-clean-architecture-template/internal/controller/grpc/pb/user/v1"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/entity"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/reqctx"
+	gogrpc "google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+const metadataAuthorization = "authorization"
+
+// TokenVerifier checks a bearer token and returns the principal it was
+// issued to.
+type TokenVerifier interface {
+	Verify(token string) (entity.Principal, error)
+}
+
+// authUnary requires a valid "authorization: Bearer <token>" metadata entry
+// on user service calls. Health checks and reflection stay public.
+func authUnary(v TokenVerifier) gogrpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *gogrpc.UnaryServerInfo, handler gogrpc.UnaryHandler) (any, error) {
+		if !protected(info.FullMethod) {
+			return handler(ctx, req)
+		}
+		ctx, err := authenticate(ctx, v)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+func authStream(v TokenVerifier) gogrpc.StreamServerInterceptor {
+	return func(srv any, ss gogrpc.ServerStream, info *gogrpc.StreamServerInfo, handler gogrpc.StreamHandler) error {
+		if !protected(info.FullMethod) {
+			return handler(srv, ss)
+		}
+		ctx, err := authenticate(ss.Context(), v)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+func protected(fullMethod string) bool {
+	return strings.HasPrefix(fullMethod, "/"+userv1.UserService_ServiceDesc.ServiceName+"/")
+}
+
+func authenticate(ctx context.Context, v TokenVerifier) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get(metadataAuthorization)
+	if len(values) == 0 {
+		return nil, apperror.Unauthorized("missing bearer token", nil)
+	}
+	scheme, token, ok := strings.Cut(values[0], " ")
+	token = strings.TrimSpace(token)
+	if !ok || !strings.EqualFold(scheme, "Bearer") || token == "" {
+		return nil, apperror.Unauthorized("missing bearer token", nil)
+	}
+
+	principal, err := v.Verify(token)
+	if err != nil {
+		if apperror.KindOf(err) != apperror.KindUnauthorized {
+			return nil, apperror.Unauthorized("invalid token", err)
+		}
+		return nil, err
+	}
+	return reqctx.WithPrincipal(ctx, principal), nil
+}
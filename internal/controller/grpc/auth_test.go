@@ -0,0 +1,81 @@
+package grpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/apperror"
+	userv1 "github.com/DeSouzaRafael/go-clean-architecture-template/internal/controller/grpc/pb/user/v1"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/entity"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/reqctx"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+type fakeVerifier map[string]entity.Principal
+
+func (f fakeVerifier) Verify(token string) (entity.Principal, error) {
+	if p, ok := f[token]; ok {
+		return p, nil
+	}
+	return entity.Principal{}, apperror.Unauthorized("invalid token", nil)
+}
+
+func withToken(token string) context.Context {
+	return metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+token)
+}
+
+func TestAuth_Rejected(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	conn := dialWithAuth(t, mocks.NewMockUser(ctrl), fakeVerifier{})
+	client := userv1.NewUserServiceClient(conn)
+
+	tests := map[string]context.Context{
+		"missing": context.Background(),
+		"scheme":  metadata.AppendToOutgoingContext(context.Background(), "authorization", "Basic abc"),
+		"invalid": withToken("bad"),
+	}
+	for name, ctx := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := client.GetUser(ctx, &userv1.GetUserRequest{Id: uuid.NewString()})
+			assert.Equal(t, codes.Unauthenticated, status.Code(err))
+			assert.Equal(t, "unauthorized", errorReason(err))
+		})
+	}
+}
+
+func TestAuth_Valid(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	uc := mocks.NewMockUser(ctrl)
+	conn := dialWithAuth(t, uc, fakeVerifier{"good": {Subject: "alice"}})
+	client := userv1.NewUserServiceClient(conn)
+
+	id := uuid.New()
+	uc.EXPECT().GetUserById(gomock.Any(), entity.UserEntity{ID: id}).
+		DoAndReturn(func(ctx context.Context, u entity.UserEntity) (entity.UserEntity, error) {
+			p, ok := reqctx.Principal(ctx)
+			require.True(t, ok)
+			assert.Equal(t, "alice", p.Subject)
+			assert.Equal(t, "alice", reqctx.Actor(ctx))
+			return u, nil
+		})
+
+	_, err := client.GetUser(withToken("good"), &userv1.GetUserRequest{Id: id.String()})
+	require.NoError(t, err)
+}
+
+func TestAuth_HealthIsPublic(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	conn := dialWithAuth(t, mocks.NewMockUser(ctrl), fakeVerifier{})
+
+	res, err := grpc_health_v1.NewHealthClient(conn).Check(context.Background(), &grpc_health_v1.HealthCheckRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, grpc_health_v1.HealthCheckResponse_SERVING, res.Status)
+}
@@ -0,0 +1,61 @@
+package grpc
+
+import (
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/apperror"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// statusError converts an error returned by a handler to a gRPC status. The
+// status of a domain error carries its code as ErrorInfo reason. Internal
+// errors are never exposed to clients.
+func statusError(err error) error {
+	if e, ok := apperror.As(err); ok {
+		code := Code(e.Kind)
+		if code == codes.Internal {
+			return internalError()
+		}
+		st := status.New(code, e.Message)
+		if withInfo, err := st.WithDetails(&errdetails.ErrorInfo{Reason: e.ErrorCode()}); err == nil {
+			st = withInfo
+		}
+		return st.Err()
+	}
+
+	if st, ok := status.FromError(err); ok && st.Code() != codes.Unknown {
+		return st.Err()
+	}
+
+	return internalError()
+}
+
+// Code maps a domain error kind to its gRPC status code.
+func Code(kind apperror.Kind) codes.Code {
+	switch kind {
+	case apperror.KindNotFound:
+		return codes.NotFound
+	case apperror.KindConflict:
+		return codes.AlreadyExists
+	case apperror.KindValidation:
+		return codes.InvalidArgument
+	case apperror.KindUnauthorized:
+		return codes.Unauthenticated
+	case apperror.KindForbidden:
+		return codes.PermissionDenied
+	case apperror.KindRateLimited:
+		return codes.ResourceExhausted
+	case apperror.KindPreconditionFailed:
+		// A version mismatch: the client should read the resource again
+		// and retry, which is what ABORTED means.
+		return codes.Aborted
+	case apperror.KindUnprocessable:
+		return codes.FailedPrecondition
+	default:
+		return codes.Internal
+	}
+}
+
+func internalError() error {
+	return status.Error(codes.Internal, "internal server error")
+}
@@ -0,0 +1,42 @@
+package grpc
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/apperror"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestCode(t *testing.T) {
+	cases := map[apperror.Kind]codes.Code{
+		apperror.KindNotFound:           codes.NotFound,
+		apperror.KindConflict:           codes.AlreadyExists,
+		apperror.KindValidation:         codes.InvalidArgument,
+		apperror.KindUnauthorized:       codes.Unauthenticated,
+		apperror.KindForbidden:          codes.PermissionDenied,
+		apperror.KindRateLimited:        codes.ResourceExhausted,
+		apperror.KindPreconditionFailed: codes.Aborted,
+		apperror.KindUnprocessable:      codes.FailedPrecondition,
+		apperror.KindInternal:           codes.Internal,
+	}
+	for kind, code := range cases {
+		assert.Equal(t, code, Code(kind), kind.String())
+	}
+}
+
+func TestStatusError(t *testing.T) {
+	err := statusError(fmt.Errorf("GetUserById: %w", apperror.NotFound("user not found", nil)))
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	assert.Equal(t, "not_found", errorReason(err))
+
+	// Statuses returned as is, e.g. by generated Unimplemented methods.
+	err = statusError(status.Error(codes.Unimplemented, "method not implemented"))
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+
+	err = statusError(apperror.New(apperror.KindInternal, "db", "connection refused"))
+	assert.Equal(t, codes.Internal, status.Code(err))
+	assert.Equal(t, "internal server error", status.Convert(err).Message())
+}
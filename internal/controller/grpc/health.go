@@ -0,0 +1,54 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/DeSouzaRafael/go-clean-architecture-template/infra/health"
+	userv1 "github.com/DeSouzaRafael/go-clean-architecture-template/internal/controller/grpc/pb/user/v1"
+	grpchealth "google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// Probes reports the readiness the health service follows.
+type Probes interface {
+	Ready(ctx context.Context) health.Report
+}
+
+// Health is the standard gRPC health service of the server and of the user
+// service. Both report SERVING until Update sees failing readiness or
+// Shutdown is called.
+type Health struct {
+	server *grpchealth.Server
+	probes Probes
+}
+
+func newHealth(probes Probes) *Health {
+	h := &Health{server: grpchealth.NewServer(), probes: probes}
+	h.set(grpc_health_v1.HealthCheckResponse_SERVING)
+	return h
+}
+
+// Update sets the services NOT_SERVING while readiness is failing and
+// SERVING otherwise. It does nothing without probes or after Shutdown.
+func (h *Health) Update(ctx context.Context) {
+	if h.probes == nil {
+		return
+	}
+	status := grpc_health_v1.HealthCheckResponse_SERVING
+	if h.probes.Ready(ctx).Status == health.StatusFailing {
+		status = grpc_health_v1.HealthCheckResponse_NOT_SERVING
+	}
+	h.set(status)
+}
+
+// Shutdown sets the services NOT_SERVING for good, so that clients move to
+// other instances while the server drains.
+func (h *Health) Shutdown() {
+	h.server.Shutdown()
+}
+
+func (h *Health) set(status grpc_health_v1.HealthCheckResponse_ServingStatus) {
+	for _, service := range []string{"", userv1.UserService_ServiceDesc.ServiceName} {
+		h.server.SetServingStatus(service, status)
+	}
+}
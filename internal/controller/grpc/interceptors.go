@@ -0,0 +1,112 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/DeSouzaRafael/go-clean-architecture-template/infra/logger"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/reqctx"
+	"github.com/google/uuid"
+	gogrpc "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	metadataRequestID = "x-request-id"
+	maxRequestIDLen   = 128
+)
+
+// recoverUnary turns a panic in a handler into an internal error.
+func recoverUnary(l logger.Interface) gogrpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *gogrpc.UnaryServerInfo, handler gogrpc.UnaryHandler) (resp any, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				l.WithContext(ctx).Error("grpc - "+info.FullMethod, logger.Err(fmt.Errorf("panic: %v", r)))
+				err = internalError()
+			}
+		}()
+		return handler(ctx, req)
+	}
+}
+
+func recoverStream(l logger.Interface) gogrpc.StreamServerInterceptor {
+	return func(srv any, ss gogrpc.ServerStream, info *gogrpc.StreamServerInfo, handler gogrpc.StreamHandler) (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				l.WithContext(ss.Context()).Error("grpc - "+info.FullMethod, logger.Err(fmt.Errorf("panic: %v", r)))
+				err = internalError()
+			}
+		}()
+		return handler(srv, ss)
+	}
+}
+
+// requestContextUnary copies the x-request-id metadata, or a generated ID,
+// and the method into the context, and sends the ID back in the response
+// header: the gRPC counterpart of the REST RequestContext middleware.
+func requestContextUnary() gogrpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *gogrpc.UnaryServerInfo, handler gogrpc.UnaryHandler) (any, error) {
+		ctx, id := requestContext(ctx, info.FullMethod)
+		_ = gogrpc.SetHeader(ctx, metadata.Pairs(metadataRequestID, id))
+		return handler(ctx, req)
+	}
+}
+
+func requestContextStream() gogrpc.StreamServerInterceptor {
+	return func(srv any, ss gogrpc.ServerStream, info *gogrpc.StreamServerInfo, handler gogrpc.StreamHandler) error {
+		ctx, id := requestContext(ss.Context(), info.FullMethod)
+		_ = ss.SetHeader(metadata.Pairs(metadataRequestID, id))
+		return handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+func requestContext(ctx context.Context, method string) (context.Context, string) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	id := ""
+	if ids := md.Get(metadataRequestID); len(ids) > 0 && len(ids[0]) <= maxRequestIDLen {
+		id = ids[0]
+	}
+	if id == "" {
+		id = uuid.NewString()
+	}
+	return reqctx.WithRoute(reqctx.WithRequestID(ctx, id), method), id
+}
+
+// errorsUnary converts handler errors to statuses, logging internal ones.
+func errorsUnary(l logger.Interface) gogrpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *gogrpc.UnaryServerInfo, handler gogrpc.UnaryHandler) (any, error) {
+		resp, err := handler(ctx, req)
+		if err != nil {
+			return resp, logStatus(l.WithContext(ctx), info.FullMethod, err)
+		}
+		return resp, nil
+	}
+}
+
+func errorsStream(l logger.Interface) gogrpc.StreamServerInterceptor {
+	return func(srv any, ss gogrpc.ServerStream, info *gogrpc.StreamServerInfo, handler gogrpc.StreamHandler) error {
+		if err := handler(srv, ss); err != nil {
+			return logStatus(l.WithContext(ss.Context()), info.FullMethod, err)
+		}
+		return nil
+	}
+}
+
+func logStatus(l logger.Interface, method string, err error) error {
+	serr := statusError(err)
+	if status.Code(serr) == codes.Internal {
+		l.Error("grpc - "+method, logger.Err(err))
+	}
+	return serr
+}
+
+type serverStream struct {
+	gogrpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,38 @@
+// Package grpc serves the use cases over gRPC, next to the REST API.
+package grpc
+
+import (
+	"github.com/DeSouzaRafael/go-clean-architecture-template/infra/logger"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/infra/validator"
+	userv1 "github.com/DeSouzaRafael/go-clean-architecture-template/internal/controller/grpc/pb/user/v1"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/usecase"
+	gogrpc "google.golang.org/grpc"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+)
+
+// NewServer returns a gRPC server with the user service, the standard health
+// service and server reflection registered, along with the health service so
+// that the caller can follow probes with it. When tokens is not nil, user
+// service calls require a bearer token.
+func NewServer(l logger.Interface, v *validator.Validator, uc usecase.UseCases, tokens TokenVerifier, probes Probes) (*gogrpc.Server, *Health) {
+	unary := []gogrpc.UnaryServerInterceptor{requestContextUnary(), recoverUnary(l), errorsUnary(l)}
+	stream := []gogrpc.StreamServerInterceptor{requestContextStream(), recoverStream(l), errorsStream(l)}
+	if tokens != nil {
+		unary = append(unary, authUnary(tokens))
+		stream = append(stream, authStream(tokens))
+	}
+	s := gogrpc.NewServer(
+		gogrpc.ChainUnaryInterceptor(unary...),
+		gogrpc.ChainStreamInterceptor(stream...),
+	)
+
+	userv1.RegisterUserServiceServer(s, newUserService(l, v, uc.UserUseCase()))
+
+	hs := newHealth(probes)
+	grpc_health_v1.RegisterHealthServer(s, hs.server)
+
+	reflection.Register(s)
+
+	return s, hs
+}
@@ -0,0 +1,288 @@
+package grpc
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/DeSouzaRafael/go-clean-architecture-template/infra/health"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/infra/logger"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/infra/validator"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/apperror"
+	userv1 "github.com/DeSouzaRafael/go-clean-architecture-template/internal/controller/grpc/pb/user/v1"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/entity"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/reqctx"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/usecase"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	gogrpc "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// dial serves NewServer over an in-memory listener and returns a client
+// connection to it.
+func dial(t *testing.T, uc usecase.User) *gogrpc.ClientConn {
+	t.Helper()
+	return dialWithAuth(t, uc, nil)
+}
+
+func dialWithAuth(t *testing.T, uc usecase.User, tokens TokenVerifier) *gogrpc.ClientConn {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	ucs := mocks.NewMockUseCases(ctrl)
+	ucs.EXPECT().UserUseCase().Return(uc).AnyTimes()
+
+	srv, _ := NewServer(logger.NewLogger("error"), validator.NewValidator(), ucs, tokens, nil)
+	return serve(t, srv)
+}
+
+// serve serves srv over an in-memory listener and returns a client
+// connection to it.
+func serve(t *testing.T, srv *gogrpc.Server) *gogrpc.ClientConn {
+	t.Helper()
+	lis := bufconn.Listen(1 << 20)
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(srv.Stop)
+
+	conn, err := gogrpc.NewClient("passthrough:///bufnet",
+		gogrpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		gogrpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+	return conn
+}
+
+// errorReason returns the ErrorInfo reason attached to err.
+func errorReason(err error) string {
+	for _, d := range status.Convert(err).Details() {
+		if info, ok := d.(*errdetails.ErrorInfo); ok {
+			return info.Reason
+		}
+	}
+	return ""
+}
+
+func TestNewServer_Services(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ucs := mocks.NewMockUseCases(ctrl)
+	ucs.EXPECT().UserUseCase().Return(mocks.NewMockUser(ctrl))
+
+	srv, _ := NewServer(logger.NewLogger("error"), validator.NewValidator(), ucs, nil, nil)
+	info := srv.GetServiceInfo()
+
+	assert.Contains(t, info, "user.v1.UserService")
+	assert.Contains(t, info, "grpc.health.v1.Health")
+	assert.Contains(t, info, "grpc.reflection.v1.ServerReflection")
+}
+
+func TestHealth(t *testing.T) {
+	client := grpc_health_v1.NewHealthClient(dial(t, nil))
+
+	for _, service := range []string{"", "user.v1.UserService"} {
+		resp, err := client.Check(context.Background(), &grpc_health_v1.HealthCheckRequest{Service: service})
+		require.NoError(t, err)
+		assert.Equal(t, grpc_health_v1.HealthCheckResponse_SERVING, resp.Status)
+	}
+}
+
+type fakeProbes struct{ status health.Status }
+
+func (p *fakeProbes) Ready(context.Context) health.Report { return health.Report{Status: p.status} }
+
+func TestHealth_FollowsReadiness(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ucs := mocks.NewMockUseCases(ctrl)
+	ucs.EXPECT().UserUseCase().Return(mocks.NewMockUser(ctrl))
+	probes := &fakeProbes{status: health.StatusFailing}
+	srv, hs := NewServer(logger.NewLogger("error"), validator.NewValidator(), ucs, nil, probes)
+	client := grpc_health_v1.NewHealthClient(serve(t, srv))
+
+	check := func(want grpc_health_v1.HealthCheckResponse_ServingStatus) {
+		t.Helper()
+		for _, service := range []string{"", "user.v1.UserService"} {
+			resp, err := client.Check(context.Background(), &grpc_health_v1.HealthCheckRequest{Service: service})
+			require.NoError(t, err)
+			assert.Equal(t, want, resp.Status, service)
+		}
+	}
+
+	hs.Update(context.Background())
+	check(grpc_health_v1.HealthCheckResponse_NOT_SERVING)
+
+	// Degraded readiness still serves.
+	probes.status = health.StatusDegraded
+	hs.Update(context.Background())
+	check(grpc_health_v1.HealthCheckResponse_SERVING)
+
+	// Once shut down, readiness no longer brings the services back.
+	hs.Shutdown()
+	probes.status = health.StatusOK
+	hs.Update(context.Background())
+	check(grpc_health_v1.HealthCheckResponse_NOT_SERVING)
+}
+
+func TestGetUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	uc := mocks.NewMockUser(ctrl)
+	client := userv1.NewUserServiceClient(dial(t, uc))
+
+	now := time.Now().UTC()
+	user := entity.UserEntity{ID: uuid.New(), Name: "Ana", Phone: "1", Version: 2, CreatedAt: now, UpdatedAt: now}
+	uc.EXPECT().GetUserById(gomock.Any(), entity.UserEntity{ID: user.ID}).Return(user, nil)
+
+	resp, err := client.GetUser(context.Background(), &userv1.GetUserRequest{Id: user.ID.String()})
+
+	require.NoError(t, err)
+	assert.Equal(t, user.ID.String(), resp.Id)
+	assert.Equal(t, "Ana", resp.Name)
+	assert.Equal(t, "1", resp.Phone)
+	assert.Equal(t, int64(2), resp.Version)
+	assert.Equal(t, now, resp.CreateTime.AsTime())
+	assert.Equal(t, now, resp.UpdateTime.AsTime())
+}
+
+func TestGetUser_Errors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	uc := mocks.NewMockUser(ctrl)
+	client := userv1.NewUserServiceClient(dial(t, uc))
+
+	_, err := client.GetUser(context.Background(), &userv1.GetUserRequest{Id: "nope"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Equal(t, "invalid UUID format", status.Convert(err).Message())
+
+	notFound := apperror.Wrap(apperror.ErrNotFound, apperror.KindNotFound, "user_not_found", "user not found")
+	uc.EXPECT().GetUserById(gomock.Any(), gomock.Any()).Return(entity.UserEntity{}, notFound)
+	_, err = client.GetUser(context.Background(), &userv1.GetUserRequest{Id: uuid.NewString()})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	assert.Equal(t, "user not found", status.Convert(err).Message())
+	assert.Equal(t, "user_not_found", errorReason(err))
+
+	uc.EXPECT().GetUserById(gomock.Any(), gomock.Any()).Return(entity.UserEntity{}, assert.AnError)
+	_, err = client.GetUser(context.Background(), &userv1.GetUserRequest{Id: uuid.NewString()})
+	assert.Equal(t, codes.Internal, status.Code(err))
+	assert.Equal(t, "internal server error", status.Convert(err).Message())
+}
+
+func TestCreateUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	uc := mocks.NewMockUser(ctrl)
+	client := userv1.NewUserServiceClient(dial(t, uc))
+
+	created := entity.UserEntity{ID: uuid.New(), Name: "Ana", Phone: "1", Version: 1}
+	uc.EXPECT().CreateUser(gomock.Any(), entity.UserEntity{Name: "Ana", Phone: "1"}).DoAndReturn(func(ctx context.Context, _ entity.UserEntity) (entity.UserEntity, error) {
+		assert.Equal(t, "req-1", reqctx.RequestID(ctx))
+		assert.Equal(t, "/user.v1.UserService/CreateUser", reqctx.Route(ctx))
+		return created, nil
+	})
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "x-request-id", "req-1")
+	var header metadata.MD
+	resp, err := client.CreateUser(ctx, &userv1.CreateUserRequest{Name: "Ana", Phone: "1"}, gogrpc.Header(&header))
+
+	require.NoError(t, err)
+	assert.Equal(t, created.ID.String(), resp.Id)
+	assert.Equal(t, []string{"req-1"}, header.Get("x-request-id"))
+
+	_, err = client.CreateUser(context.Background(), &userv1.CreateUserRequest{Name: "Ana"}, gogrpc.Header(&header))
+	assert.Len(t, header.Get("x-request-id")[0], 36, "generated request ID")
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Equal(t, "validation_failed", errorReason(err))
+}
+
+func TestUpdateUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	uc := mocks.NewMockUser(ctrl)
+	client := userv1.NewUserServiceClient(dial(t, uc))
+
+	id := uuid.New()
+	gomock.InOrder(
+		uc.EXPECT().UpdateUser(gomock.Any(), entity.UserEntity{ID: id, Name: "Bia", Phone: "2", Version: 3}).Return(nil),
+		uc.EXPECT().GetUserById(gomock.Any(), entity.UserEntity{ID: id}).Return(entity.UserEntity{ID: id, Name: "Bia", Phone: "2", Version: 4}, nil),
+	)
+
+	resp, err := client.UpdateUser(context.Background(), &userv1.UpdateUserRequest{Id: id.String(), Name: "Bia", Phone: "2", Version: 3})
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), resp.Version)
+
+	mismatch := apperror.Wrap(apperror.ErrPreconditionFailed, apperror.KindPreconditionFailed, "user_version_mismatch", "user has been modified since it was read")
+	uc.EXPECT().UpdateUser(gomock.Any(), gomock.Any()).Return(mismatch)
+	_, err = client.UpdateUser(context.Background(), &userv1.UpdateUserRequest{Id: id.String(), Name: "Bia", Phone: "2", Version: 1})
+	assert.Equal(t, codes.Aborted, status.Code(err))
+	assert.Equal(t, "user_version_mismatch", errorReason(err))
+}
+
+func TestDeleteUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	uc := mocks.NewMockUser(ctrl)
+	client := userv1.NewUserServiceClient(dial(t, uc))
+
+	id := uuid.New()
+	uc.EXPECT().DeleteUser(gomock.Any(), entity.UserEntity{ID: id, Version: 2}).Return(nil)
+
+	_, err := client.DeleteUser(context.Background(), &userv1.DeleteUserRequest{Id: id.String(), Version: 2})
+	assert.NoError(t, err)
+}
+
+func TestListUsers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	uc := mocks.NewMockUser(ctrl)
+	client := userv1.NewUserServiceClient(dial(t, uc))
+
+	cursor := usecase.Cursor{Sort: "name", Values: []any{"Ana"}, ID: uuid.New()}
+	total := int64(7)
+	uc.EXPECT().ListUsers(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, criteria usecase.Criteria, req usecase.PageRequest) (usecase.Page[entity.UserEntity], error) {
+		assert.Equal(t, []usecase.Order{{Field: "name"}}, criteria.Sort)
+		require.Len(t, criteria.Conditions, 1)
+		assert.Equal(t, "phone", criteria.Conditions[0].Field)
+		assert.Equal(t, 5, req.Limit)
+		assert.True(t, req.WithTotal)
+		assert.Equal(t, &cursor, req.Cursor)
+		return usecase.Page[entity.UserEntity]{Items: []entity.UserEntity{{ID: uuid.New()}}, Total: &total, NextCursor: &cursor, HasMore: true}, nil
+	})
+
+	resp, err := client.ListUsers(context.Background(), &userv1.ListUsersRequest{
+		PageSize: 5, PageToken: cursor.Encode(), Filter: "phone==1", OrderBy: "name", IncludeTotal: true,
+	})
+
+	require.NoError(t, err)
+	assert.Len(t, resp.Users, 1)
+	assert.Equal(t, cursor.Encode(), resp.NextPageToken)
+	assert.Equal(t, total, resp.GetTotalSize())
+}
+
+func TestListUsers_InvalidRequest(t *testing.T) {
+	client := userv1.NewUserServiceClient(dial(t, mocks.NewMockUser(gomock.NewController(t))))
+
+	for _, req := range []*userv1.ListUsersRequest{
+		{PageSize: 101},
+		{PageSize: -1},
+		{PageToken: "garbage"},
+		{Filter: "name"},
+		{OrderBy: "-"},
+	} {
+		_, err := client.ListUsers(context.Background(), req)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err), "%v", req)
+	}
+}
+
+func TestRecover(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	uc := mocks.NewMockUser(ctrl)
+	client := userv1.NewUserServiceClient(dial(t, uc))
+
+	uc.EXPECT().DeleteUser(gomock.Any(), gomock.Any()).DoAndReturn(func(context.Context, entity.UserEntity) error { panic("boom") })
+
+	_, err := client.DeleteUser(context.Background(), &userv1.DeleteUserRequest{Id: uuid.NewString()})
+	assert.Equal(t, codes.Internal, status.Code(err))
+}
@@ -0,0 +1,165 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/DeSouzaRafael/go-clean-architecture-template/infra/logger"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/infra/validator"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/apperror"
+	userv1 "github.com/DeSouzaRafael/go-clean-architecture-template/internal/controller/grpc/pb/user/v1"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/entity"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/query"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/usecase"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type userInput struct {
+	Name  string `validate:"required"`
+	Phone string `validate:"required"`
+}
+
+type userService struct {
+	userv1.UnimplementedUserServiceServer
+	usecase   usecase.User
+	logger    logger.Interface
+	validator *validator.Validator
+}
+
+func newUserService(l logger.Interface, v *validator.Validator, uc usecase.User) *userService {
+	return &userService{usecase: uc, logger: l, validator: v}
+}
+
+func (s *userService) GetUser(ctx context.Context, req *userv1.GetUserRequest) (*userv1.User, error) {
+	id, err := parseUserID(req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.usecase.GetUserById(ctx, entity.UserEntity{ID: id})
+	if err != nil {
+		return nil, err
+	}
+
+	return toUser(user), nil
+}
+
+func (s *userService) CreateUser(ctx context.Context, req *userv1.CreateUserRequest) (*userv1.User, error) {
+	if err := s.validate(userInput{Name: req.GetName(), Phone: req.GetPhone()}); err != nil {
+		return nil, err
+	}
+
+	user, err := s.usecase.CreateUser(ctx, entity.UserEntity{Name: req.GetName(), Phone: req.GetPhone()})
+	if err != nil {
+		return nil, err
+	}
+
+	return toUser(user), nil
+}
+
+func (s *userService) UpdateUser(ctx context.Context, req *userv1.UpdateUserRequest) (*userv1.User, error) {
+	id, err := parseUserID(req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.validate(userInput{Name: req.GetName(), Phone: req.GetPhone()}); err != nil {
+		return nil, err
+	}
+
+	user := entity.UserEntity{ID: id, Name: req.GetName(), Phone: req.GetPhone(), Version: req.GetVersion()}
+	if err := s.usecase.UpdateUser(ctx, user); err != nil {
+		return nil, err
+	}
+
+	user, err = s.usecase.GetUserById(ctx, entity.UserEntity{ID: id})
+	if err != nil {
+		return nil, err
+	}
+
+	return toUser(user), nil
+}
+
+func (s *userService) DeleteUser(ctx context.Context, req *userv1.DeleteUserRequest) (*emptypb.Empty, error) {
+	id, err := parseUserID(req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.usecase.DeleteUser(ctx, entity.UserEntity{ID: id, Version: req.GetVersion()}); err != nil {
+		return nil, err
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *userService) ListUsers(ctx context.Context, req *userv1.ListUsersRequest) (*userv1.ListUsersResponse, error) {
+	page := usecase.PageRequest{Limit: int(req.GetPageSize()), WithTotal: req.GetIncludeTotal()}
+	if page.Limit == 0 {
+		page.Limit = usecase.DefaultPageLimit
+	}
+	if page.Limit < 1 || page.Limit > usecase.MaxPageLimit {
+		return nil, apperror.Validation(fmt.Sprintf("page_size must be between 1 and %d", usecase.MaxPageLimit), nil)
+	}
+	if req.GetPageToken() != "" {
+		cursor, err := usecase.DecodeCursor(req.GetPageToken())
+		if err != nil {
+			return nil, err
+		}
+		page.Cursor = cursor
+	}
+
+	conds, err := query.ParseFilter(req.GetFilter())
+	if err != nil {
+		return nil, err
+	}
+	sort, err := query.ParseSort(req.GetOrderBy())
+	if err != nil {
+		return nil, err
+	}
+
+	users, err := s.usecase.ListUsers(ctx, usecase.Criteria{Conditions: conds, Sort: sort}, page)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &userv1.ListUsersResponse{
+		Users:     make([]*userv1.User, 0, len(users.Items)),
+		TotalSize: users.Total,
+	}
+	for _, user := range users.Items {
+		resp.Users = append(resp.Users, toUser(user))
+	}
+	if users.NextCursor != nil {
+		resp.NextPageToken = users.NextCursor.Encode()
+	}
+
+	return resp, nil
+}
+
+func (s *userService) validate(input userInput) error {
+	if err := s.validator.Validate(input); err != nil {
+		return apperror.Validation("invalid request data: "+err.Error(), err)
+	}
+	return nil
+}
+
+func parseUserID(s string) (uuid.UUID, error) {
+	id, err := uuid.Parse(s)
+	if err != nil {
+		return uuid.Nil, apperror.Validation("invalid UUID format", err)
+	}
+	return id, nil
+}
+
+func toUser(user entity.UserEntity) *userv1.User {
+	return &userv1.User{
+		Id:         user.ID.String(),
+		Name:       user.Name,
+		Phone:      user.Phone,
+		Version:    user.Version,
+		CreateTime: timestamppb.New(user.CreatedAt),
+		UpdateTime: timestamppb.New(user.UpdatedAt),
+	}
+}
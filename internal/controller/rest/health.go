@@ -0,0 +1,31 @@
+package rest
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/DeSouzaRafael/go-clean-architecture-template/infra/health"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/controller/rest/output"
+	"github.com/labstack/echo/v4"
+)
+
+// Probes reports the health of the application on /livez and /readyz.
+type Probes interface {
+	Live(ctx context.Context) health.Report
+	Ready(ctx context.Context) health.Report
+}
+
+// probe serves a report: 200 when it is ok or degraded and 503 when it is
+// failing. The checks are listed when the verbose query parameter is set.
+func probe(report func(context.Context) health.Report) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		r := report(c.Request().Context())
+		code := http.StatusOK
+		if r.Status == health.StatusFailing {
+			code = http.StatusServiceUnavailable
+		}
+		_, verbose := c.QueryParams()["verbose"]
+		c.Response().Header().Set(echo.HeaderCacheControl, "no-store")
+		return c.JSON(code, output.NewHealthOutput(r, verbose))
+	}
+}
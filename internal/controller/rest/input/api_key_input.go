@@ -0,0 +1,17 @@
+package input
+
+import (
+	"time"
+
+	"github.com/DeSouzaRafael/go-clean-architecture-template/infra/validator"
+)
+
+type APIKeyInput struct {
+	Name      string     `json:"name" validate:"required,max=100" example:"acme integration"`
+	Scopes    []string   `json:"scopes" validate:"required,min=1" example:"users:read,users:write"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty" example:"2027-01-01T00:00:00Z"`
+}
+
+func (input *APIKeyInput) Validate(v *validator.Validator) error {
+	return v.Validate(input)
+}
@@ -0,0 +1,12 @@
+package input
+
+import "github.com/DeSouzaRafael/go-clean-architecture-template/infra/validator"
+
+type LogLevelInput struct {
+	Level      string `json:"level" validate:"required,oneof=debug info warn error" example:"debug"`
+	TTLSeconds int    `json:"ttl_seconds" validate:"required,min=1,max=86400" example:"600"`
+}
+
+func (input *LogLevelInput) Validate(v *validator.Validator) error {
+	return v.Validate(input)
+}
@@ -0,0 +1,14 @@
+package input
+
+import "github.com/DeSouzaRafael/go-clean-architecture-template/infra/validator"
+
+type PageInput struct {
+	Limit  int    `query:"limit" validate:"omitempty,min=1,max=100" example:"20"`
+	Offset int    `query:"offset" validate:"omitempty,min=0" example:"0"`
+	Cursor string `query:"cursor"`
+	Total  bool   `query:"total"`
+}
+
+func (input *PageInput) Validate(v *validator.Validator) error {
+	return v.Validate(input)
+}
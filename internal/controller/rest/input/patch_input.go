@@ -0,0 +1,72 @@
+package input
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"mime"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+)
+
+const (
+	MIMEMergePatch = "application/merge-patch+json"
+	MIMEJSONPatch  = "application/json-patch+json"
+)
+
+var (
+	ErrUnsupportedPatch = errors.New("unsupported patch media type")
+	ErrPatchTestFailed  = jsonpatch.ErrTestFailed
+)
+
+// PatchDocument is a decoded RFC 7396 JSON Merge Patch or RFC 6902 JSON Patch.
+type PatchDocument struct {
+	merge []byte
+	ops   jsonpatch.Patch
+}
+
+// DecodePatch parses body according to its media type.
+func DecodePatch(contentType string, body []byte) (*PatchDocument, error) {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return nil, ErrUnsupportedPatch
+	}
+
+	switch mediaType {
+	case MIMEMergePatch:
+		var obj map[string]json.RawMessage
+		if err := json.Unmarshal(body, &obj); err != nil || obj == nil {
+			return nil, errors.New("merge patch must be a JSON object")
+		}
+		return &PatchDocument{merge: body}, nil
+	case MIMEJSONPatch:
+		ops, err := jsonpatch.DecodePatch(body)
+		if err != nil {
+			return nil, err
+		}
+		return &PatchDocument{ops: ops}, nil
+	default:
+		return nil, ErrUnsupportedPatch
+	}
+}
+
+// Apply patches the JSON document doc and decodes the result into out,
+// rejecting fields out does not declare.
+func (p *PatchDocument) Apply(doc []byte, out interface{}) error {
+	var (
+		patched []byte
+		err     error
+	)
+	if p.ops != nil {
+		patched, err = p.ops.Apply(doc)
+	} else {
+		patched, err = jsonpatch.MergePatch(doc, p.merge)
+	}
+	if err != nil {
+		return err
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(patched))
+	dec.DisallowUnknownFields()
+	return dec.Decode(out)
+}
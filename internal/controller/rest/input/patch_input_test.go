@@ -0,0 +1,68 @@
+package input
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const userDoc = `{"name":"Ana","phone":"+5511999999999"}`
+
+func TestDecodePatch_MergePatch(t *testing.T) {
+	doc, err := DecodePatch(MIMEMergePatch+"; charset=utf-8", []byte(`{"name":"Bia"}`))
+	require.NoError(t, err)
+
+	var out UserInput
+	require.NoError(t, doc.Apply([]byte(userDoc), &out))
+	assert.Equal(t, UserInput{Name: "Bia", Phone: "+5511999999999"}, out)
+}
+
+func TestDecodePatch_MergePatchRemovesNull(t *testing.T) {
+	doc, err := DecodePatch(MIMEMergePatch, []byte(`{"phone":null}`))
+	require.NoError(t, err)
+
+	var out UserInput
+	require.NoError(t, doc.Apply([]byte(userDoc), &out))
+	assert.Equal(t, UserInput{Name: "Ana"}, out)
+}
+
+func TestDecodePatch_JSONPatch(t *testing.T) {
+	doc, err := DecodePatch(MIMEJSONPatch, []byte(`[{"op":"test","path":"/name","value":"Ana"},{"op":"replace","path":"/phone","value":"+5511888888888"}]`))
+	require.NoError(t, err)
+
+	var out UserInput
+	require.NoError(t, doc.Apply([]byte(userDoc), &out))
+	assert.Equal(t, UserInput{Name: "Ana", Phone: "+5511888888888"}, out)
+}
+
+func TestDecodePatch_JSONPatchTestFailed(t *testing.T) {
+	doc, err := DecodePatch(MIMEJSONPatch, []byte(`[{"op":"test","path":"/name","value":"Bia"}]`))
+	require.NoError(t, err)
+
+	var out UserInput
+	assert.ErrorIs(t, doc.Apply([]byte(userDoc), &out), ErrPatchTestFailed)
+}
+
+func TestDecodePatch_UnknownField(t *testing.T) {
+	doc, err := DecodePatch(MIMEMergePatch, []byte(`{"role":"admin"}`))
+	require.NoError(t, err)
+
+	var out UserInput
+	assert.Error(t, doc.Apply([]byte(userDoc), &out))
+}
+
+func TestDecodePatch_Invalid(t *testing.T) {
+	_, err := DecodePatch(MIMEMergePatch, []byte(`["not","an","object"]`))
+	assert.Error(t, err)
+
+	_, err = DecodePatch(MIMEJSONPatch, []byte(`{"op":"replace"}`))
+	assert.Error(t, err)
+}
+
+func TestDecodePatch_UnsupportedMediaType(t *testing.T) {
+	for _, ct := range []string{"application/json", "", "text/plain;;"} {
+		_, err := DecodePatch(ct, []byte(`{}`))
+		assert.ErrorIs(t, err, ErrUnsupportedPatch, ct)
+	}
+}
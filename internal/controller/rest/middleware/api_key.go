@@ -0,0 +1,46 @@
+package middleware
+
+import (
+	"context"
+
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/entity"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/reqctx"
+)
+
+const HeaderAPIKey = "X-API-Key"
+
+// APIKeyAuthenticator checks an API key and returns the principal of the
+// client it was issued to.
+type APIKeyAuthenticator interface {
+	AuthenticateAPIKey(ctx context.Context, key string) (entity.Principal, error)
+}
+
+// APIKey authenticates requests carrying an X-API-Key header and puts the
+// key's principal in the request context, where Authenticate finds it and
+// stops asking for a bearer token. An invalid key gets a 401; requests
+// without the header pass through untouched.
+func APIKey(a APIKeyAuthenticator, skipper middleware.Skipper) echo.MiddlewareFunc {
+	if skipper == nil {
+		skipper = middleware.DefaultSkipper
+	}
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			key := c.Request().Header.Get(HeaderAPIKey)
+			if key == "" || skipper(c) {
+				return next(c)
+			}
+
+			req := c.Request()
+			principal, err := a.AuthenticateAPIKey(req.Context(), key)
+			if err != nil {
+				return err
+			}
+
+			c.SetRequest(req.WithContext(reqctx.WithPrincipal(req.Context(), principal)))
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/DeSouzaRafael/go-clean-architecture-template/infra/logger"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/apperror"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/controller/rest/output"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/entity"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/reqctx"
+)
+
+type fakeAPIKeys map[string]entity.Principal
+
+func (f fakeAPIKeys) AuthenticateAPIKey(_ context.Context, key string) (entity.Principal, error) {
+	if p, ok := f[key]; ok {
+		return p, nil
+	}
+	return entity.Principal{}, apperror.Unauthorized("invalid API key", nil)
+}
+
+func TestAPIKey(t *testing.T) {
+	client := entity.Principal{Subject: "apikey:1", Scopes: []string{"users:read"}}
+	tests := []struct {
+		name    string
+		apiKey  string
+		bearer  string
+		status  int
+		subject string
+	}{
+		{"valid key", "good", "", http.StatusNoContent, "apikey:1"},
+		{"key wins over token", "good", "good", http.StatusNoContent, "apikey:1"},
+		{"invalid key", "bad", "good", http.StatusUnauthorized, ""},
+		{"no key falls back to token", "", "good", http.StatusNoContent, "alice"},
+		{"neither", "", "", http.StatusUnauthorized, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got entity.Principal
+			e := echo.New()
+			e.HTTPErrorHandler = output.HTTPErrorHandler(logger.NewLogger("error"))
+			e.Use(APIKey(fakeAPIKeys{"good": client}, nil))
+			e.Use(Authenticate(fakeVerifier{"good": {Subject: "alice"}}, nil))
+			e.GET("/", func(c echo.Context) error {
+				got, _ = reqctx.Principal(c.Request().Context())
+				return c.NoContent(http.StatusNoContent)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/", http.NoBody)
+			if tt.apiKey != "" {
+				req.Header.Set(HeaderAPIKey, tt.apiKey)
+			}
+			if tt.bearer != "" {
+				req.Header.Set(echo.HeaderAuthorization, "Bearer "+tt.bearer)
+			}
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.status, rec.Code)
+			assert.Equal(t, tt.subject, got.Subject)
+		})
+	}
+}
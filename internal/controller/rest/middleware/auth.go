@@ -0,0 +1,66 @@
+package middleware
+
+import (
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/apperror"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/entity"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/reqctx"
+)
+
+// TokenVerifier checks a bearer token and returns the principal it was
+// issued to.
+type TokenVerifier interface {
+	Verify(token string) (entity.Principal, error)
+}
+
+// Authenticate requires a valid "Authorization: Bearer <token>" header on
+// every request skipper does not exempt, and puts the authenticated
+// principal in the request context. Other requests get a 401, unless an
+// earlier middleware, such as APIKey, already authenticated them.
+func Authenticate(v TokenVerifier, skipper middleware.Skipper) echo.MiddlewareFunc {
+	if skipper == nil {
+		skipper = middleware.DefaultSkipper
+	}
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if skipper(c) {
+				return next(c)
+			}
+			if _, ok := reqctx.Principal(c.Request().Context()); ok {
+				return next(c)
+			}
+
+			token, ok := bearerToken(c.Request().Header.Get(echo.HeaderAuthorization))
+			if !ok {
+				c.Response().Header().Set(echo.HeaderWWWAuthenticate, "Bearer")
+				return apperror.Unauthorized("missing bearer token", nil)
+			}
+
+			principal, err := v.Verify(token)
+			if err != nil {
+				c.Response().Header().Set(echo.HeaderWWWAuthenticate, `Bearer error="invalid_token"`)
+				if apperror.KindOf(err) != apperror.KindUnauthorized {
+					return apperror.Unauthorized("invalid token", err)
+				}
+				return err
+			}
+
+			req := c.Request()
+			c.SetRequest(req.WithContext(reqctx.WithPrincipal(req.Context(), principal)))
+			return next(c)
+		}
+	}
+}
+
+func bearerToken(header string) (string, bool) {
+	scheme, token, ok := strings.Cut(header, " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+	token = strings.TrimSpace(token)
+	return token, token != ""
+}
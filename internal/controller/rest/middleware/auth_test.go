@@ -0,0 +1,99 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/DeSouzaRafael/go-clean-architecture-template/infra/logger"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/apperror"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/controller/rest/output"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/entity"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/reqctx"
+)
+
+type fakeVerifier map[string]entity.Principal
+
+func (f fakeVerifier) Verify(token string) (entity.Principal, error) {
+	if p, ok := f[token]; ok {
+		return p, nil
+	}
+	return entity.Principal{}, apperror.Unauthorized("invalid token", nil)
+}
+
+func newAuthServer(skipper func(echo.Context) bool, got *entity.Principal) *echo.Echo {
+	e := echo.New()
+	e.HTTPErrorHandler = output.HTTPErrorHandler(logger.NewLogger("error"))
+	e.Use(Authenticate(fakeVerifier{"good": {Subject: "alice", Roles: []string{"admin"}}}, skipper))
+	e.GET("/*", func(c echo.Context) error {
+		*got, _ = reqctx.Principal(c.Request().Context())
+		return c.NoContent(http.StatusNoContent)
+	})
+	return e
+}
+
+func TestAuthenticate_Rejected(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		want   string
+	}{
+		{"missing", "", "Bearer"},
+		{"scheme", "Basic YWxpY2U6c2VjcmV0", "Bearer"},
+		{"empty token", "Bearer ", "Bearer"},
+		{"invalid", "Bearer bad", `Bearer error="invalid_token"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got entity.Principal
+			e := newAuthServer(nil, &got)
+
+			req := httptest.NewRequest(http.MethodGet, "/", http.NoBody)
+			if tt.header != "" {
+				req.Header.Set(echo.HeaderAuthorization, tt.header)
+			}
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, http.StatusUnauthorized, rec.Code)
+			assert.Equal(t, tt.want, rec.Header().Get(echo.HeaderWWWAuthenticate))
+			assert.Empty(t, got.Subject)
+		})
+	}
+}
+
+func TestAuthenticate_Valid(t *testing.T) {
+	var got entity.Principal
+	var actor string
+	e := echo.New()
+	e.Use(Authenticate(fakeVerifier{"good": {Subject: "alice", Roles: []string{"admin"}}}, nil))
+	e.GET("/", func(c echo.Context) error {
+		got, _ = reqctx.Principal(c.Request().Context())
+		actor = reqctx.Actor(c.Request().Context())
+		return c.NoContent(http.StatusNoContent)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/", http.NoBody)
+	req.Header.Set(echo.HeaderAuthorization, "bearer good")
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusNoContent, rec.Code)
+	assert.Equal(t, entity.Principal{Subject: "alice", Roles: []string{"admin"}}, got)
+	assert.Equal(t, "alice", actor)
+}
+
+func TestAuthenticate_Skipper(t *testing.T) {
+	var got entity.Principal
+	e := newAuthServer(func(c echo.Context) bool { return c.Request().URL.Path == "/health" }, &got)
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/health", http.NoBody))
+
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	assert.Empty(t, got.Subject)
+}
@@ -0,0 +1,142 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/DeSouzaRafael/go-clean-architecture-template/infra/logger"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/apperror"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/entity"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/reqctx"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/usecase"
+)
+
+const (
+	HeaderIdempotencyKey     = "Idempotency-Key"
+	HeaderIdempotentReplayed = "Idempotent-Replayed"
+
+	maxIdempotencyKeyLen = 255
+)
+
+// storedHeaders are the response headers kept for replay. Others, such as
+// CORS headers, are set again by the middleware chain on every request.
+var storedHeaders = []string{echo.HeaderContentType, echo.HeaderLocation, "ETag", "Link"}
+
+// Idempotency makes POST and PATCH requests carrying an Idempotency-Key
+// header safe to retry: the first response is stored and replayed for
+// retries with the same key and body. Server errors release the key so the
+// request can be retried for real, and so do responses marked
+// "Cache-Control: no-store", which may hold secrets.
+func Idempotency(uc usecase.Idempotency, l logger.Interface) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			key := req.Header.Get(HeaderIdempotencyKey)
+			if key == "" || (req.Method != http.MethodPost && req.Method != http.MethodPatch) {
+				return next(c)
+			}
+			if len(key) > maxIdempotencyKeyLen {
+				return apperror.Validation("Idempotency-Key must be at most 255 characters", nil)
+			}
+
+			body, err := io.ReadAll(req.Body)
+			if err != nil {
+				return apperror.Validation("invalid request body", err)
+			}
+			req.Body = io.NopCloser(bytes.NewReader(body))
+
+			l := l.WithContext(req.Context())
+			record, err := uc.Begin(req.Context(), key, fingerprint(req, body))
+			if err != nil {
+				l.Error("http - middleware - idempotency", logger.Err(err))
+				return err
+			}
+			if record.Completed() {
+				return replay(c, record)
+			}
+
+			// The outcome is stored even if the client went away meanwhile.
+			ctx := context.WithoutCancel(req.Context())
+			release := func() {
+				if err := uc.Release(ctx, key); err != nil {
+					l.Error("http - middleware - idempotency - release", logger.Err(err))
+				}
+			}
+			defer func() {
+				if r := recover(); r != nil {
+					release()
+					panic(r)
+				}
+			}()
+
+			rec := &bodyRecorder{ResponseWriter: c.Response().Writer}
+			c.Response().Writer = rec
+
+			err = next(c)
+			if err != nil {
+				// Render the error now so its response can be stored.
+				c.Error(err)
+			}
+
+			res := c.Response()
+			if res.Status >= http.StatusInternalServerError || res.Header().Get(echo.HeaderCacheControl) == "no-store" {
+				release()
+				return err
+			}
+
+			record.StatusCode = res.Status
+			record.Header = make(map[string][]string, len(storedHeaders))
+			for _, h := range storedHeaders {
+				if v := res.Header().Values(h); len(v) > 0 {
+					record.Header[h] = v
+				}
+			}
+			record.Body = rec.body.Bytes()
+
+			if err := uc.Complete(ctx, record); err != nil {
+				l.Error("http - middleware - idempotency - complete", logger.Err(err))
+			}
+			return err
+		}
+	}
+}
+
+func replay(c echo.Context, record entity.IdempotencyRecord) error {
+	res := c.Response()
+	for h, values := range record.Header {
+		res.Header().Del(h)
+		for _, v := range values {
+			res.Header().Add(h, v)
+		}
+	}
+	res.Header().Set(HeaderIdempotentReplayed, "true")
+	res.WriteHeader(record.StatusCode)
+	_, err := res.Write(record.Body)
+	return err
+}
+
+// fingerprint identifies a request by caller, target and body, so that a key
+// reused by another caller never replays a response meant for someone else.
+func fingerprint(req *http.Request, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(reqctx.Actor(req.Context()) + "\n" + req.Method + " " + req.URL.Path + "\n"))
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// bodyRecorder copies the response body while it is written.
+type bodyRecorder struct {
+	http.ResponseWriter
+	body bytes.Buffer
+}
+
+func (r *bodyRecorder) Write(b []byte) (int, error) {
+	r.body.Write(b)
+	return r.ResponseWriter.Write(b)
+}
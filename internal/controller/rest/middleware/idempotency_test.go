@@ -0,0 +1,221 @@
+package middleware
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/DeSouzaRafael/go-clean-architecture-template/infra/logger"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/apperror"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/controller/rest/output"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/entity"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/mocks"
+)
+
+// serve runs a POST /v0/user request with the given key through the
+// idempotency middleware in front of handler.
+func serve(t *testing.T, uc *mocks.MockIdempotency, key, body string, handler echo.HandlerFunc) *httptest.ResponseRecorder {
+	t.Helper()
+	l := logger.NewLogger("info")
+
+	e := echo.New()
+	e.HTTPErrorHandler = output.HTTPErrorHandler(l)
+	e.POST("/v0/user", handler, Idempotency(uc, l))
+
+	req := httptest.NewRequest(http.MethodPost, "/v0/user", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	if key != "" {
+		req.Header.Set(HeaderIdempotencyKey, key)
+	}
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	return rec
+}
+
+func created(c echo.Context) error {
+	body, _ := io.ReadAll(c.Request().Body)
+	c.Response().Header().Set("ETag", `"1"`)
+	return c.JSONBlob(http.StatusCreated, body)
+}
+
+func TestIdempotency_WithoutKey(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	rec := serve(t, mocks.NewMockIdempotency(ctrl), "", `{"name":"Ana"}`, created)
+
+	assert.Equal(t, http.StatusCreated, rec.Code)
+}
+
+func TestIdempotency_FirstRequest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uc := mocks.NewMockIdempotency(ctrl)
+	body := `{"name":"Ana"}`
+
+	gomock.InOrder(
+		uc.EXPECT().Begin(gomock.Any(), "k1", gomock.Any()).Return(entity.IdempotencyRecord{Key: "k1", Fingerprint: "f1"}, nil),
+		uc.EXPECT().Complete(gomock.Any(), entity.IdempotencyRecord{
+			Key:         "k1",
+			Fingerprint: "f1",
+			StatusCode:  http.StatusCreated,
+			Header:      map[string][]string{echo.HeaderContentType: {echo.MIMEApplicationJSON}, "ETag": {`"1"`}},
+			Body:        []byte(body),
+		}).Return(nil),
+	)
+
+	rec := serve(t, uc, "k1", body, created)
+
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	assert.Equal(t, body, rec.Body.String())
+	assert.Empty(t, rec.Header().Get(HeaderIdempotentReplayed))
+}
+
+func TestIdempotency_Replay(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uc := mocks.NewMockIdempotency(ctrl)
+	uc.EXPECT().Begin(gomock.Any(), "k1", gomock.Any()).Return(entity.IdempotencyRecord{
+		Key:        "k1",
+		StatusCode: http.StatusCreated,
+		Header:     map[string][]string{echo.HeaderContentType: {echo.MIMEApplicationJSON}, "ETag": {`"1"`}},
+		Body:       []byte(`{"id":"1"}`),
+	}, nil)
+
+	rec := serve(t, uc, "k1", `{"name":"Ana"}`, func(c echo.Context) error {
+		t.Fatal("handler must not run on replay")
+		return nil
+	})
+
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	assert.Equal(t, `{"id":"1"}`, rec.Body.String())
+	assert.Equal(t, `"1"`, rec.Header().Get("ETag"))
+	assert.Equal(t, echo.MIMEApplicationJSON, rec.Header().Get(echo.HeaderContentType))
+	assert.Equal(t, "true", rec.Header().Get(HeaderIdempotentReplayed))
+}
+
+func TestIdempotency_BeginErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		status int
+	}{
+		{"in flight", apperror.Wrap(nil, apperror.KindConflict, "idempotency_request_in_progress", "in progress"), http.StatusConflict},
+		{"reused", apperror.Wrap(nil, apperror.KindUnprocessable, "idempotency_key_reused", "reused"), http.StatusUnprocessableEntity},
+		{"store failure", fmt.Errorf("connection reset"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			uc := mocks.NewMockIdempotency(ctrl)
+			uc.EXPECT().Begin(gomock.Any(), "k1", gomock.Any()).Return(entity.IdempotencyRecord{}, tt.err)
+
+			rec := serve(t, uc, "k1", `{}`, created)
+
+			assert.Equal(t, tt.status, rec.Code)
+		})
+	}
+}
+
+func TestIdempotency_StoresClientErrors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uc := mocks.NewMockIdempotency(ctrl)
+	gomock.InOrder(
+		uc.EXPECT().Begin(gomock.Any(), "k1", gomock.Any()).Return(entity.IdempotencyRecord{Key: "k1"}, nil),
+		uc.EXPECT().Complete(gomock.Any(), gomock.Any()).DoAndReturn(func(_ interface{}, r entity.IdempotencyRecord) error {
+			assert.Equal(t, http.StatusConflict, r.StatusCode)
+			assert.Contains(t, string(r.Body), `"code":"user_conflict"`)
+			return nil
+		}),
+	)
+
+	rec := serve(t, uc, "k1", `{}`, func(c echo.Context) error {
+		return apperror.Wrap(nil, apperror.KindConflict, "user_conflict", "user already exists")
+	})
+
+	assert.Equal(t, http.StatusConflict, rec.Code)
+}
+
+func TestIdempotency_ReleasesOnServerError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uc := mocks.NewMockIdempotency(ctrl)
+	gomock.InOrder(
+		uc.EXPECT().Begin(gomock.Any(), "k1", gomock.Any()).Return(entity.IdempotencyRecord{Key: "k1"}, nil),
+		uc.EXPECT().Release(gomock.Any(), "k1").Return(nil),
+	)
+
+	rec := serve(t, uc, "k1", `{}`, func(c echo.Context) error {
+		return fmt.Errorf("database is down")
+	})
+
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+}
+
+func TestIdempotency_ReleasesNoStore(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uc := mocks.NewMockIdempotency(ctrl)
+	gomock.InOrder(
+		uc.EXPECT().Begin(gomock.Any(), "k1", gomock.Any()).Return(entity.IdempotencyRecord{Key: "k1"}, nil),
+		uc.EXPECT().Release(gomock.Any(), "k1").Return(nil),
+	)
+
+	rec := serve(t, uc, "k1", `{}`, func(c echo.Context) error {
+		c.Response().Header().Set(echo.HeaderCacheControl, "no-store")
+		return c.JSON(http.StatusOK, map[string]string{"key": "secret"})
+	})
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestIdempotency_ReleasesOnPanic(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uc := mocks.NewMockIdempotency(ctrl)
+	gomock.InOrder(
+		uc.EXPECT().Begin(gomock.Any(), "k1", gomock.Any()).Return(entity.IdempotencyRecord{Key: "k1"}, nil),
+		uc.EXPECT().Release(gomock.Any(), "k1").Return(nil),
+	)
+
+	assert.Panics(t, func() {
+		serve(t, uc, "k1", `{}`, func(c echo.Context) error {
+			panic("boom")
+		})
+	})
+}
+
+func TestIdempotency_KeyTooLong(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	rec := serve(t, mocks.NewMockIdempotency(ctrl), strings.Repeat("k", maxIdempotencyKeyLen+1), `{}`, created)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestFingerprint(t *testing.T) {
+	post := httptest.NewRequest(http.MethodPost, "/v0/user", http.NoBody)
+	patch := httptest.NewRequest(http.MethodPatch, "/v0/user", http.NoBody)
+
+	assert.Equal(t, fingerprint(post, []byte(`{"a":1}`)), fingerprint(post, []byte(`{"a":1}`)))
+	assert.NotEqual(t, fingerprint(post, []byte(`{"a":1}`)), fingerprint(post, []byte(`{"a":2}`)))
+	assert.NotEqual(t, fingerprint(post, []byte(`{"a":1}`)), fingerprint(patch, []byte(`{"a":1}`)))
+}
@@ -0,0 +1,38 @@
+package middleware
+
+import (
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// unmatchedRoute labels requests that match no route, so that scanners
+// probing random paths do not create a series per path.
+const unmatchedRoute = "unmatched"
+
+// HTTPMetrics records served requests.
+type HTTPMetrics interface {
+	ObserveHTTP(method, route string, status int, elapsed time.Duration)
+}
+
+// Metrics records the method, route template, status and latency of every
+// request. Errors are rendered here, through the error handler, so that the
+// recorded status is the one sent; Metrics must therefore run outside of
+// Recover, to see panics turned into 500s, and inside RequestContext.
+func Metrics(m HTTPMetrics) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			begin := time.Now()
+			if err := next(c); err != nil {
+				c.Error(err)
+			}
+
+			route := c.Path()
+			if route == "" {
+				route = unmatchedRoute
+			}
+			m.ObserveHTTP(c.Request().Method, route, c.Response().Status, time.Since(begin))
+			return nil
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/DeSouzaRafael/go-clean-architecture-template/infra/logger"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/apperror"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/controller/rest/output"
+)
+
+type fakeHTTPMetrics struct {
+	observed []string
+}
+
+func (f *fakeHTTPMetrics) ObserveHTTP(method, route string, status int, _ time.Duration) {
+	f.observed = append(f.observed, method+" "+route+" "+http.StatusText(status))
+}
+
+func TestMetrics(t *testing.T) {
+	m := &fakeHTTPMetrics{}
+	e := echo.New()
+	e.HTTPErrorHandler = output.HTTPErrorHandler(logger.NewLogger("error"))
+	e.Use(Metrics(m), middleware.Recover())
+	e.GET("/v0/user/:id", func(c echo.Context) error { return c.NoContent(http.StatusNoContent) })
+	e.POST("/v0/user", func(c echo.Context) error { return apperror.Conflict("user exists", nil) })
+	e.DELETE("/v0/user/:id", func(c echo.Context) error { panic(errors.New("boom")) })
+
+	for _, r := range []struct{ method, path string }{
+		{http.MethodGet, "/v0/user/1"},
+		{http.MethodPost, "/v0/user"},
+		{http.MethodDelete, "/v0/user/1"},
+		{http.MethodGet, "/wp-login.php"},
+	} {
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(r.method, r.path, http.NoBody))
+	}
+
+	assert.Equal(t, []string{
+		"GET /v0/user/:id No Content",
+		"POST /v0/user Conflict",
+		"DELETE /v0/user/:id Internal Server Error",
+		"GET unmatched Not Found",
+	}, m.observed)
+}
@@ -0,0 +1,80 @@
+package middleware
+
+import (
+	"context"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/DeSouzaRafael/go-clean-architecture-template/infra/logger"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/apperror"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/entity"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/reqctx"
+)
+
+const (
+	HeaderRateLimitLimit     = "RateLimit-Limit"
+	HeaderRateLimitRemaining = "RateLimit-Remaining"
+	HeaderRateLimitReset     = "RateLimit-Reset"
+)
+
+// RateLimitStore holds the token buckets, keyed by route group and client.
+type RateLimitStore interface {
+	Take(ctx context.Context, key string, limit entity.RateLimit) (entity.RateLimitDecision, error)
+}
+
+// RateLimit limits the requests of every client to each route group: group
+// names the group of a request, limits returns the current limit of each
+// group, and requests of a group without a limit pass through. A client is
+// identified by its principal, API key or user, and by its IP address when
+// anonymous. Before authentication every client is anonymous, so groups
+// meant to limit each principal need RateLimit to run after it, while a
+// group limiting IP addresses can run before.
+//
+// Responses carry RateLimit-Limit, RateLimit-Remaining and RateLimit-Reset
+// headers; a request over the limit gets a 429 with Retry-After. Requests
+// pass when the store fails, so that an unavailable store does not take the
+// API down with it.
+func RateLimit(store RateLimitStore, limits func() map[string]entity.RateLimit, group func(echo.Context) string, l logger.Interface) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			name := group(c)
+			limit, ok := limits()[name]
+			if !ok {
+				return next(c)
+			}
+
+			d, err := store.Take(c.Request().Context(), name+":"+rateLimitClient(c), limit)
+			if err != nil {
+				l.WithContext(c.Request().Context()).Error("http - middleware - rateLimit", logger.Err(err))
+				return next(c)
+			}
+
+			h := c.Response().Header()
+			h.Set(HeaderRateLimitLimit, strconv.Itoa(d.Limit))
+			h.Set(HeaderRateLimitRemaining, strconv.Itoa(d.Remaining))
+			h.Set(HeaderRateLimitReset, ceilSeconds(d.Reset))
+			if !d.Allowed {
+				h.Set(echo.HeaderRetryAfter, ceilSeconds(d.RetryAfter))
+				return apperror.RateLimited("too many requests, retry later", nil)
+			}
+
+			return next(c)
+		}
+	}
+}
+
+// rateLimitClient identifies the caller. API key principals have subjects
+// of their own, so keys and users never share a bucket.
+func rateLimitClient(c echo.Context) string {
+	if p, ok := reqctx.Principal(c.Request().Context()); ok {
+		return "sub:" + p.Subject
+	}
+	return "ip:" + c.RealIP()
+}
+
+func ceilSeconds(d time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(d.Seconds())))
+}
@@ -0,0 +1,101 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/DeSouzaRafael/go-clean-architecture-template/infra/logger"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/controller/rest/output"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/entity"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/reqctx"
+)
+
+// fakeRateLimits answers every Take with decision, or err, and records the
+// keys it was asked for.
+type fakeRateLimits struct {
+	decision entity.RateLimitDecision
+	err      error
+	keys     []string
+}
+
+func (f *fakeRateLimits) Take(_ context.Context, key string, _ entity.RateLimit) (entity.RateLimitDecision, error) {
+	f.keys = append(f.keys, key)
+	return f.decision, f.err
+}
+
+func serveRateLimited(store RateLimitStore, req *http.Request) *httptest.ResponseRecorder {
+	l := logger.NewLogger("error")
+	e := echo.New()
+	e.HTTPErrorHandler = output.HTTPErrorHandler(l)
+	limits := func() map[string]entity.RateLimit {
+		return map[string]entity.RateLimit{"write": {Rate: 1, Burst: 10}}
+	}
+	e.Use(RateLimit(store, limits, func(c echo.Context) string {
+		if c.Request().Method == http.MethodPost {
+			return "write"
+		}
+		return "read"
+	}, l))
+	ok := func(c echo.Context) error { return c.NoContent(http.StatusNoContent) }
+	e.GET("/", ok)
+	e.POST("/", ok)
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestRateLimit_Allowed(t *testing.T) {
+	store := &fakeRateLimits{decision: entity.RateLimitDecision{Allowed: true, Limit: 10, Remaining: 9, Reset: 1500 * time.Millisecond}}
+	req := httptest.NewRequest(http.MethodPost, "/", http.NoBody)
+	req.Header.Set(echo.HeaderXRealIP, "10.0.0.1")
+
+	rec := serveRateLimited(store, req)
+
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	assert.Equal(t, "10", rec.Header().Get(HeaderRateLimitLimit))
+	assert.Equal(t, "9", rec.Header().Get(HeaderRateLimitRemaining))
+	assert.Equal(t, "2", rec.Header().Get(HeaderRateLimitReset))
+	assert.Empty(t, rec.Header().Get(echo.HeaderRetryAfter))
+	assert.Equal(t, []string{"write:ip:10.0.0.1"}, store.keys)
+}
+
+func TestRateLimit_Exceeded(t *testing.T) {
+	store := &fakeRateLimits{decision: entity.RateLimitDecision{Limit: 10, Reset: 10 * time.Second, RetryAfter: 300 * time.Millisecond}}
+	req := httptest.NewRequest(http.MethodPost, "/", http.NoBody)
+	req = req.WithContext(reqctx.WithPrincipal(req.Context(), entity.Principal{Subject: "apikey:1"}))
+
+	rec := serveRateLimited(store, req)
+
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	assert.JSONEq(t, `{"code":"rate_limited","error":"too many requests, retry later"}`, rec.Body.String())
+	assert.Equal(t, "0", rec.Header().Get(HeaderRateLimitRemaining))
+	assert.Equal(t, "1", rec.Header().Get(echo.HeaderRetryAfter))
+	assert.Equal(t, []string{"write:sub:apikey:1"}, store.keys)
+}
+
+func TestRateLimit_GroupWithoutLimit(t *testing.T) {
+	store := &fakeRateLimits{}
+
+	rec := serveRateLimited(store, httptest.NewRequest(http.MethodGet, "/", http.NoBody))
+
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	assert.Empty(t, rec.Header().Get(HeaderRateLimitLimit))
+	assert.Empty(t, store.keys)
+}
+
+func TestRateLimit_StoreError(t *testing.T) {
+	store := &fakeRateLimits{err: errors.New("connection refused")}
+
+	rec := serveRateLimited(store, httptest.NewRequest(http.MethodPost, "/", http.NoBody))
+
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	assert.Empty(t, rec.Header().Get(HeaderRateLimitLimit))
+}
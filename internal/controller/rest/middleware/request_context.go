@@ -0,0 +1,33 @@
+package middleware
+
+import (
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/reqctx"
+)
+
+const maxRequestIDLen = 128
+
+// RequestContext copies request metadata into the request context, where
+// use cases and loggers read it through reqctx. The request ID is taken from
+// the X-Request-ID header set by a client or proxy, or generated when absent
+// or overly long, and sent back in the response. The route is the method
+// and path pattern, such as "GET /v0/user/:id".
+func RequestContext() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			id := req.Header.Get(echo.HeaderXRequestID)
+			if id == "" || len(id) > maxRequestIDLen {
+				id = uuid.NewString()
+			}
+			c.Response().Header().Set(echo.HeaderXRequestID, id)
+
+			ctx := reqctx.WithRequestID(req.Context(), id)
+			ctx = reqctx.WithRoute(ctx, req.Method+" "+c.Path())
+			c.SetRequest(req.WithContext(ctx))
+			return next(c)
+		}
+	}
+}
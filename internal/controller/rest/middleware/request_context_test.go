@@ -0,0 +1,48 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/reqctx"
+)
+
+func TestRequestContext(t *testing.T) {
+	for header, keep := range map[string]bool{
+		"req-1":                  true,
+		"":                       false,
+		strings.Repeat("x", 129): false,
+	} {
+		e := echo.New()
+		var id, route string
+		e.Use(RequestContext())
+		e.GET("/v0/user/:id", func(c echo.Context) error {
+			id = reqctx.RequestID(c.Request().Context())
+			route = reqctx.Route(c.Request().Context())
+			return c.NoContent(http.StatusNoContent)
+		})
+
+		req := httptest.NewRequest(http.MethodGet, "/v0/user/1", http.NoBody)
+		if header != "" {
+			req.Header.Set(echo.HeaderXRequestID, header)
+		}
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+
+		if keep {
+			assert.Equal(t, header, id)
+		} else {
+			_, err := uuid.Parse(id)
+			require.NoError(t, err, "generated request ID")
+		}
+		assert.Equal(t, id, rec.Header().Get(echo.HeaderXRequestID))
+		assert.Equal(t, "GET /v0/user/:id", route)
+	}
+}
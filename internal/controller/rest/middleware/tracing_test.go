@@ -0,0 +1,57 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/DeSouzaRafael/go-clean-architecture-template/infra/logger"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/controller/rest/output"
+)
+
+func TestTracing(t *testing.T) {
+	spans := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans))
+
+	var handlerSpan trace.SpanContext
+	e := echo.New()
+	e.HTTPErrorHandler = output.HTTPErrorHandler(logger.NewLogger("error"))
+	e.Use(Tracing(tp, propagation.TraceContext{}))
+	e.GET("/v0/user/:id", func(c echo.Context) error {
+		handlerSpan = trace.SpanContextFromContext(c.Request().Context())
+		return c.NoContent(http.StatusNoContent)
+	})
+	e.POST("/v0/user", func(c echo.Context) error { return echo.ErrServiceUnavailable })
+
+	req := httptest.NewRequest(http.MethodGet, "/v0/user/1", http.NoBody)
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	e.ServeHTTP(httptest.NewRecorder(), req)
+	e.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/v0/user", http.NoBody))
+
+	ended := spans.Ended()
+	require.Len(t, ended, 2)
+
+	get := ended[0]
+	assert.Equal(t, "GET /v0/user/:id", get.Name())
+	assert.Equal(t, trace.SpanKindServer, get.SpanKind())
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", get.SpanContext().TraceID().String())
+	assert.Equal(t, "00f067aa0ba902b7", get.Parent().SpanID().String())
+	assert.Equal(t, get.SpanContext().SpanID(), handlerSpan.SpanID())
+	assert.Contains(t, get.Attributes(), attribute.String("http.route", "/v0/user/:id"))
+	assert.Contains(t, get.Attributes(), attribute.Int("http.response.status_code", http.StatusNoContent))
+
+	post := ended[1]
+	assert.False(t, post.Parent().IsValid())
+	assert.Equal(t, codes.Error, post.Status().Code)
+	assert.Contains(t, post.Attributes(), attribute.Int("http.response.status_code", http.StatusServiceUnavailable))
+}
@@ -0,0 +1,25 @@
+package output
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type APIKeyOutput struct {
+	ID         uuid.UUID  `json:"id"`
+	Name       string     `json:"name" example:"acme integration"`
+	Prefix     string     `json:"prefix" example:"ak_3f9a0c12d4e5"`
+	Scopes     []string   `json:"scopes" example:"users:read"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+	CreatedBy  string     `json:"created_by" example:"admin@example.com"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// CreatedAPIKeyOutput is the only response that ever shows the key itself.
+type CreatedAPIKeyOutput struct {
+	APIKeyOutput
+	Key string `json:"key" example:"ak_3f9a0c12d4e5.q0V3c1dW..."`
+}
@@ -1,13 +1,96 @@
 package output
 
 import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/DeSouzaRafael/go-clean-architecture-template/infra/logger"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/apperror"
 	"github.com/labstack/echo/v4"
 )
 
 type ResponseError struct {
+	Code  string `json:"code" example:"not_found"`
 	Error string `json:"error" example:"message"`
 }
 
 func ErrorResponse(c echo.Context, code int, msg string) error {
-	return c.JSON(code, ResponseError{Error: msg})
+	return c.JSON(code, ResponseError{Code: statusCode(code), Error: msg})
+}
+
+// HTTPErrorHandler renders errors returned by handlers, mapping domain errors
+// to status codes. Internal errors are logged and never exposed to clients.
+func HTTPErrorHandler(l logger.Interface) echo.HTTPErrorHandler {
+	return func(err error, c echo.Context) {
+		if c.Response().Committed {
+			return
+		}
+
+		l := l.WithContext(c.Request().Context())
+		status, body := errorResponse(err)
+		if status >= http.StatusInternalServerError {
+			l.Error("http - error handler", logger.Err(err))
+		}
+
+		if c.Request().Method == http.MethodHead {
+			err = c.NoContent(status)
+		} else {
+			err = c.JSON(status, body)
+		}
+		if err != nil {
+			l.Error("http - error handler - write response", logger.Err(err))
+		}
+	}
+}
+
+func errorResponse(err error) (int, ResponseError) {
+	if e, ok := apperror.As(err); ok {
+		status := StatusCode(e.Kind)
+		if status == http.StatusInternalServerError {
+			return status, internalError()
+		}
+		return status, ResponseError{Code: e.ErrorCode(), Error: e.Message}
+	}
+
+	if he, ok := err.(*echo.HTTPError); ok {
+		if he.Code >= http.StatusInternalServerError {
+			return he.Code, ResponseError{Code: statusCode(he.Code), Error: strings.ToLower(http.StatusText(he.Code))}
+		}
+		return he.Code, ResponseError{Code: statusCode(he.Code), Error: fmt.Sprint(he.Message)}
+	}
+
+	return http.StatusInternalServerError, internalError()
+}
+
+// StatusCode maps a domain error kind to its HTTP status code.
+func StatusCode(kind apperror.Kind) int {
+	switch kind {
+	case apperror.KindNotFound:
+		return http.StatusNotFound
+	case apperror.KindConflict:
+		return http.StatusConflict
+	case apperror.KindValidation:
+		return http.StatusBadRequest
+	case apperror.KindUnauthorized:
+		return http.StatusUnauthorized
+	case apperror.KindForbidden:
+		return http.StatusForbidden
+	case apperror.KindRateLimited:
+		return http.StatusTooManyRequests
+	case apperror.KindPreconditionFailed:
+		return http.StatusPreconditionFailed
+	case apperror.KindUnprocessable:
+		return http.StatusUnprocessableEntity
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func internalError() ResponseError {
+	return ResponseError{Code: apperror.KindInternal.String(), Error: "internal server error"}
+}
+
+func statusCode(status int) string {
+	return strings.ReplaceAll(strings.ToLower(http.StatusText(status)), " ", "_")
 }
@@ -0,0 +1,42 @@
+package output
+
+import (
+	"time"
+
+	"github.com/DeSouzaRafael/go-clean-architecture-template/infra/health"
+)
+
+type HealthOutput struct {
+	Status string              `json:"status" example:"ok"`
+	Checks []HealthCheckOutput `json:"checks,omitempty"`
+}
+
+type HealthCheckOutput struct {
+	Name       string    `json:"name" example:"postgres"`
+	Status     string    `json:"status" example:"ok"`
+	Critical   bool      `json:"critical"`
+	Error      string    `json:"error,omitempty"`
+	DurationMs float64   `json:"duration_ms" example:"1.25"`
+	CheckedAt  time.Time `json:"checked_at"`
+}
+
+// NewHealthOutput returns the status of report, with the result of every
+// check when verbose.
+func NewHealthOutput(report health.Report, verbose bool) HealthOutput {
+	out := HealthOutput{Status: string(report.Status)}
+	if !verbose {
+		return out
+	}
+	out.Checks = make([]HealthCheckOutput, len(report.Checks))
+	for i, res := range report.Checks {
+		out.Checks[i] = HealthCheckOutput{
+			Name:       res.Name,
+			Status:     string(res.Status),
+			Critical:   res.Critical,
+			Error:      res.Error,
+			DurationMs: float64(res.Duration.Microseconds()) / 1000,
+			CheckedAt:  res.CheckedAt,
+		}
+	}
+	return out
+}
@@ -0,0 +1,11 @@
+package output
+
+import "time"
+
+// LogLevelOutput shows the current log level and the configured one, which
+// it reverts to at revert_at while a temporary level is active.
+type LogLevelOutput struct {
+	Level      string     `json:"level" example:"debug"`
+	Configured string     `json:"configured" example:"info"`
+	RevertAt   *time.Time `json:"revert_at,omitempty"`
+}
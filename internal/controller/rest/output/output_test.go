@@ -1,10 +1,14 @@
 package output
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
+	"github.com/DeSouzaRafael/go-clean-architecture-template/infra/logger"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/apperror"
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/assert"
@@ -30,3 +34,48 @@ func TestUserOutput_Fields(t *testing.T) {
 	assert.Equal(t, "Jane", out.Name)
 	assert.Equal(t, "+5511999999999", out.Phone)
 }
+
+func TestHTTPErrorHandler(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		status   int
+		expected string
+	}{
+		{"not found", apperror.NotFound("user not found", nil), http.StatusNotFound, `{"code":"not_found","error":"user not found"}`},
+		{"conflict", apperror.Wrap(nil, apperror.KindConflict, "user_conflict", "user already exists"), http.StatusConflict, `{"code":"user_conflict","error":"user already exists"}`},
+		{"validation", apperror.Validation("invalid filter", nil), http.StatusBadRequest, `{"code":"validation_failed","error":"invalid filter"}`},
+		{"unauthorized", apperror.ErrUnauthorized, http.StatusUnauthorized, `{"code":"unauthorized","error":"unauthorized"}`},
+		{"forbidden", apperror.ErrForbidden, http.StatusForbidden, `{"code":"forbidden","error":"forbidden"}`},
+		{"rate limited", apperror.ErrRateLimited, http.StatusTooManyRequests, `{"code":"rate_limited","error":"too many requests"}`},
+		{"precondition failed", fmt.Errorf("UpdateUser: %w", apperror.PreconditionFailed("version mismatch", nil)), http.StatusPreconditionFailed, `{"code":"precondition_failed","error":"version mismatch"}`},
+		{"echo error", echo.NewHTTPError(http.StatusMethodNotAllowed, "method not allowed"), http.StatusMethodNotAllowed, `{"code":"method_not_allowed","error":"method not allowed"}`},
+		{"internal", errors.New(`pq: relation "user" does not exist`), http.StatusInternalServerError, `{"code":"internal_error","error":"internal server error"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/", http.NoBody)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			HTTPErrorHandler(logger.NewLogger("info"))(tt.err, c)
+
+			assert.Equal(t, tt.status, rec.Code)
+			assert.JSONEq(t, tt.expected, rec.Body.String())
+		})
+	}
+}
+
+func TestHTTPErrorHandler_Head(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodHead, "/", http.NoBody)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	HTTPErrorHandler(logger.NewLogger("info"))(apperror.ErrNotFound, c)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Empty(t, rec.Body.String())
+}
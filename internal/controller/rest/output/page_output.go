@@ -0,0 +1,24 @@
+package output
+
+type PageMeta struct {
+	Limit      int    `json:"limit" example:"20"`
+	Offset     *int   `json:"offset,omitempty" example:"0"`
+	Total      *int64 `json:"total,omitempty" example:"120"`
+	NextCursor string `json:"next_cursor,omitempty"`
+	HasMore    bool   `json:"has_more"`
+}
+
+type UserListOutput struct {
+	Data []UserOutput `json:"data"`
+	Meta PageMeta     `json:"meta"`
+}
+
+type UserHistoryOutput struct {
+	Data []AuditEventOutput `json:"data"`
+	Meta PageMeta           `json:"meta"`
+}
+
+type DeletedUserListOutput struct {
+	Data []DeletedUserOutput `json:"data"`
+	Meta PageMeta            `json:"meta"`
+}
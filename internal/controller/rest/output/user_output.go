@@ -1,9 +1,39 @@
 package output
 
-import "github.com/google/uuid"
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
 
 type UserOutput struct {
 	ID    uuid.UUID `json:"id"`
 	Name  string    `json:"name"`
 	Phone string    `json:"phone"`
 }
+
+type DeletedUserOutput struct {
+	ID        uuid.UUID `json:"id"`
+	Name      string    `json:"name"`
+	Phone     string    `json:"phone"`
+	DeletedAt time.Time `json:"deleted_at"`
+}
+
+type PurgeOutput struct {
+	Purged int64 `json:"purged" example:"12"`
+}
+
+type FieldChangeOutput struct {
+	Field string `json:"field" example:"name"`
+	Old   any    `json:"old"`
+	New   any    `json:"new"`
+}
+
+type AuditEventOutput struct {
+	ID        uuid.UUID           `json:"id"`
+	Action    string              `json:"action" example:"update"`
+	Actor     string              `json:"actor" example:"anonymous"`
+	RequestID string              `json:"request_id,omitempty"`
+	Changes   []FieldChangeOutput `json:"changes"`
+	CreatedAt time.Time           `json:"created_at"`
+}
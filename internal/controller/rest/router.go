@@ -2,16 +2,67 @@ package rest
 
 import (
 	"net/http"
+	"strings"
+	"sync/atomic"
 
+	"github.com/DeSouzaRafael/go-clean-architecture-template/infra/health"
 	"github.com/DeSouzaRafael/go-clean-architecture-template/infra/logger"
 	"github.com/DeSouzaRafael/go-clean-architecture-template/infra/validator"
+	restMiddleware "github.com/DeSouzaRafael/go-clean-architecture-template/internal/controller/rest/middleware"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/controller/rest/output"
 	v0 "github.com/DeSouzaRafael/go-clean-architecture-template/internal/controller/rest/routers/v0"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/entity"
 	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/usecase"
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
 	echoSwagger "github.com/swaggo/echo-swagger"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
 )
 
+// Rate limit classes: reads and writes of a client are limited separately,
+// in each route group. IP limits each IP address before authentication, so
+// that credentials cannot be guessed at full speed.
+const (
+	RateLimitRead  = "read"
+	RateLimitWrite = "write"
+	RateLimitIP    = "ip"
+)
+
+// rateLimitRoutes are the route groups whose clients get buckets of their
+// own, by path prefix. Other routes share rateLimitOther.
+var rateLimitRoutes = []struct{ prefix, group string }{
+	{"/v0/user", "users"},
+	{"/v0/api-key", "api_keys"},
+	{"/v0/admin", "admin"},
+}
+
+const rateLimitOther = "other"
+
+// RateLimits configures rate limiting. A nil Store disables it, and so does
+// a zero limit for its class.
+type RateLimits struct {
+	Store restMiddleware.RateLimitStore
+	Read  entity.RateLimit
+	Write entity.RateLimit
+	IP    entity.RateLimit
+}
+
+// Router holds the settings of the routes that may change while they are
+// served, such as on a configuration reload.
+type Router struct {
+	env    string
+	cors   atomic.Pointer[echo.MiddlewareFunc]
+	limits atomic.Pointer[map[string]entity.RateLimit]
+}
+
+// Metrics instruments the router: HTTP records every request, and Handler,
+// when not nil, is served on /metrics without authentication.
+type Metrics struct {
+	HTTP    restMiddleware.HTTPMetrics
+	Handler http.Handler
+}
+
 // NewRouter -.
 // Swagger spec:
 // @title       Go Clean Architecture Template API
@@ -19,29 +70,170 @@ import (
 // @version     1.0
 // @host        localhost:8080
 // @BasePath
-func NewRouter(h *echo.Echo, l logger.Interface, v *validator.Validator, uc usecase.UseCases, env string) {
-	h.Use(middleware.CORSWithConfig(corsConfig(env)))
+// @securityDefinitions.apikey BearerAuth
+// @in                         header
+// @name                       Authorization
+// @description                "Bearer " followed by a JWT
+// @securityDefinitions.apikey ApiKeyAuth
+// @in                         header
+// @name                       X-API-Key
+// @description                API key issued with POST /v0/api-key
+//
+// Every route but /livez, /readyz, /metrics and /docs requires a bearer token
+// verified by tokens or an API key. A nil tokens disables authentication.
+// Every IP address is limited before authentication, then every client in
+// each route group: authenticated clients, or IP addresses when
+// authentication is off.
+// When tp is not nil, every request is traced, continuing the trace of its
+// traceparent header. /livez and /readyz serve the reports of probes, and
+// always succeed when it is nil. CORS allows the default origins of env
+// until SetCORSOrigins is called.
+func NewRouter(h *echo.Echo, l logger.Interface, v *validator.Validator, uc usecase.UseCases, env string, tokens restMiddleware.TokenVerifier, limits RateLimits, metrics Metrics, tp trace.TracerProvider, probes Probes) *Router {
+	h.HTTPErrorHandler = output.HTTPErrorHandler(l)
+
+	r := &Router{env: env}
+	r.SetCORSOrigins(nil)
+	r.SetRateLimits(limits.Read, limits.Write, limits.IP)
+
+	h.Use(r.corsMiddleware)
+	h.Use(restMiddleware.RequestContext())
+	// The outer Recover turns panics of the tracing and metrics middleware
+	// into 500s; the inner one runs inside them, so that they record the 500
+	// of a panicking handler.
+	h.Use(middleware.Recover())
+	if tp != nil {
+		h.Use(restMiddleware.Tracing(tp, otel.GetTextMapPropagator()))
+	}
+	if metrics.HTTP != nil {
+		h.Use(restMiddleware.Metrics(metrics.HTTP))
+	}
 	h.Use(middleware.Recover())
+	if limits.Store != nil {
+		h.Use(restMiddleware.RateLimit(limits.Store, r.rateLimits, ipRateLimitGroup, l))
+	}
+	if tokens != nil {
+		h.Use(restMiddleware.APIKey(uc.APIKeyUseCase(), isPublic))
+		h.Use(restMiddleware.Authenticate(tokens, isPublic))
+	}
+	if limits.Store != nil {
+		h.Use(restMiddleware.RateLimit(limits.Store, r.rateLimits, rateLimitGroup, l))
+	}
+	h.Use(restMiddleware.Idempotency(uc.IdempotencyUseCase(), l))
+
+	if probes == nil {
+		probes = health.NewRegistry()
+	}
+	h.GET("/livez", probe(probes.Live))
+	h.GET("/readyz", probe(probes.Ready))
 
-	h.GET("/health", func(c echo.Context) error {
-		return c.JSON(http.StatusOK, map[string]string{"status": "ok"})
-	})
+	if metrics.Handler != nil {
+		h.GET("/metrics", echo.WrapHandler(metrics.Handler))
+	}
 
 	h.GET("/docs/*", echoSwagger.WrapHandler)
 
 	v0.NewUserRoutes(h, l, v, uc.UserUseCase())
+	v0.NewAPIKeyRoutes(h, l, v, uc.APIKeyUseCase())
+	v0.NewLogLevelRoutes(h, l, v, uc.LogLevelUseCase())
+
+	return r
+}
+
+// SetCORSOrigins changes the origins allowed by CORS. Empty origins restore
+// the default ones of the environment.
+func (r *Router) SetCORSOrigins(origins []string) {
+	mw := middleware.CORSWithConfig(corsConfig(r.env, origins))
+	r.cors.Store(&mw)
+}
+
+// SetRateLimits changes the limits of reads, writes and IP addresses. It has
+// no effect when rate limiting is disabled.
+func (r *Router) SetRateLimits(read, write, ip entity.RateLimit) {
+	groups := RateLimits{Read: read, Write: write, IP: ip}.groups()
+	r.limits.Store(&groups)
+}
+
+func (r *Router) corsMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		return (*r.cors.Load())(next)(c)
+	}
+}
+
+func (r *Router) rateLimits() map[string]entity.RateLimit {
+	return *r.limits.Load()
+}
+
+// isPublic reports whether the route is served without authentication.
+func isPublic(c echo.Context) bool {
+	switch c.Path() {
+	case "/livez", "/readyz", "/metrics":
+		return true
+	}
+	return strings.HasPrefix(c.Path(), "/docs/")
+}
+
+// rateLimitGroup returns the rate limit group of a request: its route group
+// and class, such as "users:write". Public routes are not limited.
+func rateLimitGroup(c echo.Context) string {
+	if isPublic(c) {
+		return ""
+	}
+	route := rateLimitOther
+	for _, rr := range rateLimitRoutes {
+		if c.Path() == rr.prefix || strings.HasPrefix(c.Path(), rr.prefix+"/") {
+			route = rr.group
+			break
+		}
+	}
+	switch c.Request().Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return route + ":" + RateLimitRead
+	default:
+		return route + ":" + RateLimitWrite
+	}
+}
+
+// ipRateLimitGroup puts every request but those to public routes in the IP
+// group. It runs before authentication, so clients are told apart by IP.
+func ipRateLimitGroup(c echo.Context) string {
+	if isPublic(c) {
+		return ""
+	}
+	return RateLimitIP
+}
+
+func (r RateLimits) groups() map[string]entity.RateLimit {
+	groups := make(map[string]entity.RateLimit, 2*len(rateLimitRoutes)+3)
+	add := func(name string, limit entity.RateLimit) {
+		if limit.Rate > 0 && limit.Burst > 0 {
+			groups[name] = limit
+		}
+	}
+	addRoute := func(route string) {
+		add(route+":"+RateLimitRead, r.Read)
+		add(route+":"+RateLimitWrite, r.Write)
+	}
+	add(RateLimitIP, r.IP)
+	for _, rr := range rateLimitRoutes {
+		addRoute(rr.group)
+	}
+	addRoute(rateLimitOther)
+	return groups
 }
 
-func corsConfig(env string) middleware.CORSConfig {
+func corsConfig(env string, origins []string) middleware.CORSConfig {
 	cc := middleware.CORSConfig{
-		AllowHeaders:     []string{echo.HeaderAccept, echo.HeaderAcceptEncoding, echo.HeaderAuthorization, echo.HeaderContentLength, echo.HeaderContentType, echo.HeaderOrigin, echo.HeaderXCSRFToken},
+		AllowHeaders:     []string{echo.HeaderAccept, echo.HeaderAcceptEncoding, echo.HeaderAuthorization, echo.HeaderContentLength, echo.HeaderContentType, echo.HeaderOrigin, echo.HeaderXCSRFToken, echo.HeaderXRequestID, "If-Match", restMiddleware.HeaderIdempotencyKey, restMiddleware.HeaderAPIKey, "traceparent", "tracestate"},
 		AllowCredentials: true,
-		ExposeHeaders:    []string{echo.HeaderAccept, echo.HeaderAcceptEncoding, echo.HeaderAuthorization, echo.HeaderContentLength, echo.HeaderContentType, echo.HeaderOrigin, echo.HeaderXCSRFToken},
+		ExposeHeaders:    []string{echo.HeaderAccept, echo.HeaderAcceptEncoding, echo.HeaderAuthorization, echo.HeaderContentLength, echo.HeaderContentType, echo.HeaderOrigin, echo.HeaderXCSRFToken, echo.HeaderXRequestID, "ETag", "Link", restMiddleware.HeaderIdempotentReplayed, restMiddleware.HeaderRateLimitLimit, restMiddleware.HeaderRateLimitRemaining, restMiddleware.HeaderRateLimitReset, echo.HeaderRetryAfter},
 	}
 
-	if env == "prd" {
+	switch {
+	case len(origins) > 0:
+		cc.AllowOrigins = origins
+	case env == "prd":
 		cc.AllowOrigins = []string{"https://*.your.domain.com"}
-	} else {
+	default:
 		cc.AllowOrigins = []string{"*"}
 	}
 
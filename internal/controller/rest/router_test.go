@@ -1,19 +1,32 @@
 package rest
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/DeSouzaRafael/go-clean-architecture-template/infra/health"
 	"github.com/DeSouzaRafael/go-clean-architecture-template/infra/logger"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/infra/metrics"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/infra/ratelimit"
 	"github.com/DeSouzaRafael/go-clean-architecture-template/infra/validator"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/apperror"
+	restMiddleware "github.com/DeSouzaRafael/go-clean-architecture-template/internal/controller/rest/middleware"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/controller/rest/output"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/entity"
 	"github.com/DeSouzaRafael/go-clean-architecture-template/mocks"
 	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 )
 
-func TestNewRouter_HealthEndpoint(t *testing.T) {
+func TestNewRouter_HealthEndpoints(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
@@ -23,25 +36,310 @@ func TestNewRouter_HealthEndpoint(t *testing.T) {
 	uc := mocks.NewMockUseCases(ctrl)
 	mockUser := mocks.NewMockUser(ctrl)
 	uc.EXPECT().UserUseCase().Return(mockUser)
+	uc.EXPECT().IdempotencyUseCase().Return(mocks.NewMockIdempotency(ctrl))
+	uc.EXPECT().APIKeyUseCase().Return(mocks.NewMockAPIKey(ctrl)).AnyTimes()
+	uc.EXPECT().LogLevelUseCase().Return(mocks.NewMockLogLevel(ctrl))
 
-	NewRouter(e, l, v, uc, "dev")
+	probes := health.NewRegistry()
+	probes.Register("postgres", func(context.Context) error { return nil }, health.CheckOptions{Critical: true})
+	probes.Register("cache", func(context.Context) error { return errors.New("boom") }, health.CheckOptions{})
+	NewRouter(e, l, v, uc, "dev", nil, RateLimits{}, Metrics{}, nil, probes)
 
-	req := httptest.NewRequest(http.MethodGet, "/health", http.NoBody)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/livez", http.NoBody))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"status":"ok"}`, rec.Body.String())
+
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", http.NoBody))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"status":"degraded"}`, rec.Body.String())
+
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz?verbose", http.NoBody))
+	var report output.HealthOutput
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &report))
+	require.Len(t, report.Checks, 2)
+	assert.Equal(t, "postgres", report.Checks[0].Name)
+	assert.Equal(t, "ok", report.Checks[0].Status)
+	assert.True(t, report.Checks[0].Critical)
+	assert.Equal(t, "boom", report.Checks[1].Error)
+
+	probes.Shutdown()
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", http.NoBody))
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	assert.JSONEq(t, `{"status":"failing"}`, rec.Body.String())
+}
+
+type rejectAll struct{}
+
+func (rejectAll) Verify(string) (entity.Principal, error) {
+	return entity.Principal{}, apperror.Unauthorized("invalid token", nil)
+}
+
+func TestNewRouter_Authentication(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	e := echo.New()
+	uc := mocks.NewMockUseCases(ctrl)
+	uc.EXPECT().UserUseCase().Return(mocks.NewMockUser(ctrl))
+	uc.EXPECT().IdempotencyUseCase().Return(mocks.NewMockIdempotency(ctrl))
+	uc.EXPECT().APIKeyUseCase().Return(mocks.NewMockAPIKey(ctrl)).AnyTimes()
+	uc.EXPECT().LogLevelUseCase().Return(mocks.NewMockLogLevel(ctrl))
+
+	NewRouter(e, logger.NewLogger("error"), validator.NewValidator(), uc, "dev", rejectAll{}, RateLimits{}, Metrics{}, nil, nil)
+
+	for path, want := range map[string]int{
+		"/livez":   http.StatusOK,
+		"/readyz":  http.StatusOK,
+		"/v0/user": http.StatusUnauthorized,
+	} {
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, http.NoBody))
+		assert.Equal(t, want, rec.Code, path)
+	}
+}
+
+func TestNewRouter_RateLimit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	e := echo.New()
+	uc := mocks.NewMockUseCases(ctrl)
+	uc.EXPECT().UserUseCase().Return(mocks.NewMockUser(ctrl))
+	uc.EXPECT().IdempotencyUseCase().Return(mocks.NewMockIdempotency(ctrl))
+	uc.EXPECT().APIKeyUseCase().Return(mocks.NewMockAPIKey(ctrl)).AnyTimes()
+	uc.EXPECT().LogLevelUseCase().Return(mocks.NewMockLogLevel(ctrl))
+
+	r := NewRouter(e, logger.NewLogger("error"), validator.NewValidator(), uc, "dev", nil, RateLimits{
+		Store: ratelimit.NewMemoryStore(),
+		Write: entity.RateLimit{Rate: 1, Burst: 1},
+	}, Metrics{}, nil, nil)
+
+	post := func() *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/v0/user", strings.NewReader(`{}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+
+	assert.Equal(t, http.StatusBadRequest, post().Code)
+	rec := post()
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	assert.Equal(t, "1", rec.Header().Get(echo.HeaderRetryAfter))
+
+	for range 3 {
+		rec = httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", http.NoBody))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Empty(t, rec.Header().Get(restMiddleware.HeaderRateLimitLimit))
+	}
+
+	r.SetRateLimits(entity.RateLimit{}, entity.RateLimit{}, entity.RateLimit{})
+	assert.Equal(t, http.StatusBadRequest, post().Code)
+}
+
+func TestNewRouter_RateLimitRouteGroups(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	e := echo.New()
+	uc := mocks.NewMockUseCases(ctrl)
+	uc.EXPECT().UserUseCase().Return(mocks.NewMockUser(ctrl))
+	uc.EXPECT().IdempotencyUseCase().Return(mocks.NewMockIdempotency(ctrl))
+	uc.EXPECT().APIKeyUseCase().Return(mocks.NewMockAPIKey(ctrl)).AnyTimes()
+	uc.EXPECT().LogLevelUseCase().Return(mocks.NewMockLogLevel(ctrl))
+
+	NewRouter(e, logger.NewLogger("error"), validator.NewValidator(), uc, "dev", nil, RateLimits{
+		Store: ratelimit.NewMemoryStore(),
+		Write: entity.RateLimit{Rate: 1, Burst: 1},
+	}, Metrics{}, nil, nil)
+
+	post := func(path string) int {
+		req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(`{}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	assert.Equal(t, http.StatusBadRequest, post("/v0/user"))
+	assert.Equal(t, http.StatusTooManyRequests, post("/v0/user"))
+	// API keys are another route group, with a bucket of its own.
+	assert.Equal(t, http.StatusBadRequest, post("/v0/api-key"))
+	assert.Equal(t, http.StatusTooManyRequests, post("/v0/api-key"))
+}
+
+func TestNewRouter_RateLimitBeforeAuthentication(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	e := echo.New()
+	uc := mocks.NewMockUseCases(ctrl)
+	uc.EXPECT().UserUseCase().Return(mocks.NewMockUser(ctrl))
+	uc.EXPECT().IdempotencyUseCase().Return(mocks.NewMockIdempotency(ctrl))
+	uc.EXPECT().APIKeyUseCase().Return(mocks.NewMockAPIKey(ctrl)).AnyTimes()
+	uc.EXPECT().LogLevelUseCase().Return(mocks.NewMockLogLevel(ctrl))
+
+	NewRouter(e, logger.NewLogger("error"), validator.NewValidator(), uc, "dev", rejectAll{}, RateLimits{
+		Store: ratelimit.NewMemoryStore(),
+		IP:    entity.RateLimit{Rate: 1, Burst: 2},
+	}, Metrics{}, nil, nil)
+
+	get := func(ip string) int {
+		req := httptest.NewRequest(http.MethodGet, "/v0/user", http.NoBody)
+		req.Header.Set(echo.HeaderAuthorization, "Bearer guess")
+		req.Header.Set(echo.HeaderXRealIP, ip)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	assert.Equal(t, http.StatusUnauthorized, get("10.0.0.1"))
+	assert.Equal(t, http.StatusUnauthorized, get("10.0.0.1"))
+	assert.Equal(t, http.StatusTooManyRequests, get("10.0.0.1"))
+	assert.Equal(t, http.StatusUnauthorized, get("10.0.0.2"))
+}
+
+func TestRouter_SetCORSOrigins(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	e := echo.New()
+	uc := mocks.NewMockUseCases(ctrl)
+	uc.EXPECT().UserUseCase().Return(mocks.NewMockUser(ctrl))
+	uc.EXPECT().IdempotencyUseCase().Return(mocks.NewMockIdempotency(ctrl))
+	uc.EXPECT().APIKeyUseCase().Return(mocks.NewMockAPIKey(ctrl)).AnyTimes()
+	uc.EXPECT().LogLevelUseCase().Return(mocks.NewMockLogLevel(ctrl))
+
+	r := NewRouter(e, logger.NewLogger("error"), validator.NewValidator(), uc, "dev", nil, RateLimits{}, Metrics{}, nil, nil)
+
+	allowed := func(origin string) string {
+		req := httptest.NewRequest(http.MethodGet, "/livez", http.NoBody)
+		req.Header.Set(echo.HeaderOrigin, origin)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec.Header().Get(echo.HeaderAccessControlAllowOrigin)
+	}
+
+	assert.Equal(t, "*", allowed("https://evil.example.org"))
+
+	r.SetCORSOrigins([]string{"https://*.example.com"})
+	assert.Equal(t, "https://app.example.com", allowed("https://app.example.com"))
+	assert.Empty(t, allowed("https://evil.example.org"))
+
+	r.SetCORSOrigins(nil)
+	assert.Equal(t, "*", allowed("https://evil.example.org"))
+}
+
+func TestNewRouter_CORSPreflight(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	e := echo.New()
+	uc := mocks.NewMockUseCases(ctrl)
+	uc.EXPECT().UserUseCase().Return(mocks.NewMockUser(ctrl))
+	uc.EXPECT().IdempotencyUseCase().Return(mocks.NewMockIdempotency(ctrl))
+	uc.EXPECT().APIKeyUseCase().Return(mocks.NewMockAPIKey(ctrl)).AnyTimes()
+	uc.EXPECT().LogLevelUseCase().Return(mocks.NewMockLogLevel(ctrl))
+	NewRouter(e, logger.NewLogger("error"), validator.NewValidator(), uc, "dev", rejectAll{}, RateLimits{}, Metrics{}, nil, nil)
+
+	req := httptest.NewRequest(http.MethodOptions, "/v0/user/00000000-0000-0000-0000-000000000001", http.NoBody)
+	req.Header.Set(echo.HeaderOrigin, "https://app.example.com")
+	req.Header.Set(echo.HeaderAccessControlRequestMethod, http.MethodPatch)
+	req.Header.Set(echo.HeaderAccessControlRequestHeaders, "if-match, content-type")
 	rec := httptest.NewRecorder()
 	e.ServeHTTP(rec, req)
 
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	assert.Contains(t, rec.Header().Get(echo.HeaderAccessControlAllowHeaders), "If-Match")
+	assert.Contains(t, rec.Header().Get(echo.HeaderAccessControlAllowMethods), http.MethodPatch)
+}
+
+func TestNewRouter_Metrics(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	e := echo.New()
+	uc := mocks.NewMockUseCases(ctrl)
+	uc.EXPECT().UserUseCase().Return(mocks.NewMockUser(ctrl))
+	uc.EXPECT().IdempotencyUseCase().Return(mocks.NewMockIdempotency(ctrl))
+	uc.EXPECT().APIKeyUseCase().Return(mocks.NewMockAPIKey(ctrl)).AnyTimes()
+	uc.EXPECT().LogLevelUseCase().Return(mocks.NewMockLogLevel(ctrl))
+
+	m := metrics.New()
+	NewRouter(e, logger.NewLogger("error"), validator.NewValidator(), uc, "dev", rejectAll{}, RateLimits{}, Metrics{HTTP: m, Handler: m.Handler()}, nil, nil)
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v0/user", http.NoBody))
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", http.NoBody))
 	assert.Equal(t, http.StatusOK, rec.Code)
-	assert.Contains(t, rec.Body.String(), `"status":"ok"`)
+	assert.Contains(t, rec.Body.String(), `app_http_requests_total{method="GET",route="/v0/user",status="401"} 1`)
+}
+
+func TestNewRouter_MetricsRecordPanics(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	e := echo.New()
+	uc := mocks.NewMockUseCases(ctrl)
+	uc.EXPECT().UserUseCase().Return(mocks.NewMockUser(ctrl))
+	uc.EXPECT().IdempotencyUseCase().Return(mocks.NewMockIdempotency(ctrl))
+	uc.EXPECT().APIKeyUseCase().Return(mocks.NewMockAPIKey(ctrl)).AnyTimes()
+	uc.EXPECT().LogLevelUseCase().Return(mocks.NewMockLogLevel(ctrl))
+
+	m := metrics.New()
+	NewRouter(e, logger.NewLogger("error"), validator.NewValidator(), uc, "dev", nil, RateLimits{}, Metrics{HTTP: m, Handler: m.Handler()}, nil, nil)
+	e.GET("/panic", func(echo.Context) error { panic("boom") })
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/panic", http.NoBody))
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", http.NoBody))
+	assert.Contains(t, rec.Body.String(), `app_http_requests_total{method="GET",route="/panic",status="500"} 1`)
+}
+
+type panickingHTTPMetrics struct{}
+
+func (panickingHTTPMetrics) ObserveHTTP(string, string, int, time.Duration) { panic("boom") }
+
+func TestNewRouter_RecoverMiddlewarePanics(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	e := echo.New()
+	uc := mocks.NewMockUseCases(ctrl)
+	uc.EXPECT().UserUseCase().Return(mocks.NewMockUser(ctrl))
+	uc.EXPECT().IdempotencyUseCase().Return(mocks.NewMockIdempotency(ctrl))
+	uc.EXPECT().APIKeyUseCase().Return(mocks.NewMockAPIKey(ctrl)).AnyTimes()
+	uc.EXPECT().LogLevelUseCase().Return(mocks.NewMockLogLevel(ctrl))
+
+	NewRouter(e, logger.NewLogger("error"), validator.NewValidator(), uc, "dev", nil, RateLimits{}, Metrics{HTTP: panickingHTTPMetrics{}}, nil, nil)
+	e.GET("/ok", func(c echo.Context) error { return c.NoContent(http.StatusNoContent) })
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/ok", http.NoBody)
+	require.NotPanics(t, func() { e.ServeHTTP(rec, req) })
+	assert.NotEmpty(t, rec.Header().Get(echo.HeaderXRequestID))
 }
 
 func TestCorsConfig_Production(t *testing.T) {
-	cc := corsConfig("prd")
+	cc := corsConfig("prd", nil)
 	assert.Equal(t, []string{"https://*.your.domain.com"}, cc.AllowOrigins)
 }
 
+func TestCorsConfig_Origins(t *testing.T) {
+	cc := corsConfig("prd", []string{"https://app.example.com"})
+	assert.Equal(t, []string{"https://app.example.com"}, cc.AllowOrigins)
+}
+
+func TestCorsConfig_ExposeHeaders(t *testing.T) {
+	cc := corsConfig("dev", nil)
+	assert.Subset(t, cc.ExposeHeaders, []string{"ETag", "Link", echo.HeaderXRequestID})
+}
+
 func TestCorsConfig_NonProduction(t *testing.T) {
 	for _, env := range []string{"dev", "local", ""} {
-		cc := corsConfig(env)
+		cc := corsConfig(env, nil)
 		assert.Equal(t, []string{"*"}, cc.AllowOrigins)
 	}
 }
@@ -0,0 +1,154 @@
+package v0
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+
+	"github.com/DeSouzaRafael/go-clean-architecture-template/infra/logger"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/infra/validator"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/apperror"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/controller/rest/input"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/controller/rest/output"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/entity"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/usecase"
+)
+
+type apiKeyRoutes struct {
+	usecase   usecase.APIKey
+	logger    logger.Interface
+	validator *validator.Validator
+}
+
+func NewAPIKeyRoutes(handler *echo.Echo, l logger.Interface, v *validator.Validator, uc usecase.APIKey) {
+
+	ar := &apiKeyRoutes{uc, l, v}
+
+	g := handler.Group("/v0/api-key")
+	g.GET("", ar.list)
+	g.POST("", ar.create)
+	g.DELETE("/:id", ar.revoke)
+}
+
+// @Summary     Create API Key
+// @Description Issues an API key for a machine client, sent back in the X-API-Key header. The key is only
+// @Description returned by this call: only a hash of it is stored. Scopes: users:read, users:write, users:delete.
+// @ID          createAPIKey
+// @Tags        api-keys
+// @Accept      json
+// @Produce     json
+// @Param       key  body  input.APIKeyInput  true  "Name, scopes and optional expiry"
+// @Success     200  {object} output.CreatedAPIKeyOutput  "Returns the key, shown this one time"
+// @Failure     400  {object} output.ResponseError  "Invalid name, scopes or expiry"
+// @Failure     401  {object} output.ResponseError  "Missing or invalid bearer token"
+// @Failure     403  {object} output.ResponseError  "Not allowed for the caller's roles"
+// @Failure     429  {object} output.ResponseError  "Rate limit exceeded, retry after Retry-After seconds"
+// @Header      429  {integer} Retry-After  "Seconds until the request may be retried"
+// @Failure     500  {object} output.ResponseError  "Internal server error"
+// @Security    BearerAuth
+// @Router      /v0/api-key [post]
+func (ar *apiKeyRoutes) create(c echo.Context) error {
+
+	var input input.APIKeyInput
+	if err := c.Bind(&input); err != nil {
+		ar.logger.WithContext(c.Request().Context()).Debug("http - v0 - createAPIKey", logger.Err(err))
+		return apperror.Validation("invalid request body", err)
+	}
+
+	if err := input.Validate(ar.validator); err != nil {
+		ar.logger.WithContext(c.Request().Context()).Debug("http - v0 - createAPIKey validation", logger.Err(err))
+		return apperror.Validation("invalid request data: "+err.Error(), nil)
+	}
+
+	key, secret, err := ar.usecase.CreateAPIKey(c.Request().Context(), entity.APIKey{
+		Name:      input.Name,
+		Scopes:    input.Scopes,
+		ExpiresAt: input.ExpiresAt,
+	})
+	if err != nil {
+		ar.logger.WithContext(c.Request().Context()).Error("http - v0 - createAPIKey", logger.Err(err))
+		return err
+	}
+
+	// The secret must not end up in a cache, nor in the idempotency store.
+	c.Response().Header().Set(echo.HeaderCacheControl, "no-store")
+	return c.JSON(http.StatusOK, output.CreatedAPIKeyOutput{APIKeyOutput: apiKeyOutput(key), Key: secret})
+}
+
+// @Summary     List API Keys
+// @Description Lists every API key, revoked and expired ones included. Keys themselves are never shown.
+// @ID          listAPIKeys
+// @Tags        api-keys
+// @Accept      json
+// @Produce     json
+// @Success     200  {array}  output.APIKeyOutput  "Returns the API keys"
+// @Failure     401  {object} output.ResponseError  "Missing or invalid bearer token"
+// @Failure     403  {object} output.ResponseError  "Not allowed for the caller's roles"
+// @Failure     429  {object} output.ResponseError  "Rate limit exceeded, retry after Retry-After seconds"
+// @Header      429  {integer} Retry-After  "Seconds until the request may be retried"
+// @Failure     500  {object} output.ResponseError  "Internal server error"
+// @Security    BearerAuth
+// @Router      /v0/api-key [get]
+func (ar *apiKeyRoutes) list(c echo.Context) error {
+
+	keys, err := ar.usecase.ListAPIKeys(c.Request().Context())
+	if err != nil {
+		ar.logger.WithContext(c.Request().Context()).Error("http - v0 - listAPIKeys", logger.Err(err))
+		return err
+	}
+
+	response := make([]output.APIKeyOutput, 0, len(keys))
+	for _, key := range keys {
+		response = append(response, apiKeyOutput(key))
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// @Summary     Revoke API Key
+// @Description Revokes an API key: requests presenting it are rejected from now on.
+// @ID          revokeAPIKey
+// @Tags        api-keys
+// @Accept      json
+// @Produce     json
+// @Param       id   path   string  true  "API key ID"
+// @Success     200  "API key successfully revoked"
+// @Failure     400  {object} output.ResponseError  "Invalid UUID format"
+// @Failure     401  {object} output.ResponseError  "Missing or invalid bearer token"
+// @Failure     403  {object} output.ResponseError  "Not allowed for the caller's roles"
+// @Failure     404  {object} output.ResponseError  "API key not found or already revoked"
+// @Failure     429  {object} output.ResponseError  "Rate limit exceeded, retry after Retry-After seconds"
+// @Header      429  {integer} Retry-After  "Seconds until the request may be retried"
+// @Failure     500  {object} output.ResponseError  "Internal server error"
+// @Security    BearerAuth
+// @Router      /v0/api-key/{id} [delete]
+func (ar *apiKeyRoutes) revoke(c echo.Context) error {
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		ar.logger.WithContext(c.Request().Context()).Debug("http - v0 - revokeAPIKey", logger.Err(err))
+		return apperror.Validation("invalid UUID format", err)
+	}
+
+	if err := ar.usecase.RevokeAPIKey(c.Request().Context(), id); err != nil {
+		ar.logger.WithContext(c.Request().Context()).Error("http - v0 - revokeAPIKey", logger.Err(err))
+		return err
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"message": "Successfully revoked"})
+}
+
+func apiKeyOutput(key entity.APIKey) output.APIKeyOutput {
+	return output.APIKeyOutput{
+		ID:         key.ID,
+		Name:       key.Name,
+		Prefix:     key.Prefix,
+		Scopes:     key.Scopes,
+		ExpiresAt:  key.ExpiresAt,
+		LastUsedAt: key.LastUsedAt,
+		RevokedAt:  key.RevokedAt,
+		CreatedBy:  key.CreatedBy,
+		CreatedAt:  key.CreatedAt,
+	}
+}
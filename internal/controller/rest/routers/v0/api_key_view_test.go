@@ -0,0 +1,107 @@
+package v0
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/DeSouzaRafael/go-clean-architecture-template/infra/logger"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/infra/validator"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/apperror"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/controller/rest/output"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/entity"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/mocks"
+)
+
+func newAPIKeyServer(t *testing.T) (*echo.Echo, *mocks.MockAPIKey) {
+	ctrl := gomock.NewController(t)
+	mockUseCase := mocks.NewMockAPIKey(ctrl)
+	l := logger.NewLogger("error")
+
+	e := echo.New()
+	e.HTTPErrorHandler = output.HTTPErrorHandler(l)
+	NewAPIKeyRoutes(e, l, validator.NewValidator(), mockUseCase)
+	return e, mockUseCase
+}
+
+func TestNewAPIKeyRoutes(t *testing.T) {
+	e, _ := newAPIKeyServer(t)
+
+	assertRouteExists(t, e, http.MethodGet, "/v0/api-key")
+	assertRouteExists(t, e, http.MethodPost, "/v0/api-key")
+	assertRouteExists(t, e, http.MethodDelete, "/v0/api-key/:id")
+}
+
+func TestCreateAPIKey(t *testing.T) {
+	e, mockUseCase := newAPIKeyServer(t)
+	expires := time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)
+	key := entity.APIKey{ID: uuid.New(), Name: "acme", Prefix: "ak_0123456789ab", Scopes: []string{"users:read"}, ExpiresAt: &expires, CreatedBy: "admin-1"}
+
+	mockUseCase.EXPECT().
+		CreateAPIKey(gomock.Any(), entity.APIKey{Name: "acme", Scopes: []string{"users:read"}, ExpiresAt: &expires}).
+		Return(key, "ak_0123456789ab.secret", nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/v0/api-key", strings.NewReader(`{"name":"acme","scopes":["users:read"],"expires_at":"2027-01-01T00:00:00Z"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "no-store", rec.Header().Get(echo.HeaderCacheControl))
+	assert.Contains(t, rec.Body.String(), `"key":"ak_0123456789ab.secret"`)
+	assert.Contains(t, rec.Body.String(), `"prefix":"ak_0123456789ab"`)
+	assert.NotContains(t, rec.Body.String(), "hash")
+}
+
+func TestCreateAPIKey_InvalidInput(t *testing.T) {
+	e, _ := newAPIKeyServer(t)
+
+	for _, body := range []string{`{"scopes":["users:read"]}`, `{"name":"acme","scopes":[]}`, `{`} {
+		req := httptest.NewRequest(http.MethodPost, "/v0/api-key", strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code, body)
+	}
+}
+
+func TestListAPIKeys(t *testing.T) {
+	e, mockUseCase := newAPIKeyServer(t)
+	id := uuid.New()
+
+	mockUseCase.EXPECT().ListAPIKeys(gomock.Any()).Return([]entity.APIKey{{ID: id, Name: "acme", Hash: []byte("secret-hash")}}, nil)
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v0/api-key", http.NoBody))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"id":"`+id.String()+`"`)
+	assert.NotContains(t, rec.Body.String(), `"key"`)
+}
+
+func TestRevokeAPIKey(t *testing.T) {
+	e, mockUseCase := newAPIKeyServer(t)
+	id := uuid.New()
+
+	mockUseCase.EXPECT().RevokeAPIKey(gomock.Any(), id).Return(nil)
+	mockUseCase.EXPECT().RevokeAPIKey(gomock.Any(), gomock.Not(id)).
+		Return(apperror.Wrap(nil, apperror.KindNotFound, "api_key_not_found", "API key not found or already revoked"))
+
+	for path, want := range map[string]int{
+		"/v0/api-key/" + id.String():      http.StatusOK,
+		"/v0/api-key/" + uuid.NewString(): http.StatusNotFound,
+		"/v0/api-key/not-a-uuid":          http.StatusBadRequest,
+	} {
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodDelete, path, http.NoBody))
+		assert.Equal(t, want, rec.Code, path)
+	}
+}
@@ -0,0 +1,47 @@
+package v0
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/apperror"
+)
+
+const (
+	headerETag    = "ETag"
+	headerIfMatch = "If-Match"
+)
+
+// setETag sets the strong entity tag of a resource, derived from its
+// version which changes on every write.
+func setETag(c echo.Context, version int64) {
+	c.Response().Header().Set(headerETag, `"`+strconv.FormatInt(version, 10)+`"`)
+}
+
+// bindIfMatch reads the version a write is conditioned on. The header is
+// required; "*" matches any current version and yields zero.
+func bindIfMatch(c echo.Context) (int64, error) {
+	h := strings.TrimSpace(c.Request().Header.Get(headerIfMatch))
+	switch {
+	case h == "":
+		return 0, echo.NewHTTPError(http.StatusPreconditionRequired, "If-Match header is required")
+	case h == "*":
+		return 0, nil
+	case strings.HasPrefix(h, "W/"):
+		// If-Match uses the strong comparison, a weak tag never matches.
+		return 0, apperror.PreconditionFailed("weak entity tags cannot match", nil)
+	}
+
+	if len(h) < 2 || h[0] != '"' || h[len(h)-1] != '"' || strings.Contains(h, ",") {
+		return 0, apperror.Validation("If-Match must be * or a single entity tag", nil)
+	}
+	version, err := strconv.ParseInt(h[1:len(h)-1], 10, 64)
+	if err != nil || version <= 0 {
+		// Not a tag this API ever issued.
+		return 0, apperror.PreconditionFailed("precondition failed", nil)
+	}
+	return version, nil
+}
@@ -0,0 +1,129 @@
+package v0
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/DeSouzaRafael/go-clean-architecture-template/infra/logger"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/infra/validator"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/apperror"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/controller/rest/input"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/controller/rest/output"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/entity"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/usecase"
+)
+
+type logLevelRoutes struct {
+	usecase   usecase.LogLevel
+	logger    logger.Interface
+	validator *validator.Validator
+}
+
+func NewLogLevelRoutes(handler *echo.Echo, l logger.Interface, v *validator.Validator, uc usecase.LogLevel) {
+
+	lr := &logLevelRoutes{uc, l, v}
+
+	g := handler.Group("/v0/admin/log-level")
+	g.GET("", lr.get)
+	g.PUT("", lr.set)
+	g.DELETE("", lr.reset)
+}
+
+// @Summary     Get Log Level
+// @Description Shows the current log level, the configured one, and when a temporary level reverts to it.
+// @ID          getLogLevel
+// @Tags        admin
+// @Accept      json
+// @Produce     json
+// @Success     200  {object} output.LogLevelOutput  "Returns the log level"
+// @Failure     401  {object} output.ResponseError  "Missing or invalid bearer token"
+// @Failure     403  {object} output.ResponseError  "Not allowed for the caller's roles"
+// @Failure     429  {object} output.ResponseError  "Rate limit exceeded, retry after Retry-After seconds"
+// @Header      429  {integer} Retry-After  "Seconds until the request may be retried"
+// @Failure     500  {object} output.ResponseError  "Internal server error"
+// @Security    BearerAuth
+// @Router      /v0/admin/log-level [get]
+func (lr *logLevelRoutes) get(c echo.Context) error {
+
+	level, err := lr.usecase.GetLogLevel(c.Request().Context())
+	if err != nil {
+		lr.logger.WithContext(c.Request().Context()).Error("http - v0 - getLogLevel", logger.Err(err))
+		return err
+	}
+
+	return c.JSON(http.StatusOK, logLevelOutput(level))
+}
+
+// @Summary     Set Log Level
+// @Description Changes the log level of this instance for ttl_seconds, at most a day, after which the configured
+// @Description level is restored. A new call replaces the previous temporary level.
+// @ID          setLogLevel
+// @Tags        admin
+// @Accept      json
+// @Produce     json
+// @Param       level  body  input.LogLevelInput  true  "Level and how long it lasts"
+// @Success     200  {object} output.LogLevelOutput  "Returns the new log level"
+// @Failure     400  {object} output.ResponseError  "Invalid level or ttl"
+// @Failure     401  {object} output.ResponseError  "Missing or invalid bearer token"
+// @Failure     403  {object} output.ResponseError  "Not allowed for the caller's roles"
+// @Failure     429  {object} output.ResponseError  "Rate limit exceeded, retry after Retry-After seconds"
+// @Header      429  {integer} Retry-After  "Seconds until the request may be retried"
+// @Failure     500  {object} output.ResponseError  "Internal server error"
+// @Security    BearerAuth
+// @Router      /v0/admin/log-level [put]
+func (lr *logLevelRoutes) set(c echo.Context) error {
+
+	var input input.LogLevelInput
+	if err := c.Bind(&input); err != nil {
+		lr.logger.WithContext(c.Request().Context()).Debug("http - v0 - setLogLevel", logger.Err(err))
+		return apperror.Validation("invalid request body", err)
+	}
+
+	if err := input.Validate(lr.validator); err != nil {
+		lr.logger.WithContext(c.Request().Context()).Debug("http - v0 - setLogLevel validation", logger.Err(err))
+		return apperror.Validation("invalid request data: "+err.Error(), nil)
+	}
+
+	level, err := lr.usecase.SetLogLevel(c.Request().Context(), input.Level, time.Duration(input.TTLSeconds)*time.Second)
+	if err != nil {
+		lr.logger.WithContext(c.Request().Context()).Error("http - v0 - setLogLevel", logger.Err(err))
+		return err
+	}
+
+	return c.JSON(http.StatusOK, logLevelOutput(level))
+}
+
+// @Summary     Reset Log Level
+// @Description Ends a temporary log level and restores the configured one at once.
+// @ID          resetLogLevel
+// @Tags        admin
+// @Accept      json
+// @Produce     json
+// @Success     200  {object} output.LogLevelOutput  "Returns the restored log level"
+// @Failure     401  {object} output.ResponseError  "Missing or invalid bearer token"
+// @Failure     403  {object} output.ResponseError  "Not allowed for the caller's roles"
+// @Failure     429  {object} output.ResponseError  "Rate limit exceeded, retry after Retry-After seconds"
+// @Header      429  {integer} Retry-After  "Seconds until the request may be retried"
+// @Failure     500  {object} output.ResponseError  "Internal server error"
+// @Security    BearerAuth
+// @Router      /v0/admin/log-level [delete]
+func (lr *logLevelRoutes) reset(c echo.Context) error {
+
+	level, err := lr.usecase.ResetLogLevel(c.Request().Context())
+	if err != nil {
+		lr.logger.WithContext(c.Request().Context()).Error("http - v0 - resetLogLevel", logger.Err(err))
+		return err
+	}
+
+	return c.JSON(http.StatusOK, logLevelOutput(level))
+}
+
+func logLevelOutput(level entity.LogLevel) output.LogLevelOutput {
+	return output.LogLevelOutput{
+		Level:      level.Level,
+		Configured: level.Configured,
+		RevertAt:   level.RevertAt,
+	}
+}
@@ -0,0 +1,92 @@
+package v0
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/DeSouzaRafael/go-clean-architecture-template/infra/logger"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/infra/validator"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/apperror"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/controller/rest/output"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/entity"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/mocks"
+)
+
+func newLogLevelServer(t *testing.T) (*echo.Echo, *mocks.MockLogLevel) {
+	ctrl := gomock.NewController(t)
+	mockUseCase := mocks.NewMockLogLevel(ctrl)
+	l := logger.NewLogger("error")
+
+	e := echo.New()
+	e.HTTPErrorHandler = output.HTTPErrorHandler(l)
+	NewLogLevelRoutes(e, l, validator.NewValidator(), mockUseCase)
+	return e, mockUseCase
+}
+
+func TestNewLogLevelRoutes(t *testing.T) {
+	e, _ := newLogLevelServer(t)
+
+	assertRouteExists(t, e, http.MethodGet, "/v0/admin/log-level")
+	assertRouteExists(t, e, http.MethodPut, "/v0/admin/log-level")
+	assertRouteExists(t, e, http.MethodDelete, "/v0/admin/log-level")
+}
+
+func TestSetLogLevel(t *testing.T) {
+	e, mockUseCase := newLogLevelServer(t)
+	at := time.Date(2026, 1, 1, 0, 10, 0, 0, time.UTC)
+
+	mockUseCase.EXPECT().
+		SetLogLevel(gomock.Any(), "debug", 10*time.Minute).
+		Return(entity.LogLevel{Level: "debug", Configured: "info", RevertAt: &at}, nil)
+
+	req := httptest.NewRequest(http.MethodPut, "/v0/admin/log-level", strings.NewReader(`{"level":"debug","ttl_seconds":600}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"level":"debug","configured":"info","revert_at":"2026-01-01T00:10:00Z"}`, rec.Body.String())
+}
+
+func TestSetLogLevel_InvalidInput(t *testing.T) {
+	e, _ := newLogLevelServer(t)
+
+	for _, body := range []string{`{"level":"trace","ttl_seconds":600}`, `{"level":"debug"}`, `{"level":"debug","ttl_seconds":86401}`, `{`} {
+		req := httptest.NewRequest(http.MethodPut, "/v0/admin/log-level", strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code, body)
+	}
+}
+
+func TestGetLogLevel_Forbidden(t *testing.T) {
+	e, mockUseCase := newLogLevelServer(t)
+
+	mockUseCase.EXPECT().GetLogLevel(gomock.Any()).Return(entity.LogLevel{}, apperror.Forbidden("not allowed to log_level.manage", nil))
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v0/admin/log-level", http.NoBody))
+
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+}
+
+func TestResetLogLevel(t *testing.T) {
+	e, mockUseCase := newLogLevelServer(t)
+
+	mockUseCase.EXPECT().ResetLogLevel(gomock.Any()).Return(entity.LogLevel{Level: "info", Configured: "info"}, nil)
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodDelete, "/v0/admin/log-level", http.NoBody))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"level":"info","configured":"info"}`, rec.Body.String())
+}
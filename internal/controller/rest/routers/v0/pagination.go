@@ -0,0 +1,111 @@
+package v0
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/DeSouzaRafael/go-clean-architecture-template/infra/validator"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/apperror"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/controller/rest/input"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/controller/rest/output"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/query"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/usecase"
+)
+
+// bindPage reads limit/offset/cursor/total from the query string.
+func bindPage(c echo.Context, v *validator.Validator) (usecase.PageRequest, error) {
+	var in input.PageInput
+	if err := (&echo.DefaultBinder{}).BindQueryParams(c, &in); err != nil {
+		return usecase.PageRequest{}, apperror.Validation("invalid pagination parameters", err)
+	}
+
+	if err := in.Validate(v); err != nil {
+		return usecase.PageRequest{}, apperror.Validation("invalid request data: "+err.Error(), nil)
+	}
+
+	req := usecase.PageRequest{Limit: in.Limit, Offset: in.Offset, WithTotal: in.Total}
+	if req.Limit == 0 {
+		req.Limit = usecase.DefaultPageLimit
+	}
+
+	if in.Cursor != "" {
+		if c.QueryParam("offset") != "" {
+			return usecase.PageRequest{}, apperror.Validation("offset and cursor cannot be combined", nil)
+		}
+		cursor, err := usecase.DecodeCursor(in.Cursor)
+		if err != nil {
+			return usecase.PageRequest{}, err
+		}
+		req.Cursor = cursor
+	}
+
+	return req, nil
+}
+
+// bindCriteria reads the filter and sort expressions from the query string.
+func bindCriteria(c echo.Context) (usecase.Criteria, error) {
+	q := query.Values(c.Request().URL.RawQuery)
+
+	conds, err := query.ParseFilter(q.Get("filter"))
+	if err != nil {
+		return usecase.Criteria{}, err
+	}
+
+	sort, err := query.ParseSort(q.Get("sort"))
+	if err != nil {
+		return usecase.Criteria{}, err
+	}
+
+	return usecase.Criteria{Conditions: conds, Sort: sort}, nil
+}
+
+// pageMeta builds the response metadata and sets an RFC 8288 Link header.
+// Clients paging by offset get offset links, everyone else gets cursor links.
+func pageMeta[T any](c echo.Context, req usecase.PageRequest, page usecase.Page[T]) output.PageMeta {
+	meta := output.PageMeta{Limit: req.Limit, Total: page.Total, HasMore: page.HasMore}
+	if page.NextCursor != nil {
+		meta.NextCursor = page.NextCursor.Encode()
+	}
+
+	offsetMode := req.Cursor == nil && c.QueryParam("offset") != ""
+	if offsetMode {
+		offset := req.Offset
+		meta.Offset = &offset
+	}
+
+	var links []string
+	switch {
+	case offsetMode:
+		if page.HasMore {
+			links = append(links, pageLink(c, "next", "offset", strconv.Itoa(req.Offset+req.Limit), req.Limit))
+		}
+		if req.Offset > 0 {
+			links = append(links,
+				pageLink(c, "first", "offset", "0", req.Limit),
+				pageLink(c, "prev", "offset", strconv.Itoa(max(req.Offset-req.Limit, 0)), req.Limit),
+			)
+		}
+	case page.HasMore:
+		links = append(links, pageLink(c, "next", "cursor", meta.NextCursor, req.Limit))
+	}
+
+	if len(links) > 0 {
+		c.Response().Header().Set("Link", strings.Join(links, ", "))
+	}
+
+	return meta
+}
+
+func pageLink(c echo.Context, rel, key, value string, limit int) string {
+	u := *c.Request().URL
+	q := query.Values(u.RawQuery)
+	q.Del("offset")
+	q.Del("cursor")
+	q.Set("limit", strconv.Itoa(limit))
+	q.Set(key, value)
+	u.RawQuery = q.Encode()
+	return fmt.Sprintf("<%s>; rel=%q", u.RequestURI(), rel)
+}
@@ -1,13 +1,18 @@
 package v0
 
 import (
+	"encoding/json"
+	"errors"
+	"io"
 	"net/http"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 
 	"github.com/DeSouzaRafael/go-clean-architecture-template/infra/logger"
 	"github.com/DeSouzaRafael/go-clean-architecture-template/infra/validator"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/apperror"
 	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/controller/rest/input"
 	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/controller/rest/output"
 	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/entity"
@@ -25,10 +30,17 @@ func NewUserRoutes(handler *echo.Echo, l logger.Interface, v *validator.Validato
 	ur := &userRoutes{uc, l, v}
 
 	g := handler.Group("/v0/user")
+	g.GET("", ur.list)
 	g.GET("/:id", ur.get)
 	g.POST("", ur.create)
 	g.PUT("/:id", ur.update)
+	g.PATCH("/:id", ur.patch)
 	g.DELETE("/:id", ur.delete)
+	g.GET("/deleted", ur.listDeleted)
+	g.POST("/:id/restore", ur.restore)
+	g.GET("/:id/history", ur.history)
+	g.DELETE("/deleted/:id", ur.purge)
+	g.DELETE("/deleted", ur.purgeDeleted)
 }
 
 // @Summary     Get User
@@ -39,23 +51,32 @@ func NewUserRoutes(handler *echo.Echo, l logger.Interface, v *validator.Validato
 // @Produce     json
 // @Param       id   path   string  true  "User ID"
 // @Success     200  {object} output.UserOutput  "Returns the found user"
+// @Header      200  {string} ETag  "Entity tag to send as If-Match on PUT, PATCH and DELETE"
 // @Failure     400  {object} output.ResponseError  "Invalid UUID format"
+// @Failure     401  {object} output.ResponseError  "Missing or invalid bearer token or API key"
+// @Failure     403  {object} output.ResponseError  "Not allowed for the caller's roles"
+// @Failure     404  {object} output.ResponseError  "User not found"
+// @Failure     429  {object} output.ResponseError  "Rate limit exceeded, retry after Retry-After seconds"
+// @Header      429  {integer} Retry-After  "Seconds until the request may be retried"
 // @Failure     500  {object} output.ResponseError  "Internal server error"
+// @Security    BearerAuth
+// @Security    ApiKeyAuth
 // @Router      /v0/user/{id} [get]
 func (ur *userRoutes) get(c echo.Context) error {
 
 	id, err := uuid.Parse(c.Param("id"))
 	if err != nil {
-		ur.logger.Error(err, "http - v0 - get")
-		return output.ErrorResponse(c, http.StatusBadRequest, "invalid UUID format")
+		ur.logger.WithContext(c.Request().Context()).Debug("http - v0 - get", logger.Err(err))
+		return apperror.Validation("invalid UUID format", err)
 	}
 
 	user, err := ur.usecase.GetUserById(c.Request().Context(), entity.UserEntity{ID: id})
 	if err != nil {
-		ur.logger.Error(err, "http - v0 - update")
-		return output.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		ur.logger.WithContext(c.Request().Context()).Error("http - v0 - get", logger.Err(err))
+		return err
 	}
 
+	setETag(c, user.Version)
 	response := output.UserOutput{
 		ID:    user.ID,
 		Name:  user.Name,
@@ -65,6 +86,66 @@ func (ur *userRoutes) get(c echo.Context) error {
 	return c.JSON(http.StatusOK, response)
 }
 
+// @Summary     List Users
+// @Description List users, by default ordered by creation date. Page with limit/offset or with the opaque
+// @Description next_cursor returned by the previous page; the Link header carries the next page URL.
+// @Description Filter clauses are separated by ';' and use ==, !=, >, >=, <, <= or =like= (with * as wildcard)
+// @Description on name, phone, created_at and updated_at. Sort on name, created_at or updated_at, '-' for descending.
+// @ID          listUsers
+// @Tags        users
+// @Accept      json
+// @Produce     json
+// @Param       limit   query  int     false  "Page size (default 20, max 100)"
+// @Param       offset  query  int     false  "Number of users to skip, cannot be combined with cursor"
+// @Param       cursor  query  string  false  "Opaque cursor from meta.next_cursor"
+// @Param       total   query  bool    false  "Include the total number of users"
+// @Param       filter  query  string  false  "Filter expression, e.g. name=like=ana*;created_at>2026-01-01"
+// @Param       sort    query  string  false  "Sort fields, e.g. -created_at,name"
+// @Success     200  {object} output.UserListOutput  "Returns a page of users"
+// @Failure     400  {object} output.ResponseError  "Invalid pagination, filter or sort parameters"
+// @Failure     401  {object} output.ResponseError  "Missing or invalid bearer token or API key"
+// @Failure     403  {object} output.ResponseError  "Not allowed for the caller's roles"
+// @Failure     429  {object} output.ResponseError  "Rate limit exceeded, retry after Retry-After seconds"
+// @Header      429  {integer} Retry-After  "Seconds until the request may be retried"
+// @Failure     500  {object} output.ResponseError  "Internal server error"
+// @Security    BearerAuth
+// @Security    ApiKeyAuth
+// @Router      /v0/user [get]
+func (ur *userRoutes) list(c echo.Context) error {
+
+	req, err := bindPage(c, ur.validator)
+	if err != nil {
+		ur.logger.WithContext(c.Request().Context()).Debug("http - v0 - list", logger.Err(err))
+		return err
+	}
+
+	criteria, err := bindCriteria(c)
+	if err != nil {
+		ur.logger.WithContext(c.Request().Context()).Debug("http - v0 - list", logger.Err(err))
+		return err
+	}
+
+	page, err := ur.usecase.ListUsers(c.Request().Context(), criteria, req)
+	if err != nil {
+		ur.logger.WithContext(c.Request().Context()).Error("http - v0 - list", logger.Err(err))
+		return err
+	}
+
+	response := output.UserListOutput{
+		Data: make([]output.UserOutput, 0, len(page.Items)),
+		Meta: pageMeta(c, req, page),
+	}
+	for _, user := range page.Items {
+		response.Data = append(response.Data, output.UserOutput{
+			ID:    user.ID,
+			Name:  user.Name,
+			Phone: user.Phone,
+		})
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
 // @Summary     Update User
 // @Description update existing user details
 // @ID          updateUser
@@ -72,68 +153,191 @@ func (ur *userRoutes) get(c echo.Context) error {
 // @Accept      json
 // @Produce     json
 // @Param       id path string true "User ID"
+// @Param       If-Match header string true "ETag of the user as last read, or *"
 // @Param       request body input.UserInput true "Update user details"
 // @Success     200 "User Successfully updated"
 // @Failure     400 {object} output.ResponseError
+// @Failure     401  {object} output.ResponseError  "Missing or invalid bearer token or API key"
+// @Failure     403  {object} output.ResponseError  "Not allowed for the caller's roles"
 // @Failure     404 {object} output.ResponseError "User not found"
+// @Failure     412 {object} output.ResponseError "User has been modified since it was read"
+// @Failure     428 {object} output.ResponseError "Missing If-Match header"
+// @Failure     429 {object} output.ResponseError "Rate limit exceeded, retry after Retry-After seconds"
+// @Header      429 {integer} Retry-After "Seconds until the request may be retried"
 // @Failure     500 {object} output.ResponseError
+// @Security    BearerAuth
+// @Security    ApiKeyAuth
 // @Router      /v0/user/{id} [put]
 func (ur *userRoutes) update(c echo.Context) error {
 	id, err := uuid.Parse(c.Param("id"))
 	if err != nil {
-		ur.logger.Error(err, "http - v0 - update")
-		return output.ErrorResponse(c, http.StatusBadRequest, "invalid UUID format")
+		ur.logger.WithContext(c.Request().Context()).Debug("http - v0 - update", logger.Err(err))
+		return apperror.Validation("invalid UUID format", err)
+	}
+
+	version, err := bindIfMatch(c)
+	if err != nil {
+		return err
 	}
 
 	var input input.UserInput
 	if err := c.Bind(&input); err != nil {
-		ur.logger.Error(err, "http - v0 - update")
-		return output.ErrorResponse(c, http.StatusBadRequest, "invalid request body")
+		ur.logger.WithContext(c.Request().Context()).Debug("http - v0 - update", logger.Err(err))
+		return apperror.Validation("invalid request body", err)
 	}
 
 	if err := input.Validate(ur.validator); err != nil {
-		ur.logger.Error(err, "http - v0 - update")
-		return output.ErrorResponse(c, http.StatusBadRequest, "invalid request data: "+err.Error())
+		ur.logger.WithContext(c.Request().Context()).Debug("http - v0 - update validation", logger.Err(err))
+		return apperror.Validation("invalid request data: "+err.Error(), nil)
 	}
 
 	err = ur.usecase.UpdateUser(
 		c.Request().Context(),
 		entity.UserEntity{
-			ID:    id,
-			Name:  input.Name,
-			Phone: input.Phone,
+			ID:      id,
+			Name:    input.Name,
+			Phone:   input.Phone,
+			Version: version,
 		},
 	)
 	if err != nil {
-		ur.logger.Error(err, "http - v0 - update")
-		return output.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		ur.logger.WithContext(c.Request().Context()).Error("http - v0 - update", logger.Err(err))
+		return err
 	}
 
 	return c.JSON(http.StatusOK, map[string]string{"message": "Successfully updated"})
 }
 
+// @Summary     Patch User
+// @Description Partially update a user with a JSON Merge Patch (RFC 7396, application/merge-patch+json)
+// @Description or a JSON Patch (RFC 6902, application/json-patch+json) applied to {"name", "phone"}.
+// @ID          patchUser
+// @Tags        users
+// @Accept      application/merge-patch+json,application/json-patch+json
+// @Produce     json
+// @Param       id path string true "User ID"
+// @Param       If-Match header string true "ETag of the user as last read, or *"
+// @Param       Idempotency-Key header string false "Client generated key making retries safe"
+// @Param       request body object true "Patch document"
+// @Success     200 {object} output.UserOutput "Returns the updated user"
+// @Header      200 {string} ETag "Entity tag of the updated user"
+// @Failure     400 {object} output.ResponseError "Invalid patch document or resulting user"
+// @Failure     401  {object} output.ResponseError  "Missing or invalid bearer token or API key"
+// @Failure     403  {object} output.ResponseError  "Not allowed for the caller's roles"
+// @Failure     404 {object} output.ResponseError "User not found"
+// @Failure     409 {object} output.ResponseError "JSON Patch test operation failed"
+// @Failure     412 {object} output.ResponseError "User has been modified since it was read"
+// @Failure     415 {object} output.ResponseError "Unsupported patch media type"
+// @Failure     422 {object} output.ResponseError "Patch cannot be applied to the user"
+// @Failure     428 {object} output.ResponseError "Missing If-Match header"
+// @Failure     429 {object} output.ResponseError "Rate limit exceeded, retry after Retry-After seconds"
+// @Header      429 {integer} Retry-After "Seconds until the request may be retried"
+// @Failure     500 {object} output.ResponseError
+// @Security    BearerAuth
+// @Security    ApiKeyAuth
+// @Router      /v0/user/{id} [patch]
+func (ur *userRoutes) patch(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		ur.logger.WithContext(c.Request().Context()).Debug("http - v0 - patch", logger.Err(err))
+		return apperror.Validation("invalid UUID format", err)
+	}
+
+	version, err := bindIfMatch(c)
+	if err != nil {
+		return err
+	}
+
+	body, err := io.ReadAll(c.Request().Body)
+	if err != nil {
+		ur.logger.WithContext(c.Request().Context()).Debug("http - v0 - patch", logger.Err(err))
+		return apperror.Validation("invalid request body", err)
+	}
+
+	doc, err := input.DecodePatch(c.Request().Header.Get(echo.HeaderContentType), body)
+	if errors.Is(err, input.ErrUnsupportedPatch) {
+		return echo.NewHTTPError(http.StatusUnsupportedMediaType, "use "+input.MIMEMergePatch+" or "+input.MIMEJSONPatch)
+	}
+	if err != nil {
+		ur.logger.WithContext(c.Request().Context()).Debug("http - v0 - patch", logger.Err(err))
+		return apperror.Validation("invalid patch document", err)
+	}
+
+	user, err := ur.usecase.PatchUser(c.Request().Context(), entity.UserEntity{ID: id, Version: version}, ur.userPatch(doc))
+	if err != nil {
+		ur.logger.WithContext(c.Request().Context()).Error("http - v0 - patch", logger.Err(err))
+		return err
+	}
+
+	setETag(c, user.Version)
+	response := output.UserOutput{
+		ID:    user.ID,
+		Name:  user.Name,
+		Phone: user.Phone,
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// userPatch applies doc to the user as represented by input.UserInput, so a
+// patched user is validated with the same rules as a full update.
+func (ur *userRoutes) userPatch(doc *input.PatchDocument) usecase.UserPatch {
+	return func(user entity.UserEntity) (entity.UserEntity, error) {
+		current, err := json.Marshal(input.UserInput{Name: user.Name, Phone: user.Phone})
+		if err != nil {
+			return user, err
+		}
+
+		var patched input.UserInput
+		if err := doc.Apply(current, &patched); err != nil {
+			if errors.Is(err, input.ErrPatchTestFailed) {
+				return user, apperror.Conflict("patch test operation failed", err)
+			}
+			return user, apperror.Unprocessable("patch cannot be applied to the user", err)
+		}
+
+		if err := patched.Validate(ur.validator); err != nil {
+			return user, apperror.Validation("invalid request data: "+err.Error(), nil)
+		}
+
+		user.Name = patched.Name
+		user.Phone = patched.Phone
+		return user, nil
+	}
+}
+
 // @Summary     Create User
 // @Description add new user
 // @ID          create
 // @Tags  	    users
 // @Accept      json
 // @Produce     json
+// @Param       Idempotency-Key header string false "Client generated key making retries safe"
 // @Param       user body input.UserInput true "Set up users"
 // @Success     200 {object} output.UserOutput
+// @Header      200 {string} ETag "Entity tag of the created user"
 // @Failure     400 {object} output.ResponseError
+// @Failure     401  {object} output.ResponseError  "Missing or invalid bearer token or API key"
+// @Failure     403  {object} output.ResponseError  "Not allowed for the caller's roles"
+// @Failure     409 {object} output.ResponseError "User already exists or a request with the same Idempotency-Key is in progress"
+// @Failure     422 {object} output.ResponseError "Idempotency-Key reused with a different request"
+// @Failure     429 {object} output.ResponseError "Rate limit exceeded, retry after Retry-After seconds"
+// @Header      429 {integer} Retry-After "Seconds until the request may be retried"
 // @Failure     500 {object} output.ResponseError
+// @Security    BearerAuth
+// @Security    ApiKeyAuth
 // @Router      /v0/user [post]
 func (ur *userRoutes) create(c echo.Context) error {
 
 	var input input.UserInput
 	if err := c.Bind(&input); err != nil {
-		ur.logger.Error(err, "http - v0 - create")
-		return output.ErrorResponse(c, http.StatusBadRequest, "invalid request body")
+		ur.logger.WithContext(c.Request().Context()).Debug("http - v0 - create", logger.Err(err))
+		return apperror.Validation("invalid request body", err)
 	}
 
 	if err := input.Validate(ur.validator); err != nil {
-		ur.logger.Error(err, "http - v0 - update validation")
-		return output.ErrorResponse(c, http.StatusBadRequest, "invalid request data: "+err.Error())
+		ur.logger.WithContext(c.Request().Context()).Debug("http - v0 - create validation", logger.Err(err))
+		return apperror.Validation("invalid request data: "+err.Error(), nil)
 	}
 
 	user, err := ur.usecase.CreateUser(
@@ -144,10 +348,11 @@ func (ur *userRoutes) create(c echo.Context) error {
 		},
 	)
 	if err != nil {
-		ur.logger.Error(err, "http - v0 - create")
-		return output.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		ur.logger.WithContext(c.Request().Context()).Error("http - v0 - create", logger.Err(err))
+		return err
 	}
 
+	setETag(c, user.Version)
 	response := output.UserOutput{
 		ID:    user.ID,
 		Name:  user.Name,
@@ -164,23 +369,274 @@ func (ur *userRoutes) create(c echo.Context) error {
 // @Accept      json
 // @Produce     json
 // @Param       id   path   string  true  "User ID"
+// @Param       If-Match  header  string  true  "ETag of the user as last read, or *"
 // @Success     200  "User successfully deleted"
 // @Failure     400  {object} output.ResponseError  "Invalid UUID format"
+// @Failure     401  {object} output.ResponseError  "Missing or invalid bearer token or API key"
+// @Failure     403  {object} output.ResponseError  "Not allowed for the caller's roles"
+// @Failure     404  {object} output.ResponseError  "User not found"
+// @Failure     412  {object} output.ResponseError  "User has been modified since it was read"
+// @Failure     428  {object} output.ResponseError  "Missing If-Match header"
+// @Failure     429  {object} output.ResponseError  "Rate limit exceeded, retry after Retry-After seconds"
+// @Header      429  {integer} Retry-After  "Seconds until the request may be retried"
 // @Failure     500  {object} output.ResponseError  "Internal server error"
+// @Security    BearerAuth
+// @Security    ApiKeyAuth
 // @Router      /v0/user/{id} [delete]
 func (ur *userRoutes) delete(c echo.Context) error {
 
 	id, err := uuid.Parse(c.Param("id"))
 	if err != nil {
-		ur.logger.Error(err, "http - v0 - delete")
-		return output.ErrorResponse(c, http.StatusBadRequest, "Invalid UUID format")
+		ur.logger.WithContext(c.Request().Context()).Debug("http - v0 - delete", logger.Err(err))
+		return apperror.Validation("invalid UUID format", err)
+	}
+
+	version, err := bindIfMatch(c)
+	if err != nil {
+		return err
 	}
 
-	err = ur.usecase.DeleteUser(c.Request().Context(), entity.UserEntity{ID: id})
+	err = ur.usecase.DeleteUser(c.Request().Context(), entity.UserEntity{ID: id, Version: version})
 	if err != nil {
-		ur.logger.Error(err, "http - v0 - delete")
-		return output.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		ur.logger.WithContext(c.Request().Context()).Error("http - v0 - delete", logger.Err(err))
+		return err
 	}
 
 	return c.JSON(http.StatusOK, map[string]string{"message": "Successfully deleted"})
 }
+
+// @Summary     List Deleted Users
+// @Description List soft-deleted users, with the same paging, filter and sort parameters as List Users.
+// @Description deleted_at may also be used to filter and sort.
+// @ID          listDeletedUsers
+// @Tags        users
+// @Accept      json
+// @Produce     json
+// @Param       limit   query  int     false  "Page size (default 20, max 100)"
+// @Param       offset  query  int     false  "Number of users to skip, cannot be combined with cursor"
+// @Param       cursor  query  string  false  "Opaque cursor from meta.next_cursor"
+// @Param       total   query  bool    false  "Include the total number of deleted users"
+// @Param       filter  query  string  false  "Filter expression, e.g. deleted_at<2026-01-01"
+// @Param       sort    query  string  false  "Sort fields, e.g. -deleted_at"
+// @Success     200  {object} output.DeletedUserListOutput  "Returns a page of deleted users"
+// @Failure     400  {object} output.ResponseError  "Invalid pagination, filter or sort parameters"
+// @Failure     401  {object} output.ResponseError  "Missing or invalid bearer token or API key"
+// @Failure     403  {object} output.ResponseError  "Not allowed for the caller's roles"
+// @Failure     429  {object} output.ResponseError  "Rate limit exceeded, retry after Retry-After seconds"
+// @Header      429  {integer} Retry-After  "Seconds until the request may be retried"
+// @Failure     500  {object} output.ResponseError  "Internal server error"
+// @Security    BearerAuth
+// @Security    ApiKeyAuth
+// @Router      /v0/user/deleted [get]
+func (ur *userRoutes) listDeleted(c echo.Context) error {
+
+	req, err := bindPage(c, ur.validator)
+	if err != nil {
+		ur.logger.WithContext(c.Request().Context()).Debug("http - v0 - listDeleted", logger.Err(err))
+		return err
+	}
+
+	criteria, err := bindCriteria(c)
+	if err != nil {
+		ur.logger.WithContext(c.Request().Context()).Debug("http - v0 - listDeleted", logger.Err(err))
+		return err
+	}
+
+	page, err := ur.usecase.ListDeletedUsers(c.Request().Context(), criteria, req)
+	if err != nil {
+		ur.logger.WithContext(c.Request().Context()).Error("http - v0 - listDeleted", logger.Err(err))
+		return err
+	}
+
+	response := output.DeletedUserListOutput{
+		Data: make([]output.DeletedUserOutput, 0, len(page.Items)),
+		Meta: pageMeta(c, req, page),
+	}
+	for _, user := range page.Items {
+		item := output.DeletedUserOutput{
+			ID:    user.ID,
+			Name:  user.Name,
+			Phone: user.Phone,
+		}
+		if user.DeletedAt != nil {
+			item.DeletedAt = *user.DeletedAt
+		}
+		response.Data = append(response.Data, item)
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// @Summary     Restore User
+// @Description Undeletes a soft-deleted user.
+// @ID          restoreUser
+// @Tags        users
+// @Accept      json
+// @Produce     json
+// @Param       id   path   string  true  "User ID"
+// @Success     200  {object} output.UserOutput  "Returns the restored user"
+// @Header      200  {string} ETag  "Entity tag to send as If-Match on PUT, PATCH and DELETE"
+// @Failure     400  {object} output.ResponseError  "Invalid UUID format"
+// @Failure     401  {object} output.ResponseError  "Missing or invalid bearer token or API key"
+// @Failure     403  {object} output.ResponseError  "Not allowed for the caller's roles"
+// @Failure     404  {object} output.ResponseError  "Deleted user not found"
+// @Failure     429  {object} output.ResponseError  "Rate limit exceeded, retry after Retry-After seconds"
+// @Header      429  {integer} Retry-After  "Seconds until the request may be retried"
+// @Failure     500  {object} output.ResponseError  "Internal server error"
+// @Security    BearerAuth
+// @Security    ApiKeyAuth
+// @Router      /v0/user/{id}/restore [post]
+func (ur *userRoutes) restore(c echo.Context) error {
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		ur.logger.WithContext(c.Request().Context()).Debug("http - v0 - restore", logger.Err(err))
+		return apperror.Validation("invalid UUID format", err)
+	}
+
+	user, err := ur.usecase.RestoreUser(c.Request().Context(), entity.UserEntity{ID: id})
+	if err != nil {
+		ur.logger.WithContext(c.Request().Context()).Error("http - v0 - restore", logger.Err(err))
+		return err
+	}
+
+	setETag(c, user.Version)
+	response := output.UserOutput{
+		ID:    user.ID,
+		Name:  user.Name,
+		Phone: user.Phone,
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// @Summary     Purge User
+// @Description Permanently erases a soft-deleted user, and the values recorded in its history and events. Users that are not deleted cannot be purged.
+// @ID          purgeUser
+// @Tags        users
+// @Accept      json
+// @Produce     json
+// @Param       id   path   string  true  "User ID"
+// @Success     200  "User successfully purged"
+// @Failure     400  {object} output.ResponseError  "Invalid UUID format"
+// @Failure     401  {object} output.ResponseError  "Missing or invalid bearer token or API key"
+// @Failure     403  {object} output.ResponseError  "Not allowed for the caller's roles"
+// @Failure     404  {object} output.ResponseError  "Deleted user not found"
+// @Failure     429  {object} output.ResponseError  "Rate limit exceeded, retry after Retry-After seconds"
+// @Header      429  {integer} Retry-After  "Seconds until the request may be retried"
+// @Failure     500  {object} output.ResponseError  "Internal server error"
+// @Security    BearerAuth
+// @Security    ApiKeyAuth
+// @Router      /v0/user/deleted/{id} [delete]
+func (ur *userRoutes) purge(c echo.Context) error {
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		ur.logger.WithContext(c.Request().Context()).Debug("http - v0 - purge", logger.Err(err))
+		return apperror.Validation("invalid UUID format", err)
+	}
+
+	if err := ur.usecase.PurgeUser(c.Request().Context(), entity.UserEntity{ID: id}); err != nil {
+		ur.logger.WithContext(c.Request().Context()).Error("http - v0 - purge", logger.Err(err))
+		return err
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"message": "Successfully purged"})
+}
+
+// @Summary     Purge Deleted Users
+// @Description Permanently erases all users soft-deleted before the given time, and the values recorded in their history and events.
+// @ID          purgeDeletedUsers
+// @Tags        users
+// @Accept      json
+// @Produce     json
+// @Param       before  query  string  true  "RFC 3339 timestamp, e.g. 2026-01-01T00:00:00Z"
+// @Success     200  {object} output.PurgeOutput  "Returns the number of purged users"
+// @Failure     400  {object} output.ResponseError  "Missing or invalid before parameter"
+// @Failure     401  {object} output.ResponseError  "Missing or invalid bearer token or API key"
+// @Failure     403  {object} output.ResponseError  "Not allowed for the caller's roles"
+// @Failure     429  {object} output.ResponseError  "Rate limit exceeded, retry after Retry-After seconds"
+// @Header      429  {integer} Retry-After  "Seconds until the request may be retried"
+// @Failure     500  {object} output.ResponseError  "Internal server error"
+// @Security    BearerAuth
+// @Security    ApiKeyAuth
+// @Router      /v0/user/deleted [delete]
+func (ur *userRoutes) purgeDeleted(c echo.Context) error {
+
+	before, err := time.Parse(time.RFC3339, c.QueryParam("before"))
+	if err != nil {
+		ur.logger.WithContext(c.Request().Context()).Debug("http - v0 - purgeDeleted", logger.Err(err))
+		return apperror.Validation("before must be an RFC 3339 timestamp", err)
+	}
+
+	n, err := ur.usecase.PurgeDeletedUsers(c.Request().Context(), before)
+	if err != nil {
+		ur.logger.WithContext(c.Request().Context()).Error("http - v0 - purgeDeleted", logger.Err(err))
+		return err
+	}
+
+	return c.JSON(http.StatusOK, output.PurgeOutput{Purged: n})
+}
+
+// @Summary     User History
+// @Description Audit trail of a user, most recent first: who created, changed, deleted or restored it, in which
+// @Description request, and the old and new value of each changed field. After a purge, only the values are erased.
+// @ID          userHistory
+// @Tags        users
+// @Accept      json
+// @Produce     json
+// @Param       id      path   string  true   "User ID"
+// @Param       limit   query  int     false  "Page size (default 20, max 100)"
+// @Param       offset  query  int     false  "Number of events to skip"
+// @Param       total   query  bool    false  "Include the total number of events"
+// @Success     200  {object} output.UserHistoryOutput  "Returns a page of audit events"
+// @Failure     400  {object} output.ResponseError  "Invalid UUID format or pagination parameters"
+// @Failure     401  {object} output.ResponseError  "Missing or invalid bearer token or API key"
+// @Failure     403  {object} output.ResponseError  "Not allowed for the caller's roles"
+// @Failure     429  {object} output.ResponseError  "Rate limit exceeded, retry after Retry-After seconds"
+// @Header      429  {integer} Retry-After  "Seconds until the request may be retried"
+// @Failure     500  {object} output.ResponseError  "Internal server error"
+// @Security    BearerAuth
+// @Security    ApiKeyAuth
+// @Router      /v0/user/{id}/history [get]
+func (ur *userRoutes) history(c echo.Context) error {
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		ur.logger.WithContext(c.Request().Context()).Debug("http - v0 - history", logger.Err(err))
+		return apperror.Validation("invalid UUID format", err)
+	}
+
+	req, err := bindPage(c, ur.validator)
+	if err != nil {
+		ur.logger.WithContext(c.Request().Context()).Debug("http - v0 - history", logger.Err(err))
+		return err
+	}
+
+	page, err := ur.usecase.UserHistory(c.Request().Context(), entity.UserEntity{ID: id}, req)
+	if err != nil {
+		ur.logger.WithContext(c.Request().Context()).Error("http - v0 - history", logger.Err(err))
+		return err
+	}
+
+	response := output.UserHistoryOutput{
+		Data: make([]output.AuditEventOutput, 0, len(page.Items)),
+		Meta: pageMeta(c, req, page),
+	}
+	for _, event := range page.Items {
+		item := output.AuditEventOutput{
+			ID:        event.ID,
+			Action:    string(event.Action),
+			Actor:     event.Actor,
+			RequestID: event.RequestID,
+			Changes:   make([]output.FieldChangeOutput, 0, len(event.Changes)),
+			CreatedAt: event.CreatedAt,
+		}
+		for _, change := range event.Changes {
+			item.Changes = append(item.Changes, output.FieldChangeOutput(change))
+		}
+		response.Data = append(response.Data, item)
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
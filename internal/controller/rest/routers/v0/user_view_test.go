@@ -2,15 +2,20 @@
 package v0
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/DeSouzaRafael/go-clean-architecture-template/infra/logger"
 	"github.com/DeSouzaRafael/go-clean-architecture-template/infra/validator"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/apperror"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/controller/rest/output"
 	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/entity"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/usecase"
 	"github.com/DeSouzaRafael/go-clean-architecture-template/mocks"
 	"go.uber.org/mock/gomock"
 	"github.com/google/uuid"
@@ -30,10 +35,17 @@ func TestNewUserRoutes(t *testing.T) {
 	NewUserRoutes(e, l, v, mockUseCase)
 
 	// Verify that routes are configured correctly
+	assertRouteExists(t, e, http.MethodGet, "/v0/user")
 	assertRouteExists(t, e, http.MethodGet, "/v0/user/:id")
 	assertRouteExists(t, e, http.MethodPost, "/v0/user")
 	assertRouteExists(t, e, http.MethodPut, "/v0/user/:id")
+	assertRouteExists(t, e, http.MethodPatch, "/v0/user/:id")
 	assertRouteExists(t, e, http.MethodDelete, "/v0/user/:id")
+	assertRouteExists(t, e, http.MethodGet, "/v0/user/deleted")
+	assertRouteExists(t, e, http.MethodPost, "/v0/user/:id/restore")
+	assertRouteExists(t, e, http.MethodGet, "/v0/user/:id/history")
+	assertRouteExists(t, e, http.MethodDelete, "/v0/user/deleted/:id")
+	assertRouteExists(t, e, http.MethodDelete, "/v0/user/deleted")
 }
 
 func assertRouteExists(t *testing.T, e *echo.Echo, method, path string) {
@@ -58,8 +70,9 @@ func TestGetUser(t *testing.T) {
 	e := echo.New()
 	userID := uuid.New()
 	user := entity.UserEntity{
-		ID:   userID,
-		Name: "User Name",
+		ID:      userID,
+		Name:    "User Name",
+		Version: 7,
 	}
 
 	mockUseCase.EXPECT().GetUserById(gomock.Any(), entity.UserEntity{ID: userID}).Return(user, nil)
@@ -77,6 +90,7 @@ func TestGetUser(t *testing.T) {
 	if assert.NoError(t, err) {
 		assert.Equal(t, http.StatusOK, rec.Code)
 		assert.Contains(t, rec.Body.String(), `"name":"User Name"`)
+		assert.Equal(t, `"7"`, rec.Header().Get(headerETag))
 	}
 }
 
@@ -103,9 +117,75 @@ func TestGetUser_InternalServerError(t *testing.T) {
 	r := &userRoutes{usecase: mockUseCase, logger: l, validator: v}
 	err := r.get(c)
 
-	if assert.NoError(t, err) {
+	if assert.Error(t, err) {
+		output.HTTPErrorHandler(l)(err, c)
 		assert.Equal(t, http.StatusInternalServerError, rec.Code)
-		assert.Contains(t, rec.Body.String(), `"error":"some error"`)
+		assert.Contains(t, rec.Body.String(), `"error":"internal server error"`)
+		assert.NotContains(t, rec.Body.String(), "some error")
+	}
+}
+
+func TestGetUser_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockUser(ctrl)
+	l := logger.NewLogger("info")
+	v := validator.NewValidator()
+
+	e := echo.New()
+	userID := uuid.New()
+	notFound := apperror.Wrap(apperror.NotFound("record not found", fmt.Errorf("record not found")), apperror.KindNotFound, "user_not_found", "user not found")
+
+	mockUseCase.EXPECT().GetUserById(gomock.Any(), entity.UserEntity{ID: userID}).Return(entity.UserEntity{}, fmt.Errorf("GetUserById: %w", notFound))
+
+	req := httptest.NewRequest(http.MethodGet, "/v0/user/"+userID.String(), http.NoBody)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("/v0/user/:id")
+	c.SetParamNames("id")
+	c.SetParamValues(userID.String())
+
+	r := &userRoutes{usecase: mockUseCase, logger: l, validator: v}
+	err := r.get(c)
+
+	if assert.Error(t, err) {
+		output.HTTPErrorHandler(l)(err, c)
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+		assert.JSONEq(t, `{"code":"user_not_found","error":"user not found"}`, rec.Body.String())
+	}
+}
+
+func TestUpdateUser_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockUser(ctrl)
+	l := logger.NewLogger("info")
+	v := validator.NewValidator()
+
+	e := echo.New()
+	userID := uuid.New()
+	reqBody := `{"name": "User Name", "phone": "+5511999999999"}`
+
+	mockUseCase.EXPECT().UpdateUser(gomock.Any(), gomock.Any()).Return(fmt.Errorf("UpdateUser: %w", apperror.NotFound("user not found", nil)))
+
+	req := httptest.NewRequest(http.MethodPut, "/v0/user/"+userID.String(), strings.NewReader(reqBody))
+	req.Header.Set(headerIfMatch, "*")
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("/v0/user/:id")
+	c.SetParamNames("id")
+	c.SetParamValues(userID.String())
+
+	r := &userRoutes{usecase: mockUseCase, logger: l, validator: v}
+	err := r.update(c)
+
+	if assert.Error(t, err) {
+		output.HTTPErrorHandler(l)(err, c)
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"code":"not_found"`)
 	}
 }
 
@@ -129,7 +209,8 @@ func TestGetUser_InvalidUUID(t *testing.T) {
 	r := &userRoutes{usecase: mockUseCase, logger: l, validator: v}
 	err := r.get(c)
 
-	if assert.NoError(t, err) {
+	if assert.Error(t, err) {
+		output.HTTPErrorHandler(l)(err, c)
 		assert.Equal(t, http.StatusBadRequest, rec.Code)
 		assert.Contains(t, rec.Body.String(), `"error":"invalid UUID format"`)
 	}
@@ -187,7 +268,8 @@ func TestCreateUser_InvalidRequestBody(t *testing.T) {
 	r := &userRoutes{usecase: mockUseCase, logger: l, validator: v}
 	err := r.create(c)
 
-	if assert.NoError(t, err) {
+	if assert.Error(t, err) {
+		output.HTTPErrorHandler(l)(err, c)
 		assert.Equal(t, http.StatusBadRequest, rec.Code)
 		assert.Contains(t, rec.Body.String(), `"error":"invalid request body"`)
 	}
@@ -205,6 +287,7 @@ func TestCreateUser_InvalidInput(t *testing.T) {
 	validator := validator.NewValidator()
 
 	req := httptest.NewRequest(http.MethodPut, "/v0/user/"+entity.UserEntity{}.ID.String(), strings.NewReader(reqBody))
+	req.Header.Set(headerIfMatch, "*")
 	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
 	rec := httptest.NewRecorder()
 	c := e.NewContext(req, rec)
@@ -215,9 +298,10 @@ func TestCreateUser_InvalidInput(t *testing.T) {
 	r := &userRoutes{usecase: mockUseCase, logger: l, validator: validator}
 	err := r.create(c)
 
-	if assert.NoError(t, err) {
+	if assert.Error(t, err) {
+		output.HTTPErrorHandler(l)(err, c)
 		assert.Equal(t, http.StatusBadRequest, rec.Code)
-		assert.Contains(t, rec.Body.String(), `{"error":"invalid request data: Field validation for 'Name' failed on the 'required' tag."}`+"\n")
+		assert.Contains(t, rec.Body.String(), `{"code":"validation_failed","error":"invalid request data: Field validation for 'Name' failed on the 'required' tag."}`+"\n")
 	}
 }
 
@@ -243,9 +327,11 @@ func TestCreateUser_InternalServerError(t *testing.T) {
 	r := &userRoutes{usecase: mockUseCase, logger: l, validator: v}
 	err := r.create(c)
 
-	if assert.NoError(t, err) {
+	if assert.Error(t, err) {
+		output.HTTPErrorHandler(l)(err, c)
 		assert.Equal(t, http.StatusInternalServerError, rec.Code)
-		assert.Contains(t, rec.Body.String(), `"error":"some error"`)
+		assert.Contains(t, rec.Body.String(), `"error":"internal server error"`)
+		assert.NotContains(t, rec.Body.String(), "some error")
 	}
 }
 
@@ -269,6 +355,7 @@ func TestUpdateUser(t *testing.T) {
 	mockUseCase.EXPECT().UpdateUser(gomock.Any(), user).Return(nil)
 
 	req := httptest.NewRequest(http.MethodPut, "/v0/user/"+userID.String(), strings.NewReader(reqBody))
+	req.Header.Set(headerIfMatch, "*")
 	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
 	rec := httptest.NewRecorder()
 	c := e.NewContext(req, rec)
@@ -305,6 +392,7 @@ func TestUpdateUser_InternalServerError(t *testing.T) {
 	mockUseCase.EXPECT().UpdateUser(gomock.Any(), user).Return(fmt.Errorf("some error"))
 
 	req := httptest.NewRequest(http.MethodPut, "/v0/user/"+userID.String(), strings.NewReader(reqBody))
+	req.Header.Set(headerIfMatch, "*")
 	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
 	rec := httptest.NewRecorder()
 	c := e.NewContext(req, rec)
@@ -315,9 +403,11 @@ func TestUpdateUser_InternalServerError(t *testing.T) {
 	r := &userRoutes{usecase: mockUseCase, logger: l, validator: v}
 	err := r.update(c)
 
-	if assert.NoError(t, err) {
+	if assert.Error(t, err) {
+		output.HTTPErrorHandler(l)(err, c)
 		assert.Equal(t, http.StatusInternalServerError, rec.Code)
-		assert.Contains(t, rec.Body.String(), `"error":"some error"`)
+		assert.Contains(t, rec.Body.String(), `"error":"internal server error"`)
+		assert.NotContains(t, rec.Body.String(), "some error")
 	}
 }
 
@@ -333,6 +423,7 @@ func TestUpdateUser_InvalidInput(t *testing.T) {
 	validator := validator.NewValidator()
 
 	req := httptest.NewRequest(http.MethodPut, "/v0/user/"+entity.UserEntity{}.ID.String(), strings.NewReader(reqBody))
+	req.Header.Set(headerIfMatch, "*")
 	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
 	rec := httptest.NewRecorder()
 	c := e.NewContext(req, rec)
@@ -343,9 +434,10 @@ func TestUpdateUser_InvalidInput(t *testing.T) {
 	r := &userRoutes{usecase: mockUseCase, logger: l, validator: validator}
 	err := r.update(c)
 
-	if assert.NoError(t, err) {
+	if assert.Error(t, err) {
+		output.HTTPErrorHandler(l)(err, c)
 		assert.Equal(t, http.StatusBadRequest, rec.Code)
-		assert.Contains(t, rec.Body.String(), `{"error":"invalid request data: Field validation for 'Name' failed on the 'required' tag."}`+"\n")
+		assert.Contains(t, rec.Body.String(), `{"code":"validation_failed","error":"invalid request data: Field validation for 'Name' failed on the 'required' tag."}`+"\n")
 	}
 }
 
@@ -361,6 +453,7 @@ func TestUpdateUser_InvalidRequestBody(t *testing.T) {
 	userID := uuid.New()
 
 	req := httptest.NewRequest(http.MethodPut, "/v0/user/"+userID.String(), strings.NewReader("invalid-json"))
+	req.Header.Set(headerIfMatch, "*")
 	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
 	rec := httptest.NewRecorder()
 	c := e.NewContext(req, rec)
@@ -371,7 +464,8 @@ func TestUpdateUser_InvalidRequestBody(t *testing.T) {
 	r := &userRoutes{usecase: mockUseCase, logger: l, validator: v}
 	err := r.update(c)
 
-	if assert.NoError(t, err) {
+	if assert.Error(t, err) {
+		output.HTTPErrorHandler(l)(err, c)
 		assert.Equal(t, http.StatusBadRequest, rec.Code)
 		assert.Contains(t, rec.Body.String(), `"error":"invalid request body"`)
 	}
@@ -388,6 +482,7 @@ func TestUpdateUser_InvalidUUID(t *testing.T) {
 	e := echo.New()
 
 	req := httptest.NewRequest(http.MethodPut, "/v0/user/invalid-uuid", strings.NewReader(`{"name": "Jane Doe", "phone": "+551199999999"}`))
+	req.Header.Set(headerIfMatch, "*")
 	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
 	rec := httptest.NewRecorder()
 	c := e.NewContext(req, rec)
@@ -398,7 +493,8 @@ func TestUpdateUser_InvalidUUID(t *testing.T) {
 	r := &userRoutes{usecase: mockUseCase, logger: l, validator: v}
 	err := r.update(c)
 
-	if assert.NoError(t, err) {
+	if assert.Error(t, err) {
+		output.HTTPErrorHandler(l)(err, c)
 		assert.Equal(t, http.StatusBadRequest, rec.Code)
 		assert.Contains(t, rec.Body.String(), `"error":"invalid UUID format"`)
 	}
@@ -415,9 +511,10 @@ func TestDeleteUser(t *testing.T) {
 	e := echo.New()
 	userID := uuid.New()
 
-	mockUseCase.EXPECT().DeleteUser(gomock.Any(), entity.UserEntity{ID: userID}).Return(nil)
+	mockUseCase.EXPECT().DeleteUser(gomock.Any(), entity.UserEntity{ID: userID, Version: 2}).Return(nil)
 
 	req := httptest.NewRequest(http.MethodDelete, "/v0/user/"+userID.String(), http.NoBody)
+	req.Header.Set(headerIfMatch, `"2"`)
 	rec := httptest.NewRecorder()
 	c := e.NewContext(req, rec)
 	c.SetPath("/v0/user/:id")
@@ -444,6 +541,7 @@ func TestDeleteUser_InvalidUUID(t *testing.T) {
 	e := echo.New()
 
 	req := httptest.NewRequest(http.MethodDelete, "/v0/user/invalid-uuid", http.NoBody)
+	req.Header.Set(headerIfMatch, "*")
 	rec := httptest.NewRecorder()
 	c := e.NewContext(req, rec)
 	c.SetPath("/v0/user/:id")
@@ -453,9 +551,10 @@ func TestDeleteUser_InvalidUUID(t *testing.T) {
 	r := &userRoutes{usecase: mockUseCase, logger: l, validator: v}
 	err := r.delete(c)
 
-	if assert.NoError(t, err) {
+	if assert.Error(t, err) {
+		output.HTTPErrorHandler(l)(err, c)
 		assert.Equal(t, http.StatusBadRequest, rec.Code)
-		assert.Contains(t, rec.Body.String(), `"error":"Invalid UUID format"`)
+		assert.Contains(t, rec.Body.String(), `"error":"invalid UUID format"`)
 	}
 }
 
@@ -474,6 +573,7 @@ func TestDeleteUser_DeleteUserError(t *testing.T) {
 	mockUseCase.EXPECT().DeleteUser(gomock.Any(), user).Return(fmt.Errorf("failed to delete user"))
 
 	req := httptest.NewRequest(http.MethodDelete, "/v0/user/"+userID.String(), http.NoBody)
+	req.Header.Set(headerIfMatch, "*")
 	rec := httptest.NewRecorder()
 	c := e.NewContext(req, rec)
 	c.SetPath("/v0/user/:id")
@@ -483,8 +583,633 @@ func TestDeleteUser_DeleteUserError(t *testing.T) {
 	r := &userRoutes{usecase: mockUseCase, logger: l, validator: v}
 	err := r.delete(c)
 
-	if assert.NoError(t, err) {
+	if assert.Error(t, err) {
+		output.HTTPErrorHandler(l)(err, c)
 		assert.Equal(t, http.StatusInternalServerError, rec.Code)
-		assert.Contains(t, rec.Body.String(), `"error":"failed to delete user"`)
+		assert.Contains(t, rec.Body.String(), `"error":"internal server error"`)
+		assert.NotContains(t, rec.Body.String(), "failed to delete user")
 	}
 }
+
+func TestListUsers_Cursor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockUser(ctrl)
+	l := logger.NewLogger("info")
+	v := validator.NewValidator()
+
+	e := echo.New()
+	users := []entity.UserEntity{{ID: uuid.New(), Name: "Ana"}, {ID: uuid.New(), Name: "Bia"}}
+	next := &usecase.Cursor{Sort: "created_at", Values: []any{time.Now().UTC()}, ID: users[1].ID}
+	total := int64(7)
+
+	mockUseCase.EXPECT().
+		ListUsers(gomock.Any(), usecase.Criteria{}, usecase.PageRequest{Limit: 2, WithTotal: true}).
+		Return(usecase.Page[entity.UserEntity]{Items: users, Total: &total, HasMore: true, NextCursor: next}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/v0/user?limit=2&total=true", http.NoBody)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("/v0/user")
+
+	r := &userRoutes{usecase: mockUseCase, logger: l, validator: v}
+	err := r.list(c)
+
+	if assert.NoError(t, err) {
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"name":"Ana"`)
+		assert.Contains(t, rec.Body.String(), `"total":7`)
+		assert.Contains(t, rec.Body.String(), `"next_cursor":"`+next.Encode()+`"`)
+		assert.Contains(t, rec.Body.String(), `"has_more":true`)
+		assert.NotContains(t, rec.Body.String(), `"offset"`)
+		assert.Equal(t, `</v0/user?cursor=`+next.Encode()+`&limit=2&total=true>; rel="next"`, rec.Header().Get("Link"))
+	}
+}
+
+func TestListUsers_WithCursor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockUser(ctrl)
+	l := logger.NewLogger("info")
+	v := validator.NewValidator()
+
+	e := echo.New()
+	cursor := usecase.Cursor{Sort: "created_at", Values: []any{"2026-01-01T00:00:00Z"}, ID: uuid.New()}
+
+	mockUseCase.EXPECT().
+		ListUsers(gomock.Any(), usecase.Criteria{}, usecase.PageRequest{Limit: usecase.DefaultPageLimit, Cursor: &cursor}).
+		Return(usecase.Page[entity.UserEntity]{}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/v0/user?cursor="+cursor.Encode(), http.NoBody)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("/v0/user")
+
+	r := &userRoutes{usecase: mockUseCase, logger: l, validator: v}
+	err := r.list(c)
+
+	if assert.NoError(t, err) {
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"data":[]`)
+		assert.Empty(t, rec.Header().Get("Link"))
+	}
+}
+
+func TestListUsers_Offset(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockUser(ctrl)
+	l := logger.NewLogger("info")
+	v := validator.NewValidator()
+
+	e := echo.New()
+	users := []entity.UserEntity{{ID: uuid.New(), Name: "Ana"}}
+
+	mockUseCase.EXPECT().
+		ListUsers(gomock.Any(), usecase.Criteria{}, usecase.PageRequest{Limit: 10, Offset: 20}).
+		Return(usecase.Page[entity.UserEntity]{Items: users, HasMore: true, NextCursor: &usecase.Cursor{Sort: "created_at", Values: []any{time.Now()}, ID: users[0].ID}}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/v0/user?limit=10&offset=20", http.NoBody)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("/v0/user")
+
+	r := &userRoutes{usecase: mockUseCase, logger: l, validator: v}
+	err := r.list(c)
+
+	if assert.NoError(t, err) {
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"offset":20`)
+		assert.Equal(t,
+			`</v0/user?limit=10&offset=30>; rel="next", </v0/user?limit=10&offset=0>; rel="first", </v0/user?limit=10&offset=10>; rel="prev"`,
+			rec.Header().Get("Link"))
+	}
+}
+
+func TestListUsers_FilterAndSort(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockUser(ctrl)
+	l := logger.NewLogger("info")
+	v := validator.NewValidator()
+
+	e := echo.New()
+	criteria := usecase.Criteria{
+		Conditions: []usecase.Condition{
+			{Field: "name", Op: usecase.OpLike, Value: "ana*"},
+			{Field: "created_at", Op: usecase.OpGt, Value: "2026-01-01"},
+		},
+		Sort: []usecase.Order{{Field: "created_at", Desc: true}, {Field: "name"}},
+	}
+	next := &usecase.Cursor{Sort: "-created_at,name", Values: []any{"2026-01-05T00:00:00Z", "Ana"}, ID: uuid.New()}
+
+	mockUseCase.EXPECT().
+		ListUsers(gomock.Any(), criteria, usecase.PageRequest{Limit: usecase.DefaultPageLimit}).
+		Return(usecase.Page[entity.UserEntity]{HasMore: true, NextCursor: next}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/v0/user?filter=name=like=ana*;created_at>2026-01-01&sort=-created_at,name", http.NoBody)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("/v0/user")
+
+	r := &userRoutes{usecase: mockUseCase, logger: l, validator: v}
+	err := r.list(c)
+
+	if assert.NoError(t, err) {
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t,
+			`</v0/user?cursor=`+next.Encode()+`&filter=name%3Dlike%3Dana%2A%3Bcreated_at%3E2026-01-01&limit=20&sort=-created_at%2Cname>; rel="next"`,
+			rec.Header().Get("Link"))
+	}
+}
+
+func TestListUsers_InvalidParams(t *testing.T) {
+	cursor := usecase.Cursor{Sort: "created_at", Values: []any{time.Now()}, ID: uuid.New()}.Encode()
+
+	for _, query := range []string{"limit=abc", "limit=1000", "offset=-1", "cursor=garbage", "offset=10&cursor=" + cursor, "filter=name~ana", "sort=--name"} {
+		t.Run(query, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUseCase := mocks.NewMockUser(ctrl)
+			l := logger.NewLogger("info")
+			v := validator.NewValidator()
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/v0/user?"+query, http.NoBody)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetPath("/v0/user")
+
+			r := &userRoutes{usecase: mockUseCase, logger: l, validator: v}
+			err := r.list(c)
+
+			if assert.Error(t, err) {
+				output.HTTPErrorHandler(l)(err, c)
+				assert.Equal(t, http.StatusBadRequest, rec.Code)
+			}
+		})
+	}
+}
+
+func newPatchContext(e *echo.Echo, id, contentType, body string) (echo.Context, *httptest.ResponseRecorder) {
+	req := httptest.NewRequest(http.MethodPatch, "/v0/user/"+id, strings.NewReader(body))
+	req.Header.Set(headerIfMatch, "*")
+	req.Header.Set(echo.HeaderContentType, contentType)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("/v0/user/:id")
+	c.SetParamNames("id")
+	c.SetParamValues(id)
+	return c, rec
+}
+
+// applyPatch makes the mocked use case run the handler patch on current.
+func applyPatch(current entity.UserEntity) func(context.Context, entity.UserEntity, usecase.UserPatch) (entity.UserEntity, error) {
+	return func(_ context.Context, _ entity.UserEntity, patch usecase.UserPatch) (entity.UserEntity, error) {
+		return patch(current)
+	}
+}
+
+func TestPatchUser(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		expected    string
+	}{
+		{"merge patch", "application/merge-patch+json", `{"name":"Bia"}`, `"name":"Bia","phone":"+5511999999999"`},
+		{"json patch", "application/json-patch+json", `[{"op":"test","path":"/name","value":"Ana"},{"op":"replace","path":"/phone","value":"+5511888888888"}]`, `"name":"Ana","phone":"+5511888888888"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUseCase := mocks.NewMockUser(ctrl)
+			l := logger.NewLogger("info")
+			v := validator.NewValidator()
+
+			e := echo.New()
+			current := entity.UserEntity{ID: uuid.New(), Name: "Ana", Phone: "+5511999999999"}
+
+			mockUseCase.EXPECT().PatchUser(gomock.Any(), entity.UserEntity{ID: current.ID}, gomock.Any()).DoAndReturn(applyPatch(current))
+
+			c, rec := newPatchContext(e, current.ID.String(), tt.contentType, tt.body)
+
+			r := &userRoutes{usecase: mockUseCase, logger: l, validator: v}
+			err := r.patch(c)
+
+			if assert.NoError(t, err) {
+				assert.Equal(t, http.StatusOK, rec.Code)
+				assert.Contains(t, rec.Body.String(), tt.expected)
+			}
+		})
+	}
+}
+
+func TestPatchUser_PatchErrors(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		status      int
+	}{
+		{"test operation failed", "application/json-patch+json", `[{"op":"test","path":"/name","value":"Bia"}]`, http.StatusConflict},
+		{"missing path", "application/json-patch+json", `[{"op":"replace","path":"/email","value":"a@b.c"}]`, http.StatusUnprocessableEntity},
+		{"unknown field", "application/merge-patch+json", `{"role":"admin"}`, http.StatusUnprocessableEntity},
+		{"invalid result", "application/merge-patch+json", `{"name":null}`, http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUseCase := mocks.NewMockUser(ctrl)
+			l := logger.NewLogger("info")
+			v := validator.NewValidator()
+
+			e := echo.New()
+			current := entity.UserEntity{ID: uuid.New(), Name: "Ana", Phone: "+5511999999999"}
+
+			mockUseCase.EXPECT().PatchUser(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(applyPatch(current))
+
+			c, rec := newPatchContext(e, current.ID.String(), tt.contentType, tt.body)
+
+			r := &userRoutes{usecase: mockUseCase, logger: l, validator: v}
+			err := r.patch(c)
+
+			if assert.Error(t, err) {
+				output.HTTPErrorHandler(l)(err, c)
+				assert.Equal(t, tt.status, rec.Code)
+			}
+		})
+	}
+}
+
+func TestPatchUser_InvalidRequest(t *testing.T) {
+	tests := []struct {
+		name        string
+		id          string
+		contentType string
+		body        string
+		status      int
+	}{
+		{"invalid uuid", "invalid-uuid", "application/merge-patch+json", `{}`, http.StatusBadRequest},
+		{"unsupported media type", uuid.NewString(), "application/json", `{"name":"Bia"}`, http.StatusUnsupportedMediaType},
+		{"invalid merge patch", uuid.NewString(), "application/merge-patch+json", `[]`, http.StatusBadRequest},
+		{"invalid json patch", uuid.NewString(), "application/json-patch+json", `{"op":"add"}`, http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUseCase := mocks.NewMockUser(ctrl)
+			l := logger.NewLogger("info")
+			v := validator.NewValidator()
+
+			e := echo.New()
+			c, rec := newPatchContext(e, tt.id, tt.contentType, tt.body)
+
+			r := &userRoutes{usecase: mockUseCase, logger: l, validator: v}
+			if err := r.patch(c); err != nil {
+				output.HTTPErrorHandler(l)(err, c)
+			}
+
+			assert.Equal(t, tt.status, rec.Code)
+		})
+	}
+}
+
+func TestPatchUser_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockUser(ctrl)
+	l := logger.NewLogger("info")
+	v := validator.NewValidator()
+
+	e := echo.New()
+	id := uuid.New()
+
+	mockUseCase.EXPECT().PatchUser(gomock.Any(), entity.UserEntity{ID: id}, gomock.Any()).Return(entity.UserEntity{}, apperror.NotFound("user not found", nil))
+
+	c, rec := newPatchContext(e, id.String(), "application/merge-patch+json", `{"name":"Bia"}`)
+
+	r := &userRoutes{usecase: mockUseCase, logger: l, validator: v}
+	err := r.patch(c)
+
+	if assert.Error(t, err) {
+		output.HTTPErrorHandler(l)(err, c)
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	}
+}
+
+func TestPatchUser_IfMatch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockUser(ctrl)
+	l := logger.NewLogger("info")
+	v := validator.NewValidator()
+
+	e := echo.New()
+	current := entity.UserEntity{ID: uuid.New(), Name: "Ana", Phone: "+5511999999999", Version: 3}
+
+	mockUseCase.EXPECT().PatchUser(gomock.Any(), entity.UserEntity{ID: current.ID, Version: 3}, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ entity.UserEntity, patch usecase.UserPatch) (entity.UserEntity, error) {
+			u, err := patch(current)
+			u.Version++
+			return u, err
+		})
+
+	c, rec := newPatchContext(e, current.ID.String(), "application/merge-patch+json", `{"name":"Bia"}`)
+	c.Request().Header.Set(headerIfMatch, `"3"`)
+
+	r := &userRoutes{usecase: mockUseCase, logger: l, validator: v}
+	err := r.patch(c)
+
+	if assert.NoError(t, err) {
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, `"4"`, rec.Header().Get(headerETag))
+	}
+}
+
+func TestUpdateUser_PreconditionFailed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockUser(ctrl)
+	l := logger.NewLogger("info")
+	v := validator.NewValidator()
+
+	e := echo.New()
+	userID := uuid.New()
+
+	mockUseCase.EXPECT().UpdateUser(gomock.Any(), entity.UserEntity{ID: userID, Name: "User Name", Phone: "+5511999999999", Version: 2}).
+		Return(fmt.Errorf("UpdateUser: %w", apperror.Wrap(nil, apperror.KindPreconditionFailed, "user_version_mismatch", "user has been modified since it was read")))
+
+	req := httptest.NewRequest(http.MethodPut, "/v0/user/"+userID.String(), strings.NewReader(`{"name": "User Name", "phone": "+5511999999999"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	req.Header.Set(headerIfMatch, `"2"`)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("/v0/user/:id")
+	c.SetParamNames("id")
+	c.SetParamValues(userID.String())
+
+	r := &userRoutes{usecase: mockUseCase, logger: l, validator: v}
+	err := r.update(c)
+
+	if assert.Error(t, err) {
+		output.HTTPErrorHandler(l)(err, c)
+		assert.Equal(t, http.StatusPreconditionFailed, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"code":"user_version_mismatch"`)
+	}
+}
+
+func TestBindIfMatch(t *testing.T) {
+	tests := []struct {
+		name    string
+		header  string
+		version int64
+		status  int
+	}{
+		{"missing", "", 0, http.StatusPreconditionRequired},
+		{"any", "*", 0, 0},
+		{"strong", `"12"`, 12, 0},
+		{"weak", `W/"12"`, 0, http.StatusPreconditionFailed},
+		{"unknown tag", `"abc"`, 0, http.StatusPreconditionFailed},
+		{"unquoted", "12", 0, http.StatusBadRequest},
+		{"list", `"1", "2"`, 0, http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := logger.NewLogger("info")
+			e := echo.New()
+
+			req := httptest.NewRequest(http.MethodDelete, "/v0/user/"+uuid.NewString(), http.NoBody)
+			if tt.header != "" {
+				req.Header.Set(headerIfMatch, tt.header)
+			}
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			version, err := bindIfMatch(c)
+			if tt.status == 0 {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.version, version)
+				return
+			}
+
+			if assert.Error(t, err) {
+				output.HTTPErrorHandler(l)(err, c)
+				assert.Equal(t, tt.status, rec.Code)
+			}
+		})
+	}
+}
+
+func TestListDeletedUsers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockUser(ctrl)
+	l := logger.NewLogger("info")
+	v := validator.NewValidator()
+
+	e := echo.New()
+	NewUserRoutes(e, l, v, mockUseCase)
+	deletedAt := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	users := []entity.UserEntity{{ID: uuid.New(), Name: "Ana", DeletedAt: &deletedAt}}
+
+	mockUseCase.EXPECT().
+		ListDeletedUsers(gomock.Any(),
+			usecase.Criteria{Conditions: []usecase.Condition{{Field: "deleted_at", Op: usecase.OpLt, Value: "2026-04-01"}}},
+			usecase.PageRequest{Limit: usecase.DefaultPageLimit}).
+		Return(usecase.Page[entity.UserEntity]{Items: users}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/v0/user/deleted?filter=deleted_at<2026-04-01", http.NoBody)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"name":"Ana"`)
+	assert.Contains(t, rec.Body.String(), `"deleted_at":"2026-03-01T00:00:00Z"`)
+}
+
+func TestRestoreUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockUser(ctrl)
+	l := logger.NewLogger("info")
+	v := validator.NewValidator()
+
+	e := echo.New()
+	userID := uuid.New()
+
+	mockUseCase.EXPECT().
+		RestoreUser(gomock.Any(), entity.UserEntity{ID: userID}).
+		Return(entity.UserEntity{ID: userID, Name: "Ana", Version: 4}, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/v0/user/"+userID.String()+"/restore", http.NoBody)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("/v0/user/:id/restore")
+	c.SetParamNames("id")
+	c.SetParamValues(userID.String())
+
+	r := &userRoutes{usecase: mockUseCase, logger: l, validator: v}
+	err := r.restore(c)
+
+	if assert.NoError(t, err) {
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, `"4"`, rec.Header().Get(headerETag))
+		assert.Contains(t, rec.Body.String(), `"name":"Ana"`)
+	}
+}
+
+func TestRestoreUser_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockUser(ctrl)
+	l := logger.NewLogger("info")
+	v := validator.NewValidator()
+
+	e := echo.New()
+	e.HTTPErrorHandler = output.HTTPErrorHandler(l)
+	NewUserRoutes(e, l, v, mockUseCase)
+	userID := uuid.New()
+
+	mockUseCase.EXPECT().
+		RestoreUser(gomock.Any(), entity.UserEntity{ID: userID}).
+		Return(entity.UserEntity{}, apperror.Wrap(nil, apperror.KindNotFound, "deleted_user_not_found", "deleted user not found"))
+
+	req := httptest.NewRequest(http.MethodPost, "/v0/user/"+userID.String()+"/restore", http.NoBody)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"deleted_user_not_found"`)
+}
+
+func TestPurgeUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockUser(ctrl)
+	l := logger.NewLogger("info")
+	v := validator.NewValidator()
+
+	e := echo.New()
+	e.HTTPErrorHandler = output.HTTPErrorHandler(l)
+	NewUserRoutes(e, l, v, mockUseCase)
+	userID := uuid.New()
+
+	mockUseCase.EXPECT().PurgeUser(gomock.Any(), entity.UserEntity{ID: userID}).Return(nil)
+
+	req := httptest.NewRequest(http.MethodDelete, "/v0/user/deleted/"+userID.String(), http.NoBody)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"message":"Successfully purged"`)
+
+	req = httptest.NewRequest(http.MethodDelete, "/v0/user/deleted/not-a-uuid", http.NoBody)
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestPurgeDeletedUsers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockUser(ctrl)
+	l := logger.NewLogger("info")
+	v := validator.NewValidator()
+
+	e := echo.New()
+	e.HTTPErrorHandler = output.HTTPErrorHandler(l)
+	NewUserRoutes(e, l, v, mockUseCase)
+
+	mockUseCase.EXPECT().
+		PurgeDeletedUsers(gomock.Any(), time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)).
+		Return(int64(12), nil)
+
+	req := httptest.NewRequest(http.MethodDelete, "/v0/user/deleted?before=2026-01-01T00:00:00Z", http.NoBody)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"purged":12}`, rec.Body.String())
+
+	for _, target := range []string{"/v0/user/deleted", "/v0/user/deleted?before=yesterday"} {
+		req = httptest.NewRequest(http.MethodDelete, target, http.NoBody)
+		rec = httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code, target)
+	}
+}
+
+func TestUserHistory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockUser(ctrl)
+	l := logger.NewLogger("info")
+	v := validator.NewValidator()
+
+	e := echo.New()
+	e.HTTPErrorHandler = output.HTTPErrorHandler(l)
+	NewUserRoutes(e, l, v, mockUseCase)
+	userID := uuid.New()
+	eventID := uuid.New()
+	createdAt := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	mockUseCase.EXPECT().
+		UserHistory(gomock.Any(), entity.UserEntity{ID: userID}, usecase.PageRequest{Limit: 1, Offset: 1}).
+		Return(usecase.Page[entity.AuditEvent]{
+			Items: []entity.AuditEvent{{
+				ID: eventID, EntityType: "user", EntityID: userID, Action: entity.AuditUpdate, Actor: "anonymous", RequestID: "req-1",
+				Changes: []entity.FieldChange{{Field: "name", Old: "Ana", New: "Bia"}}, CreatedAt: createdAt,
+			}},
+			HasMore: true,
+		}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/v0/user/"+userID.String()+"/history?limit=1&offset=1", http.NoBody)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{
+		"data": [{
+			"id": "`+eventID.String()+`",
+			"action": "update",
+			"actor": "anonymous",
+			"request_id": "req-1",
+			"changes": [{"field": "name", "old": "Ana", "new": "Bia"}],
+			"created_at": "2026-03-01T10:00:00Z"
+		}],
+		"meta": {"limit": 1, "offset": 1, "has_more": true}
+	}`, rec.Body.String())
+
+	req = httptest.NewRequest(http.MethodGet, "/v0/user/not-a-uuid/history", http.NoBody)
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
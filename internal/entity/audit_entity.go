@@ -0,0 +1,39 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type AuditAction string
+
+const (
+	AuditCreate  AuditAction = "create"
+	AuditUpdate  AuditAction = "update"
+	AuditDelete  AuditAction = "delete"
+	AuditRestore AuditAction = "restore"
+	AuditPurge   AuditAction = "purge"
+)
+
+// AuditEvent records one mutation of an entity: who made it, in which
+// request, and how each field changed.
+type AuditEvent struct {
+	ID         uuid.UUID
+	EntityType string
+	EntityID   uuid.UUID
+	Action     AuditAction
+	Actor      string
+	RequestID  string
+	Changes    []FieldChange
+	CreatedAt  time.Time
+}
+
+// FieldChange is the value of a field before and after a mutation. Old is
+// nil when the entity did not exist (or was deleted) before, New when it
+// does not exist after.
+type FieldChange struct {
+	Field string
+	Old   any
+	New   any
+}
@@ -0,0 +1,38 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type EventType string
+
+const (
+	UserCreated  EventType = "user.created"
+	UserUpdated  EventType = "user.updated"
+	UserDeleted  EventType = "user.deleted"
+	UserRestored EventType = "user.restored"
+	UserPurged   EventType = "user.purged"
+)
+
+// Event is a domain event. Payload is the JSON encoded state of the
+// aggregate the event is about; ID lets consumers drop redeliveries.
+type Event struct {
+	ID            uuid.UUID
+	Type          EventType
+	AggregateType string
+	AggregateID   uuid.UUID
+	Payload       []byte
+	OccurredAt    time.Time
+}
+
+// OutboxMessage is an event stored in the outbox along with its delivery
+// state.
+type OutboxMessage struct {
+	Event
+	Attempts      int
+	NextAttemptAt time.Time
+	PublishedAt   *time.Time
+	LastError     string
+}
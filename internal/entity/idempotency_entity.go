@@ -0,0 +1,22 @@
+package entity
+
+import "time"
+
+// IdempotencyRecord tracks a request sent with an Idempotency-Key. It is in
+// flight until the response is stored, after which retries replay it. Keys
+// are scoped to the actor, so that clients picking the same key never meet.
+type IdempotencyRecord struct {
+	Actor       string
+	Key         string
+	Fingerprint string
+	StatusCode  int
+	Header      map[string][]string
+	Body        []byte
+	CreatedAt   time.Time
+	ExpiresAt   time.Time
+}
+
+// Completed reports whether the response of the original request is stored.
+func (r IdempotencyRecord) Completed() bool {
+	return r.StatusCode != 0
+}
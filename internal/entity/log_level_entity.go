@@ -0,0 +1,19 @@
+package entity
+
+import "time"
+
+// Log levels, from the most verbose.
+const (
+	LogLevelDebug = "debug"
+	LogLevelInfo  = "info"
+	LogLevelWarn  = "warn"
+	LogLevelError = "error"
+)
+
+// LogLevel is the minimum level of the application logs. Level differs from
+// the Configured one while a temporary override is active, until RevertAt.
+type LogLevel struct {
+	Level      string
+	Configured string
+	RevertAt   *time.Time
+}
@@ -0,0 +1,25 @@
+package entity
+
+import "slices"
+
+// Roles known to the authorization policy.
+const (
+	RoleAdmin   = "admin"
+	RoleSupport = "support"
+)
+
+// Principal is the authenticated caller of a request. Roles say who the
+// caller is; scopes, when present, list what its credentials grant.
+type Principal struct {
+	Subject string
+	Roles   []string
+	Scopes  []string
+}
+
+func (p Principal) HasRole(role string) bool {
+	return slices.Contains(p.Roles, role)
+}
+
+func (p Principal) HasScope(scope string) bool {
+	return slices.Contains(p.Scopes, scope)
+}
@@ -0,0 +1,62 @@
+package entity
+
+import (
+	"math"
+	"time"
+)
+
+// RateLimit is a token bucket holding up to Burst tokens, refilled at Rate
+// tokens per second. Every request takes one token.
+type RateLimit struct {
+	Rate  float64
+	Burst int
+}
+
+// TokenBucket is the state of a rate limit for one client. A zero bucket is
+// full.
+type TokenBucket struct {
+	Tokens    float64
+	UpdatedAt time.Time
+}
+
+// RateLimitDecision is the outcome of taking a token from a bucket.
+type RateLimitDecision struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	// Reset is the time until the bucket is full again.
+	Reset time.Duration
+	// RetryAfter is the time until the next token, when not allowed.
+	RetryAfter time.Duration
+}
+
+// Take refills the bucket for the time elapsed since its last update and
+// takes a token from it, if there is one.
+func (b TokenBucket) Take(l RateLimit, now time.Time) (TokenBucket, RateLimitDecision) {
+	burst := float64(l.Burst)
+	tokens := burst
+	if !b.UpdatedAt.IsZero() {
+		tokens = math.Min(burst, b.Tokens+math.Max(0, now.Sub(b.UpdatedAt).Seconds())*l.Rate)
+		// Clocks of replicas sharing a bucket may disagree a little: never
+		// move a bucket back in time, or its refill would be counted twice.
+		if now.Before(b.UpdatedAt) {
+			now = b.UpdatedAt
+		}
+	}
+
+	d := RateLimitDecision{Limit: l.Burst}
+	if tokens >= 1 {
+		tokens--
+		d.Allowed = true
+	} else {
+		d.RetryAfter = seconds((1 - tokens) / l.Rate)
+	}
+	d.Remaining = int(tokens)
+	d.Reset = seconds((burst - tokens) / l.Rate)
+
+	return TokenBucket{Tokens: tokens, UpdatedAt: now}, d
+}
+
+func seconds(s float64) time.Duration {
+	return time.Duration(s * float64(time.Second))
+}
@@ -13,4 +13,5 @@ type UserEntity struct {
 	CreatedAt time.Time
 	UpdatedAt time.Time
 	DeletedAt *time.Time
+	Version   int64
 }
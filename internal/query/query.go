@@ -0,0 +1,174 @@
+package query
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/apperror"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/usecase"
+)
+
+// operators are matched in order, so longer tokens must come first.
+var operators = []struct {
+	token string
+	op    usecase.Operator
+}{
+	{"=like=", usecase.OpLike},
+	{"=gt=", usecase.OpGt},
+	{"=ge=", usecase.OpGte},
+	{"=lt=", usecase.OpLt},
+	{"=le=", usecase.OpLte},
+	{"=ne=", usecase.OpNe},
+	{"==", usecase.OpEq},
+	{"!=", usecase.OpNe},
+	{">=", usecase.OpGte},
+	{"<=", usecase.OpLte},
+	{">", usecase.OpGt},
+	{"<", usecase.OpLt},
+	{"=", usecase.OpEq},
+}
+
+// Values parses a raw query string like url.ParseQuery but only splits on
+// '&', so unescaped ';' in filter expressions are kept instead of the whole
+// pair being dropped.
+func Values(rawQuery string) url.Values {
+	values := url.Values{}
+	for _, pair := range strings.Split(rawQuery, "&") {
+		if pair == "" {
+			continue
+		}
+		key, value, _ := strings.Cut(pair, "=")
+		key, err := url.QueryUnescape(key)
+		if err != nil {
+			continue
+		}
+		value, err = url.QueryUnescape(value)
+		if err != nil {
+			continue
+		}
+		values.Add(key, value)
+	}
+	return values
+}
+
+// ParseFilter parses a filter expression made of ';' separated clauses,
+// e.g. "name=like=ana*;created_at>2026-01-01". Values may be quoted with
+// single or double quotes to contain ';'. Field names are not checked here,
+// the use case rejects the ones it does not know.
+func ParseFilter(expr string) ([]usecase.Condition, error) {
+	if strings.TrimSpace(expr) == "" {
+		return nil, nil
+	}
+
+	clauses, err := split(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	conds := make([]usecase.Condition, 0, len(clauses))
+	for _, c := range clauses {
+		cond, err := parseClause(c)
+		if err != nil {
+			return nil, err
+		}
+		conds = append(conds, cond)
+	}
+	return conds, nil
+}
+
+// ParseSort parses a comma separated list of fields, each optionally
+// prefixed by '-' for descending or '+' for ascending order.
+func ParseSort(expr string) ([]usecase.Order, error) {
+	if strings.TrimSpace(expr) == "" {
+		return nil, nil
+	}
+
+	fields := strings.Split(expr, ",")
+	order := make([]usecase.Order, 0, len(fields))
+	for _, f := range fields {
+		f = strings.TrimSpace(f)
+		o := usecase.Order{Field: strings.TrimLeft(f, "+-"), Desc: strings.HasPrefix(f, "-")}
+		if !isIdent(o.Field) || len(f)-len(o.Field) > 1 {
+			return nil, apperror.Validation(fmt.Sprintf("invalid sort field %q", f), nil)
+		}
+		order = append(order, o)
+	}
+	return order, nil
+}
+
+func parseClause(c string) (usecase.Condition, error) {
+	c = strings.TrimSpace(c)
+
+	end := 0
+	for end < len(c) && isIdentChar(c[end]) {
+		end++
+	}
+	field := c[:end]
+	if !isIdent(field) {
+		return usecase.Condition{}, apperror.Validation(fmt.Sprintf("invalid filter clause %q", c), nil)
+	}
+
+	rest := strings.TrimLeft(c[end:], " ")
+	for _, o := range operators {
+		if !strings.HasPrefix(rest, o.token) {
+			continue
+		}
+		value := unquote(strings.TrimSpace(rest[len(o.token):]))
+		if value == "" {
+			return usecase.Condition{}, apperror.Validation(fmt.Sprintf("missing value in filter clause %q", c), nil)
+		}
+		return usecase.Condition{Field: field, Op: o.op, Value: value}, nil
+	}
+
+	return usecase.Condition{}, apperror.Validation(fmt.Sprintf("unknown operator in filter clause %q", c), nil)
+}
+
+// split cuts the expression on ';' outside of quotes.
+func split(expr string) ([]string, error) {
+	var (
+		parts []string
+		quote byte
+		start int
+	)
+	for i := 0; i < len(expr); i++ {
+		switch ch := expr[i]; {
+		case quote != 0:
+			if ch == quote {
+				quote = 0
+			}
+		case ch == '\'' || ch == '"':
+			quote = ch
+		case ch == ';':
+			parts = append(parts, expr[start:i])
+			start = i + 1
+		}
+	}
+	if quote != 0 {
+		return nil, apperror.Validation("unterminated quote in filter", nil)
+	}
+	return append(parts, expr[start:]), nil
+}
+
+func unquote(s string) string {
+	if len(s) >= 2 && (s[0] == '\'' || s[0] == '"') && s[len(s)-1] == s[0] {
+		return s[1 : len(s)-1]
+	}
+	return s
+}
+
+func isIdent(s string) bool {
+	if s == "" || (s[0] >= '0' && s[0] <= '9') {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if !isIdentChar(s[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func isIdentChar(ch byte) bool {
+	return ch == '_' || (ch >= 'a' && ch <= 'z') || (ch >= 'A' && ch <= 'Z') || (ch >= '0' && ch <= '9')
+}
@@ -0,0 +1,78 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/apperror"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFilter(t *testing.T) {
+	conds, err := ParseFilter(`name=like=ana*;created_at>2026-01-01; phone == "+55;11" ;updated_at=le=2026-02-01T00:00:00Z;name!=bob`)
+
+	require.NoError(t, err)
+	assert.Equal(t, []usecase.Condition{
+		{Field: "name", Op: usecase.OpLike, Value: "ana*"},
+		{Field: "created_at", Op: usecase.OpGt, Value: "2026-01-01"},
+		{Field: "phone", Op: usecase.OpEq, Value: "+55;11"},
+		{Field: "updated_at", Op: usecase.OpLte, Value: "2026-02-01T00:00:00Z"},
+		{Field: "name", Op: usecase.OpNe, Value: "bob"},
+	}, conds)
+}
+
+func TestParseFilter_Operators(t *testing.T) {
+	tests := map[string]usecase.Operator{
+		"a==1": usecase.OpEq, "a=1": usecase.OpEq, "a!=1": usecase.OpNe, "a=ne=1": usecase.OpNe,
+		"a>1": usecase.OpGt, "a=gt=1": usecase.OpGt, "a>=1": usecase.OpGte, "a=ge=1": usecase.OpGte,
+		"a<1": usecase.OpLt, "a=lt=1": usecase.OpLt, "a<=1": usecase.OpLte, "a=le=1": usecase.OpLte,
+		"a=like=1*": usecase.OpLike,
+	}
+	for expr, op := range tests {
+		conds, err := ParseFilter(expr)
+		require.NoError(t, err, expr)
+		assert.Equal(t, op, conds[0].Op, expr)
+	}
+}
+
+func TestParseFilter_Empty(t *testing.T) {
+	conds, err := ParseFilter("  ")
+	assert.NoError(t, err)
+	assert.Nil(t, conds)
+}
+
+func TestParseFilter_Invalid(t *testing.T) {
+	for _, expr := range []string{"name", "name~ana", "=ana", "1name==a", "name==", `name=="ana`, "name==a;;phone==b"} {
+		_, err := ParseFilter(expr)
+		assert.ErrorIs(t, err, apperror.ErrValidation, expr)
+	}
+}
+
+func TestParseSort(t *testing.T) {
+	order, err := ParseSort("-created_at, name,+updated_at")
+
+	require.NoError(t, err)
+	assert.Equal(t, []usecase.Order{
+		{Field: "created_at", Desc: true},
+		{Field: "name"},
+		{Field: "updated_at"},
+	}, order)
+}
+
+func TestParseSort_Invalid(t *testing.T) {
+	for _, expr := range []string{"--name", "name,", "-", "na me"} {
+		_, err := ParseSort(expr)
+		assert.ErrorIs(t, err, apperror.ErrValidation, expr)
+	}
+}
+
+func TestValues(t *testing.T) {
+	v := Values("filter=name=like=ana*;phone==1&sort=-name&empty=&&bad=%zz&limit=10")
+
+	assert.Equal(t, "name=like=ana*;phone==1", v.Get("filter"))
+	assert.Equal(t, "-name", v.Get("sort"))
+	assert.Equal(t, "10", v.Get("limit"))
+	assert.True(t, v.Has("empty"))
+	assert.False(t, v.Has("bad"))
+}
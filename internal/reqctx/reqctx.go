@@ -0,0 +1,62 @@
+// Package reqctx carries request metadata, such as the request ID and the
+// acting principal, across layers in a context.Context.
+package reqctx
+
+import (
+	"context"
+
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/entity"
+)
+
+type ctxKey int
+
+const (
+	requestIDKey ctxKey = iota
+	actorKey
+	principalKey
+	routeKey
+)
+
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey, id)
+}
+
+// RequestID returns the ID of the request being served, or "".
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey).(string)
+	return id
+}
+
+// WithRoute stores the route being served, such as "GET /v0/user/:id" or a
+// full gRPC method name.
+func WithRoute(ctx context.Context, route string) context.Context {
+	return context.WithValue(ctx, routeKey, route)
+}
+
+// Route returns the route being served, or "".
+func Route(ctx context.Context) string {
+	route, _ := ctx.Value(routeKey).(string)
+	return route
+}
+
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorKey, actor)
+}
+
+// Actor returns who is performing the request, or "" when unknown.
+func Actor(ctx context.Context) string {
+	actor, _ := ctx.Value(actorKey).(string)
+	return actor
+}
+
+// WithPrincipal stores the authenticated caller. Its subject also becomes
+// the actor.
+func WithPrincipal(ctx context.Context, p entity.Principal) context.Context {
+	return WithActor(context.WithValue(ctx, principalKey, p), p.Subject)
+}
+
+// Principal returns the authenticated caller, if the request carries one.
+func Principal(ctx context.Context) (entity.Principal, bool) {
+	p, ok := ctx.Value(principalKey).(entity.Principal)
+	return p, ok
+}
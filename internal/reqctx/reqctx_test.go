@@ -0,0 +1,38 @@
+package reqctx
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestID(t *testing.T) {
+	ctx := context.Background()
+	assert.Empty(t, RequestID(ctx))
+	assert.Equal(t, "req-1", RequestID(WithRequestID(ctx, "req-1")))
+}
+
+func TestRoute(t *testing.T) {
+	ctx := context.Background()
+	assert.Empty(t, Route(ctx))
+	assert.Equal(t, "GET /v0/user/:id", Route(WithRoute(ctx, "GET /v0/user/:id")))
+}
+
+func TestActor(t *testing.T) {
+	ctx := context.Background()
+	assert.Empty(t, Actor(ctx))
+	assert.Equal(t, "cli:ops", Actor(WithActor(ctx, "cli:ops")))
+}
+
+func TestPrincipal(t *testing.T) {
+	_, ok := Principal(context.Background())
+	assert.False(t, ok)
+
+	ctx := WithPrincipal(context.Background(), entity.Principal{Subject: "user-1", Roles: []string{"admin"}})
+	p, ok := Principal(ctx)
+	assert.True(t, ok)
+	assert.Equal(t, entity.Principal{Subject: "user-1", Roles: []string{"admin"}}, p)
+	assert.Equal(t, "user-1", Actor(ctx))
+}
@@ -0,0 +1,192 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/apperror"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/entity"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/reqctx"
+	"github.com/google/uuid"
+)
+
+const (
+	// An API key reads "ak_<prefix>.<secret>". The prefix identifies the
+	// key and may be logged; the secret is only known to the client.
+	apiKeyPrefix      = "ak_"
+	apiKeyPrefixBytes = 6
+	apiKeySecretBytes = 32
+
+	// apiKeySubjectPrefix starts the subject of API key principals.
+	apiKeySubjectPrefix = "apikey:"
+
+	// apiKeyTouchInterval bounds how often the last use of a key is written.
+	apiKeyTouchInterval = time.Minute
+)
+
+// APIKeyScopes are the scopes an API key may be granted.
+var APIKeyScopes = []string{ScopeUsersRead, ScopeUsersWrite, ScopeUsersDelete}
+
+type APIKeyUseCase struct {
+	repo  APIKeyRepo
+	authz Authorizer
+}
+
+// NewAPIKey returns the API key use case. Managing keys requires the
+// PermManageAPIKeys permission; authenticating with one does not.
+func NewAPIKey(r APIKeyRepo, authz Authorizer) *APIKeyUseCase {
+	return &APIKeyUseCase{repo: r, authz: authz}
+}
+
+// CreateAPIKey issues a key with the name, scopes and optional expiry of
+// key. Only a hash of the returned secret is stored.
+func (uc *APIKeyUseCase) CreateAPIKey(ctx context.Context, key entity.APIKey) (entity.APIKey, string, error) {
+
+	if err := uc.authz.Authorize(ctx, PermManageAPIKeys, uuid.Nil); err != nil {
+		return entity.APIKey{}, "", fmt.Errorf("CreateAPIKey: %w", err)
+	}
+
+	now := time.Now().UTC()
+	if err := checkAPIKey(key, now); err != nil {
+		return entity.APIKey{}, "", fmt.Errorf("CreateAPIKey: %w", err)
+	}
+
+	prefix, secret, err := newAPIKeySecret()
+	if err != nil {
+		return entity.APIKey{}, "", fmt.Errorf("CreateAPIKey: %w", err)
+	}
+
+	actor := reqctx.Actor(ctx)
+	if actor == "" {
+		actor = AnonymousActor
+	}
+	created, err := uc.repo.Create(ctx, entity.APIKey{
+		Name:      key.Name,
+		Prefix:    prefix,
+		Hash:      hashAPIKey(secret),
+		Scopes:    slices.Compact(slices.Sorted(slices.Values(key.Scopes))),
+		ExpiresAt: key.ExpiresAt,
+		CreatedBy: actor,
+		CreatedAt: now,
+	})
+	if err != nil {
+		return entity.APIKey{}, "", fmt.Errorf("CreateAPIKey: %w", err)
+	}
+
+	return created, secret, nil
+}
+
+// ListAPIKeys returns every key, revoked and expired ones included.
+func (uc *APIKeyUseCase) ListAPIKeys(ctx context.Context) ([]entity.APIKey, error) {
+
+	if err := uc.authz.Authorize(ctx, PermManageAPIKeys, uuid.Nil); err != nil {
+		return nil, fmt.Errorf("ListAPIKeys: %w", err)
+	}
+
+	keys, err := uc.repo.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("ListAPIKeys: %w", err)
+	}
+
+	return keys, nil
+}
+
+// RevokeAPIKey makes a key unusable from now on.
+func (uc *APIKeyUseCase) RevokeAPIKey(ctx context.Context, id uuid.UUID) error {
+
+	if err := uc.authz.Authorize(ctx, PermManageAPIKeys, uuid.Nil); err != nil {
+		return fmt.Errorf("RevokeAPIKey: %w", err)
+	}
+
+	if err := uc.repo.Revoke(ctx, id, time.Now().UTC()); err != nil {
+		return fmt.Errorf("RevokeAPIKey: %w", apiKeyError(err))
+	}
+
+	return nil
+}
+
+// AuthenticateAPIKey checks a key presented by a client and returns its
+// principal, which holds the scopes of the key and no role.
+func (uc *APIKeyUseCase) AuthenticateAPIKey(ctx context.Context, key string) (entity.Principal, error) {
+
+	prefix, _, ok := strings.Cut(key, ".")
+	if !ok || !strings.HasPrefix(prefix, apiKeyPrefix) {
+		return entity.Principal{}, fmt.Errorf("AuthenticateAPIKey: %w", apperror.Unauthorized("invalid API key", nil))
+	}
+
+	stored, err := uc.repo.GetByPrefix(ctx, prefix)
+	if apperror.KindOf(err) == apperror.KindNotFound {
+		return entity.Principal{}, fmt.Errorf("AuthenticateAPIKey: %w", apperror.Unauthorized("invalid API key", nil))
+	}
+	if err != nil {
+		return entity.Principal{}, fmt.Errorf("AuthenticateAPIKey: %w", err)
+	}
+	if subtle.ConstantTimeCompare(stored.Hash, hashAPIKey(key)) != 1 {
+		return entity.Principal{}, fmt.Errorf("AuthenticateAPIKey: %w", apperror.Unauthorized("invalid API key", nil))
+	}
+
+	now := time.Now().UTC()
+	if stored.RevokedAt != nil {
+		return entity.Principal{}, fmt.Errorf("AuthenticateAPIKey: %w", apperror.Unauthorized("API key revoked", nil))
+	}
+	if !stored.Active(now) {
+		return entity.Principal{}, fmt.Errorf("AuthenticateAPIKey: %w", apperror.Unauthorized("API key expired", nil))
+	}
+
+	if err := uc.repo.Touch(ctx, stored.ID, now, now.Add(-apiKeyTouchInterval)); err != nil {
+		return entity.Principal{}, fmt.Errorf("AuthenticateAPIKey: %w", err)
+	}
+
+	return entity.Principal{Subject: apiKeySubjectPrefix + stored.ID.String(), Scopes: stored.Scopes}, nil
+}
+
+func checkAPIKey(key entity.APIKey, now time.Time) error {
+	if strings.TrimSpace(key.Name) == "" {
+		return apperror.Validation("an API key needs a name", nil)
+	}
+	if len(key.Scopes) == 0 {
+		return apperror.Validation("an API key needs at least one scope", nil)
+	}
+	for _, s := range key.Scopes {
+		if !slices.Contains(APIKeyScopes, s) {
+			return apperror.Validation(fmt.Sprintf("unknown scope %q, use one of %s", s, strings.Join(APIKeyScopes, ", ")), nil)
+		}
+	}
+	if key.ExpiresAt != nil && !key.ExpiresAt.After(now) {
+		return apperror.Validation("expires_at must be in the future", nil)
+	}
+	return nil
+}
+
+// newAPIKeySecret returns the prefix of a new key and the full key.
+func newAPIKeySecret() (string, string, error) {
+	b := make([]byte, apiKeyPrefixBytes+apiKeySecretBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", err
+	}
+	prefix := apiKeyPrefix + hex.EncodeToString(b[:apiKeyPrefixBytes])
+	return prefix, prefix + "." + base64.RawURLEncoding.EncodeToString(b[apiKeyPrefixBytes:]), nil
+}
+
+// hashAPIKey hashes a full key. The secret holds 256 random bits, so a fast
+// hash is enough: there is nothing to brute force.
+func hashAPIKey(key string) []byte {
+	sum := sha256.Sum256([]byte(key))
+	return sum[:]
+}
+
+// apiKeyError gives repository errors an API key specific code and message.
+func apiKeyError(err error) error {
+	if apperror.KindOf(err) == apperror.KindNotFound {
+		return apperror.Wrap(err, apperror.KindNotFound, "api_key_not_found", "API key not found or already revoked")
+	}
+	return err
+}
@@ -0,0 +1,163 @@
+package usecase_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/apperror"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/entity"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/reqctx"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/usecase"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func adminContext() context.Context {
+	return reqctx.WithPrincipal(context.Background(), entity.Principal{Subject: "admin-1", Roles: []string{entity.RoleAdmin}})
+}
+
+// issueAPIKey creates a key through the use case and returns the stored key
+// along with its secret.
+func issueAPIKey(t *testing.T, key entity.APIKey) (entity.APIKey, string) {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	mockRepo := mocks.NewMockAPIKeyRepo(ctrl)
+	mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, k entity.APIKey) (entity.APIKey, error) {
+		k.ID = uuid.New()
+		return k, nil
+	})
+
+	stored, secret, err := usecase.NewAPIKey(mockRepo, usecase.DefaultPolicy).CreateAPIKey(adminContext(), key)
+	require.NoError(t, err)
+	return stored, secret
+}
+
+func TestCreateAPIKey(t *testing.T) {
+	stored, secret := issueAPIKey(t, entity.APIKey{Name: "partner", Scopes: []string{usecase.ScopeUsersWrite, usecase.ScopeUsersRead, usecase.ScopeUsersRead}})
+
+	assert.Equal(t, "partner", stored.Name)
+	assert.Equal(t, []string{usecase.ScopeUsersRead, usecase.ScopeUsersWrite}, stored.Scopes)
+	assert.Equal(t, "admin-1", stored.CreatedBy)
+	assert.Regexp(t, `^ak_[0-9a-f]{12}$`, stored.Prefix)
+	assert.True(t, strings.HasPrefix(secret, stored.Prefix+"."))
+	assert.NotContains(t, string(stored.Hash), secret)
+	assert.Len(t, stored.Hash, 32)
+}
+
+func TestCreateAPIKey_Invalid(t *testing.T) {
+	past := time.Now().Add(-time.Hour)
+	tests := map[string]entity.APIKey{
+		"no name":       {Scopes: []string{usecase.ScopeUsersRead}},
+		"no scope":      {Name: "partner"},
+		"unknown scope": {Name: "partner", Scopes: []string{"users:admin"}},
+		"expired":       {Name: "partner", Scopes: []string{usecase.ScopeUsersRead}, ExpiresAt: &past},
+	}
+	for name, key := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			uc := usecase.NewAPIKey(mocks.NewMockAPIKeyRepo(ctrl), usecase.DefaultPolicy)
+
+			_, _, err := uc.CreateAPIKey(adminContext(), key)
+
+			assert.Equal(t, apperror.KindValidation, apperror.KindOf(err))
+		})
+	}
+}
+
+func TestAPIKeyManagement_Forbidden(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	uc := usecase.NewAPIKey(mocks.NewMockAPIKeyRepo(ctrl), usecase.DefaultPolicy)
+	ctx := reqctx.WithPrincipal(context.Background(), entity.Principal{Subject: "apikey:1", Scopes: usecase.APIKeyScopes})
+
+	_, _, err := uc.CreateAPIKey(ctx, entity.APIKey{Name: "partner", Scopes: []string{usecase.ScopeUsersRead}})
+	assert.Equal(t, apperror.KindForbidden, apperror.KindOf(err))
+
+	_, err = uc.ListAPIKeys(ctx)
+	assert.Equal(t, apperror.KindForbidden, apperror.KindOf(err))
+
+	err = uc.RevokeAPIKey(ctx, uuid.New())
+	assert.Equal(t, apperror.KindForbidden, apperror.KindOf(err))
+}
+
+func TestRevokeAPIKey_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockRepo := mocks.NewMockAPIKeyRepo(ctrl)
+	uc := usecase.NewAPIKey(mockRepo, usecase.DefaultPolicy)
+	id := uuid.New()
+
+	mockRepo.EXPECT().Revoke(gomock.Any(), id, gomock.Any()).Return(apperror.NotFound("record not found", nil))
+
+	err := uc.RevokeAPIKey(adminContext(), id)
+
+	e, ok := apperror.As(err)
+	require.True(t, ok)
+	assert.Equal(t, "api_key_not_found", e.ErrorCode())
+}
+
+func TestAuthenticateAPIKey(t *testing.T) {
+	stored, secret := issueAPIKey(t, entity.APIKey{Name: "partner", Scopes: []string{usecase.ScopeUsersRead}})
+
+	ctrl := gomock.NewController(t)
+	mockRepo := mocks.NewMockAPIKeyRepo(ctrl)
+	uc := usecase.NewAPIKey(mockRepo, usecase.DefaultPolicy)
+
+	mockRepo.EXPECT().GetByPrefix(gomock.Any(), stored.Prefix).Return(stored, nil)
+	mockRepo.EXPECT().Touch(gomock.Any(), stored.ID, gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, _ uuid.UUID, at, since time.Time) error {
+		assert.Equal(t, time.Minute, at.Sub(since))
+		return nil
+	})
+
+	p, err := uc.AuthenticateAPIKey(context.Background(), secret)
+
+	require.NoError(t, err)
+	assert.Equal(t, entity.Principal{Subject: "apikey:" + stored.ID.String(), Scopes: []string{usecase.ScopeUsersRead}}, p)
+}
+
+func TestAuthenticateAPIKey_Rejected(t *testing.T) {
+	stored, secret := issueAPIKey(t, entity.APIKey{Name: "partner", Scopes: []string{usecase.ScopeUsersRead}})
+	past := time.Now().Add(-time.Minute)
+	revoked, expired := stored, stored
+	revoked.RevokedAt = &past
+	expired.ExpiresAt = &past
+
+	tests := []struct {
+		name    string
+		key     string
+		stored  *entity.APIKey
+		message string
+	}{
+		{"malformed", "not-a-key", nil, "invalid API key"},
+		{"unknown prefix", secret, nil, "invalid API key"},
+		{"wrong secret", stored.Prefix + ".wrong", &stored, "invalid API key"},
+		{"revoked", secret, &revoked, "API key revoked"},
+		{"expired", secret, &expired, "API key expired"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockRepo := mocks.NewMockAPIKeyRepo(ctrl)
+			uc := usecase.NewAPIKey(mockRepo, usecase.DefaultPolicy)
+
+			if tt.name != "malformed" {
+				get := mockRepo.EXPECT().GetByPrefix(gomock.Any(), stored.Prefix)
+				if tt.stored != nil {
+					get.Return(*tt.stored, nil)
+				} else {
+					get.Return(entity.APIKey{}, apperror.NotFound("record not found", nil))
+				}
+			}
+
+			_, err := uc.AuthenticateAPIKey(context.Background(), tt.key)
+
+			e, ok := apperror.As(err)
+			require.True(t, ok)
+			assert.Equal(t, apperror.KindUnauthorized, e.Kind)
+			assert.Equal(t, tt.message, e.Message)
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/apperror"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/entity"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/reqctx"
+	"github.com/google/uuid"
+)
+
+// AnonymousActor is recorded when the context carries no actor.
+const AnonymousActor = "anonymous"
+
+const auditEntityUser = "user"
+
+// newAuditEvent stamps an event with the actor and request ID found in ctx.
+func newAuditEvent(ctx context.Context, entityType string, id uuid.UUID, action entity.AuditAction, changes []entity.FieldChange) entity.AuditEvent {
+	actor := reqctx.Actor(ctx)
+	if actor == "" {
+		actor = AnonymousActor
+	}
+	return entity.AuditEvent{
+		EntityType: entityType,
+		EntityID:   id,
+		Action:     action,
+		Actor:      actor,
+		RequestID:  reqctx.RequestID(ctx),
+		Changes:    changes,
+		CreatedAt:  time.Now().UTC(),
+	}
+}
+
+// diffUser compares the audited fields of two states of a user. A nil state
+// stands for a user that does not exist or is deleted, so every field shows
+// up as added or removed.
+func diffUser(before, after *entity.UserEntity) []entity.FieldChange {
+	fields := []struct {
+		name  string
+		value func(entity.UserEntity) any
+	}{
+		{"name", func(u entity.UserEntity) any { return u.Name }},
+		{"phone", func(u entity.UserEntity) any { return u.Phone }},
+	}
+
+	changes := make([]entity.FieldChange, 0, len(fields))
+	for _, f := range fields {
+		var from, to any
+		if before != nil {
+			from = f.value(*before)
+		}
+		if after != nil {
+			to = f.value(*after)
+		}
+		if from != to {
+			changes = append(changes, entity.FieldChange{Field: f.name, Old: from, New: to})
+		}
+	}
+	return changes
+}
+
+// checkHistoryPage rejects cursors, history is paged by offset only.
+func checkHistoryPage(req PageRequest) (PageRequest, error) {
+	if req.Cursor != nil {
+		return req, apperror.Validation("history is paged with offset, cursors are not supported", nil)
+	}
+	return req.normalize()
+}
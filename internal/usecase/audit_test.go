@@ -0,0 +1,150 @@
+package usecase_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/apperror"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/entity"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/reqctx"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/usecase"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// recordAudit captures the appended events.
+func recordAudit(mockAudit *mocks.MockAuditRepo, events *[]entity.AuditEvent) {
+	mockAudit.EXPECT().Append(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, e entity.AuditEvent) error {
+		*events = append(*events, e)
+		return nil
+	}).AnyTimes()
+}
+
+func TestUserMutationsAreAudited(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepo(ctrl)
+	mockAudit := mocks.NewMockAuditRepo(ctrl)
+	uc := usecase.NewUser(mockRepo, inlineTx{}, mockAudit, nopOutbox{}, usecase.AllowAll)
+
+	var events []entity.AuditEvent
+	recordAudit(mockAudit, &events)
+
+	ctx := reqctx.WithRequestID(reqctx.WithActor(context.Background(), "admin@example.com"), "req-1")
+	ana := entity.UserEntity{ID: uuid.New(), Name: "Ana", Phone: "1", Version: 1}
+
+	mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(ana, nil)
+	_, err := uc.CreateUser(ctx, entity.UserEntity{Name: "Ana", Phone: "1"})
+	require.NoError(t, err)
+
+	mockRepo.EXPECT().GetById(gomock.Any(), gomock.Any()).Return(ana, nil)
+	mockRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+	require.NoError(t, uc.UpdateUser(ctx, entity.UserEntity{ID: ana.ID, Name: "Bia", Phone: "1"}))
+
+	mockRepo.EXPECT().GetById(gomock.Any(), gomock.Any()).Return(ana, nil)
+	mockRepo.EXPECT().DeleteById(gomock.Any(), gomock.Any()).Return(nil)
+	require.NoError(t, uc.DeleteUser(ctx, entity.UserEntity{ID: ana.ID}))
+
+	mockRepo.EXPECT().Restore(gomock.Any(), gomock.Any()).Return(nil)
+	mockRepo.EXPECT().GetById(gomock.Any(), gomock.Any()).Return(ana, nil)
+	_, err = uc.RestoreUser(ctx, entity.UserEntity{ID: ana.ID})
+	require.NoError(t, err)
+
+	require.Len(t, events, 4)
+	for _, e := range events {
+		assert.Equal(t, "user", e.EntityType)
+		assert.Equal(t, ana.ID, e.EntityID)
+		assert.Equal(t, "admin@example.com", e.Actor)
+		assert.Equal(t, "req-1", e.RequestID)
+		assert.WithinDuration(t, time.Now(), e.CreatedAt, time.Minute)
+	}
+
+	assert.Equal(t, entity.AuditCreate, events[0].Action)
+	assert.Equal(t, []entity.FieldChange{{Field: "name", New: "Ana"}, {Field: "phone", New: "1"}}, events[0].Changes)
+	assert.Equal(t, entity.AuditUpdate, events[1].Action)
+	assert.Equal(t, []entity.FieldChange{{Field: "name", Old: "Ana", New: "Bia"}}, events[1].Changes)
+	assert.Equal(t, entity.AuditDelete, events[2].Action)
+	assert.Equal(t, []entity.FieldChange{{Field: "name", Old: "Ana"}, {Field: "phone", Old: "1"}}, events[2].Changes)
+	assert.Equal(t, entity.AuditRestore, events[3].Action)
+	assert.Equal(t, []entity.FieldChange{{Field: "name", New: "Ana"}, {Field: "phone", New: "1"}}, events[3].Changes)
+}
+
+func TestPatchUser_Audited(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepo(ctrl)
+	mockAudit := mocks.NewMockAuditRepo(ctrl)
+	uc := usecase.NewUser(mockRepo, inlineTx{}, mockAudit, nopOutbox{}, usecase.AllowAll)
+
+	var events []entity.AuditEvent
+	recordAudit(mockAudit, &events)
+
+	ana := entity.UserEntity{ID: uuid.New(), Name: "Ana", Phone: "1", Version: 1}
+	setPhone := func(u entity.UserEntity) (entity.UserEntity, error) { u.Phone = "2"; return u, nil }
+
+	mockRepo.EXPECT().GetById(gomock.Any(), gomock.Any()).Return(ana, nil)
+	mockRepo.EXPECT().UpdateFields(gomock.Any(), gomock.Any(), "phone").DoAndReturn(func(_ context.Context, u entity.UserEntity, _ ...string) (entity.UserEntity, error) {
+		u.Version++
+		return u, nil
+	})
+	_, err := uc.PatchUser(context.Background(), entity.UserEntity{ID: ana.ID}, setPhone)
+	require.NoError(t, err)
+
+	// A patch that changes nothing writes nothing and is not audited.
+	mockRepo.EXPECT().GetById(gomock.Any(), gomock.Any()).Return(ana, nil)
+	_, err = uc.PatchUser(context.Background(), entity.UserEntity{ID: ana.ID}, func(u entity.UserEntity) (entity.UserEntity, error) { return u, nil })
+	require.NoError(t, err)
+
+	require.Len(t, events, 1)
+	assert.Equal(t, usecase.AnonymousActor, events[0].Actor)
+	assert.Empty(t, events[0].RequestID)
+	assert.Equal(t, []entity.FieldChange{{Field: "phone", Old: "1", New: "2"}}, events[0].Changes)
+}
+
+func TestUserMutation_AuditFailureFails(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepo(ctrl)
+	mockAudit := mocks.NewMockAuditRepo(ctrl)
+	uc := usecase.NewUser(mockRepo, inlineTx{}, mockAudit, nopOutbox{}, usecase.AllowAll)
+
+	mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(entity.UserEntity{ID: uuid.New()}, nil)
+	mockAudit.EXPECT().Append(gomock.Any(), gomock.Any()).Return(fmt.Errorf("audit_log is down"))
+
+	_, err := uc.CreateUser(context.Background(), entity.UserEntity{Name: "Ana"})
+
+	assert.EqualError(t, err, "CreateUser: audit_log is down")
+}
+
+func TestUserHistory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepo(ctrl)
+	mockAudit := mocks.NewMockAuditRepo(ctrl)
+	uc := usecase.NewUser(mockRepo, inlineTx{}, mockAudit, nopOutbox{}, usecase.AllowAll)
+
+	id := uuid.New()
+	events := []entity.AuditEvent{{EntityType: "user", EntityID: id, Action: entity.AuditCreate}}
+
+	mockAudit.EXPECT().
+		List(gomock.Any(), "user", id, usecase.PageRequest{Limit: usecase.DefaultPageLimit, Offset: 5}).
+		Return(usecase.Page[entity.AuditEvent]{Items: events}, nil)
+
+	page, err := uc.UserHistory(context.Background(), entity.UserEntity{ID: id}, usecase.PageRequest{Offset: 5})
+
+	require.NoError(t, err)
+	assert.Equal(t, events, page.Items)
+
+	_, err = uc.UserHistory(context.Background(), entity.UserEntity{ID: id}, usecase.PageRequest{Cursor: &usecase.Cursor{}})
+
+	assert.ErrorIs(t, err, apperror.ErrValidation)
+}
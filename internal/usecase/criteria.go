@@ -0,0 +1,139 @@
+package usecase
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/apperror"
+)
+
+type FieldType int
+
+const (
+	FieldString FieldType = iota
+	FieldTime
+)
+
+// Field describes how a listing may be queried on one attribute.
+type Field struct {
+	Type     FieldType
+	Sortable bool
+}
+
+// Fields is the whitelist of queryable attributes of an entity, keyed by public name.
+type Fields map[string]Field
+
+type Operator string
+
+const (
+	OpEq   Operator = "eq"
+	OpNe   Operator = "ne"
+	OpGt   Operator = "gt"
+	OpGte  Operator = "ge"
+	OpLt   Operator = "lt"
+	OpLte  Operator = "le"
+	OpLike Operator = "like"
+)
+
+// Condition filters on one field. Value holds the raw string as parsed from
+// the client until the criteria is resolved against the entity Fields, after
+// which it is a string or a time.Time depending on the field type.
+type Condition struct {
+	Field string
+	Op    Operator
+	Value any
+}
+
+type Order struct {
+	Field string
+	Desc  bool
+}
+
+// Criteria is a storage-agnostic query: all conditions are ANDed together.
+type Criteria struct {
+	Conditions []Condition
+	Sort       []Order
+}
+
+var defaultSort = []Order{{Field: "created_at"}}
+
+// SortKey returns the canonical form of the sort, e.g. "-created_at,name".
+func (c Criteria) SortKey() string {
+	keys := make([]string, 0, len(c.Sort))
+	for _, o := range c.Sort {
+		if o.Desc {
+			keys = append(keys, "-"+o.Field)
+		} else {
+			keys = append(keys, o.Field)
+		}
+	}
+	return strings.Join(keys, ",")
+}
+
+// resolve checks the criteria against the whitelist and converts raw values
+// to their field type. Unknown fields are validation errors.
+func (c Criteria) resolve(fields Fields) (Criteria, error) {
+	resolved := Criteria{
+		Conditions: make([]Condition, 0, len(c.Conditions)),
+		Sort:       make([]Order, 0, len(c.Sort)),
+	}
+
+	for _, cond := range c.Conditions {
+		field, ok := fields[cond.Field]
+		if !ok {
+			return Criteria{}, apperror.Validation(fmt.Sprintf("unknown filter field %q", cond.Field), nil)
+		}
+		if cond.Op == OpLike && field.Type != FieldString {
+			return Criteria{}, apperror.Validation(fmt.Sprintf("operator like is not supported on field %q", cond.Field), nil)
+		}
+		value, err := parseValue(field.Type, cond.Value)
+		if err != nil {
+			return Criteria{}, apperror.Validation(fmt.Sprintf("invalid value for field %q", cond.Field), err)
+		}
+		resolved.Conditions = append(resolved.Conditions, Condition{Field: cond.Field, Op: cond.Op, Value: value})
+	}
+
+	seen := make(map[string]bool, len(c.Sort))
+	for _, o := range c.Sort {
+		field, ok := fields[o.Field]
+		if !ok || !field.Sortable {
+			return Criteria{}, apperror.Validation(fmt.Sprintf("unknown sort field %q", o.Field), nil)
+		}
+		if seen[o.Field] {
+			return Criteria{}, apperror.Validation(fmt.Sprintf("duplicate sort field %q", o.Field), nil)
+		}
+		seen[o.Field] = true
+		resolved.Sort = append(resolved.Sort, o)
+	}
+	if len(resolved.Sort) == 0 {
+		resolved.Sort = defaultSort
+	}
+
+	return resolved, nil
+}
+
+func parseValue(t FieldType, raw any) (any, error) {
+	switch v := raw.(type) {
+	case time.Time:
+		if t == FieldTime {
+			return v, nil
+		}
+	case string:
+		if t == FieldString {
+			return v, nil
+		}
+		if t == FieldTime {
+			return parseTime(v)
+		}
+	}
+	return nil, fmt.Errorf("unexpected value %v", raw)
+}
+
+// parseTime accepts RFC 3339 timestamps and plain dates (UTC midnight).
+func parseTime(s string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339Nano, s); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.DateOnly, s)
+}
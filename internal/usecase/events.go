@@ -0,0 +1,49 @@
+package usecase
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/entity"
+	"github.com/google/uuid"
+)
+
+const aggregateUser = "user"
+
+// userEvents maps the audited user mutations to the events they raise.
+var userEvents = map[entity.AuditAction]entity.EventType{
+	entity.AuditCreate:  entity.UserCreated,
+	entity.AuditUpdate:  entity.UserUpdated,
+	entity.AuditDelete:  entity.UserDeleted,
+	entity.AuditRestore: entity.UserRestored,
+	entity.AuditPurge:   entity.UserPurged,
+}
+
+// UserEventPayload is the payload of user events: the user as the event left
+// it, or as it was before for UserDeleted. UserPurged only carries the ID, as
+// the rest is erased, and so do the earlier events of a purged user.
+type UserEventPayload struct {
+	ID      uuid.UUID `json:"id"`
+	Name    string    `json:"name"`
+	Phone   string    `json:"phone"`
+	Version int64     `json:"version"`
+}
+
+func newUserEvent(t entity.EventType, u entity.UserEntity) (entity.Event, error) {
+	payload, err := userPayload(u)
+	if err != nil {
+		return entity.Event{}, err
+	}
+	return entity.Event{
+		ID:            uuid.New(),
+		Type:          t,
+		AggregateType: aggregateUser,
+		AggregateID:   u.ID,
+		Payload:       payload,
+		OccurredAt:    time.Now().UTC(),
+	}, nil
+}
+
+func userPayload(u entity.UserEntity) ([]byte, error) {
+	return json.Marshal(UserEventPayload{ID: u.ID, Name: u.Name, Phone: u.Phone, Version: u.Version})
+}
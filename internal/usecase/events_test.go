@@ -0,0 +1,90 @@
+package usecase_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/entity"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/usecase"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestUserMutationsRaiseEvents(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepo(ctrl)
+	mockOutbox := mocks.NewMockOutboxRepo(ctrl)
+	uc := usecase.NewUser(mockRepo, inlineTx{}, nopAudit{}, mockOutbox, usecase.AllowAll)
+
+	var events []entity.Event
+	mockOutbox.EXPECT().Append(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, e entity.Event) error {
+		events = append(events, e)
+		return nil
+	}).AnyTimes()
+
+	ana := entity.UserEntity{ID: uuid.New(), Name: "Ana", Phone: "1", Version: 1}
+
+	mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(ana, nil)
+	_, err := uc.CreateUser(context.Background(), entity.UserEntity{Name: "Ana", Phone: "1"})
+	require.NoError(t, err)
+
+	mockRepo.EXPECT().GetById(gomock.Any(), gomock.Any()).Return(ana, nil)
+	mockRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+	require.NoError(t, uc.UpdateUser(context.Background(), entity.UserEntity{ID: ana.ID, Name: "Bia", Phone: "2"}))
+
+	mockRepo.EXPECT().GetById(gomock.Any(), gomock.Any()).Return(ana, nil)
+	mockRepo.EXPECT().DeleteById(gomock.Any(), gomock.Any()).Return(nil)
+	require.NoError(t, uc.DeleteUser(context.Background(), entity.UserEntity{ID: ana.ID}))
+
+	mockRepo.EXPECT().Restore(gomock.Any(), gomock.Any()).Return(nil)
+	mockRepo.EXPECT().GetById(gomock.Any(), gomock.Any()).Return(ana, nil)
+	_, err = uc.RestoreUser(context.Background(), entity.UserEntity{ID: ana.ID})
+	require.NoError(t, err)
+
+	require.Len(t, events, 4)
+	ids := map[uuid.UUID]bool{}
+	for _, e := range events {
+		assert.Equal(t, "user", e.AggregateType)
+		assert.Equal(t, ana.ID, e.AggregateID)
+		assert.NotEqual(t, uuid.Nil, e.ID)
+		assert.WithinDuration(t, time.Now(), e.OccurredAt, time.Minute)
+		ids[e.ID] = true
+	}
+	assert.Len(t, ids, 4)
+
+	payload := func(e entity.Event) usecase.UserEventPayload {
+		var p usecase.UserEventPayload
+		require.NoError(t, json.Unmarshal(e.Payload, &p))
+		return p
+	}
+	assert.Equal(t, entity.UserCreated, events[0].Type)
+	assert.Equal(t, usecase.UserEventPayload{ID: ana.ID, Name: "Ana", Phone: "1", Version: 1}, payload(events[0]))
+	assert.Equal(t, entity.UserUpdated, events[1].Type)
+	assert.Equal(t, usecase.UserEventPayload{ID: ana.ID, Name: "Bia", Phone: "2", Version: 2}, payload(events[1]))
+	assert.Equal(t, entity.UserDeleted, events[2].Type)
+	assert.Equal(t, usecase.UserEventPayload{ID: ana.ID, Name: "Ana", Phone: "1", Version: 1}, payload(events[2]))
+	assert.Equal(t, entity.UserRestored, events[3].Type)
+}
+
+func TestUserMutation_OutboxFailureFails(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepo(ctrl)
+	mockOutbox := mocks.NewMockOutboxRepo(ctrl)
+	uc := usecase.NewUser(mockRepo, inlineTx{}, nopAudit{}, mockOutbox, usecase.AllowAll)
+
+	mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(entity.UserEntity{ID: uuid.New()}, nil)
+	mockOutbox.EXPECT().Append(gomock.Any(), gomock.Any()).Return(fmt.Errorf("db down"))
+
+	_, err := uc.CreateUser(context.Background(), entity.UserEntity{Name: "Ana"})
+	assert.EqualError(t, err, "CreateUser: db down")
+}
@@ -0,0 +1,109 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/apperror"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/entity"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/reqctx"
+)
+
+const DefaultIdempotencyTTL = 24 * time.Hour
+
+type IdempotencyUseCase struct {
+	repo IdempotencyRepo
+	ttl  time.Duration
+	now  func() time.Time
+}
+
+func NewIdempotency(r IdempotencyRepo, ttl time.Duration) *IdempotencyUseCase {
+	if ttl <= 0 {
+		ttl = DefaultIdempotencyTTL
+	}
+	return &IdempotencyUseCase{
+		repo: r,
+		ttl:  ttl,
+		now:  time.Now,
+	}
+}
+
+// Begin claims key, for the actor of ctx, for a request identified by
+// fingerprint. It returns a new, not completed record when the caller should
+// process the request, or the completed record of the original request to
+// replay. A key still in flight is a conflict, a key reused for another
+// request is unprocessable.
+func (uc *IdempotencyUseCase) Begin(ctx context.Context, key, fingerprint string) (entity.IdempotencyRecord, error) {
+
+	now := uc.now()
+	record := entity.IdempotencyRecord{
+		Actor:       reqctx.Actor(ctx),
+		Key:         key,
+		Fingerprint: fingerprint,
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(uc.ttl),
+	}
+
+	acquired, err := uc.repo.Acquire(ctx, record)
+	if err != nil {
+		return entity.IdempotencyRecord{}, fmt.Errorf("Begin: %w", err)
+	}
+	if acquired {
+		return record, nil
+	}
+
+	stored, err := uc.repo.Get(ctx, record.Actor, key)
+	if err != nil {
+		// The live record was released or purged in the meantime.
+		if apperror.KindOf(err) == apperror.KindNotFound {
+			return entity.IdempotencyRecord{}, fmt.Errorf("Begin: %w", inFlight(err))
+		}
+		return entity.IdempotencyRecord{}, fmt.Errorf("Begin: %w", err)
+	}
+
+	if stored.Fingerprint != fingerprint {
+		return entity.IdempotencyRecord{}, fmt.Errorf("Begin: %w", apperror.Wrap(nil, apperror.KindUnprocessable, "idempotency_key_reused",
+			"idempotency key was already used for a different request"))
+	}
+	if !stored.Completed() {
+		return entity.IdempotencyRecord{}, fmt.Errorf("Begin: %w", inFlight(nil))
+	}
+
+	return stored, nil
+}
+
+// Complete stores the response of a request started with Begin.
+func (uc *IdempotencyUseCase) Complete(ctx context.Context, record entity.IdempotencyRecord) error {
+
+	if err := uc.repo.SaveResponse(ctx, record); err != nil {
+		return fmt.Errorf("Complete: %w", err)
+	}
+
+	return nil
+}
+
+// Release forgets a key whose request failed, so it can be retried.
+func (uc *IdempotencyUseCase) Release(ctx context.Context, key string) error {
+
+	if err := uc.repo.Delete(ctx, reqctx.Actor(ctx), key); err != nil {
+		return fmt.Errorf("Release: %w", err)
+	}
+
+	return nil
+}
+
+// PurgeExpired deletes the expired records and returns how many were removed.
+func (uc *IdempotencyUseCase) PurgeExpired(ctx context.Context) (int64, error) {
+
+	n, err := uc.repo.DeleteExpired(ctx, uc.now())
+	if err != nil {
+		return 0, fmt.Errorf("PurgeExpired: %w", err)
+	}
+
+	return n, nil
+}
+
+func inFlight(err error) error {
+	return apperror.Wrap(err, apperror.KindConflict, "idempotency_request_in_progress", "a request with this idempotency key is still in progress")
+}
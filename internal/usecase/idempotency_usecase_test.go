@@ -0,0 +1,109 @@
+package usecase_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/apperror"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/entity"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/reqctx"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/usecase"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/mocks"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestIdempotencyBegin_Acquired(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockIdempotencyRepo(ctrl)
+	uc := usecase.NewIdempotency(mockRepo, time.Hour)
+
+	mockRepo.EXPECT().Acquire(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, r entity.IdempotencyRecord) (bool, error) {
+		assert.Equal(t, "user-1", r.Actor)
+		assert.Equal(t, "k1", r.Key)
+		assert.Equal(t, "f1", r.Fingerprint)
+		assert.Equal(t, time.Hour, r.ExpiresAt.Sub(r.CreatedAt))
+		return true, nil
+	})
+
+	record, err := uc.Begin(reqctx.WithActor(context.Background(), "user-1"), "k1", "f1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "k1", record.Key)
+	assert.False(t, record.Completed())
+}
+
+func TestIdempotencyBegin_Existing(t *testing.T) {
+	completed := entity.IdempotencyRecord{Key: "k1", Fingerprint: "f1", StatusCode: 200, Body: []byte(`{}`)}
+	inFlight := entity.IdempotencyRecord{Key: "k1", Fingerprint: "f1"}
+
+	tests := []struct {
+		name     string
+		stored   entity.IdempotencyRecord
+		getErr   error
+		fp       string
+		kind     apperror.Kind
+		code     string
+		expected entity.IdempotencyRecord
+	}{
+		{name: "replay", stored: completed, fp: "f1", expected: completed},
+		{name: "different request", stored: completed, fp: "f2", kind: apperror.KindUnprocessable, code: "idempotency_key_reused"},
+		{name: "in flight", stored: inFlight, fp: "f1", kind: apperror.KindConflict, code: "idempotency_request_in_progress"},
+		{name: "released meanwhile", getErr: apperror.NotFound("record not found", nil), fp: "f1", kind: apperror.KindConflict, code: "idempotency_request_in_progress"},
+		{name: "repository error", getErr: fmt.Errorf("connection reset"), fp: "f1", kind: apperror.KindInternal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockIdempotencyRepo(ctrl)
+			uc := usecase.NewIdempotency(mockRepo, time.Hour)
+
+			gomock.InOrder(
+				mockRepo.EXPECT().Acquire(gomock.Any(), gomock.Any()).Return(false, nil),
+				mockRepo.EXPECT().Get(gomock.Any(), "user-1", "k1").Return(tt.stored, tt.getErr),
+			)
+
+			record, err := uc.Begin(reqctx.WithActor(context.Background(), "user-1"), "k1", tt.fp)
+
+			if tt.expected.Key != "" {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, record)
+				return
+			}
+			if assert.Error(t, err) {
+				assert.Equal(t, tt.kind, apperror.KindOf(err))
+				if e, ok := apperror.As(err); ok {
+					assert.Equal(t, tt.code, e.ErrorCode())
+				}
+			}
+		})
+	}
+}
+
+func TestIdempotency_CompleteReleasePurge(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockIdempotencyRepo(ctrl)
+	uc := usecase.NewIdempotency(mockRepo, time.Hour)
+
+	record := entity.IdempotencyRecord{Key: "k1", StatusCode: 201}
+
+	mockRepo.EXPECT().SaveResponse(gomock.Any(), record).Return(nil)
+	assert.NoError(t, uc.Complete(context.Background(), record))
+
+	mockRepo.EXPECT().Delete(gomock.Any(), "user-1", "k1").Return(fmt.Errorf("failed to delete"))
+	assert.EqualError(t, uc.Release(reqctx.WithActor(context.Background(), "user-1"), "k1"), "Release: failed to delete")
+
+	mockRepo.EXPECT().DeleteExpired(gomock.Any(), gomock.Any()).Return(int64(3), nil)
+	n, err := uc.PurgeExpired(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), n)
+}
@@ -2,8 +2,10 @@ package usecase
 
 import (
 	"context"
+	"time"
 
 	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/entity"
+	"github.com/google/uuid"
 )
 
 type (
@@ -11,35 +13,172 @@ type (
 	User interface {
 		CreateUser(context.Context, entity.UserEntity) (entity.UserEntity, error)
 		UpdateUser(context.Context, entity.UserEntity) error
+		PatchUser(context.Context, entity.UserEntity, UserPatch) (entity.UserEntity, error)
 		DeleteUser(context.Context, entity.UserEntity) error
 		GetUserById(context.Context, entity.UserEntity) (entity.UserEntity, error)
+		ListUsers(context.Context, Criteria, PageRequest) (Page[entity.UserEntity], error)
+		ListDeletedUsers(context.Context, Criteria, PageRequest) (Page[entity.UserEntity], error)
+		RestoreUser(context.Context, entity.UserEntity) (entity.UserEntity, error)
+		PurgeUser(context.Context, entity.UserEntity) error
+		PurgeDeletedUsers(ctx context.Context, before time.Time) (int64, error)
+		UserHistory(context.Context, entity.UserEntity, PageRequest) (Page[entity.AuditEvent], error)
 	}
 
 	// Repository
 	UserRepo interface {
 		Create(context.Context, entity.UserEntity) (entity.UserEntity, error)
 		Update(context.Context, entity.UserEntity) error
+		UpdateFields(context.Context, entity.UserEntity, ...string) (entity.UserEntity, error)
 		DeleteById(context.Context, entity.UserEntity) error
 		GetById(context.Context, entity.UserEntity) (entity.UserEntity, error)
+		List(context.Context, Criteria, PageRequest) (Page[entity.UserEntity], error)
+		ListDeleted(context.Context, Criteria, PageRequest) (Page[entity.UserEntity], error)
+		Restore(context.Context, entity.UserEntity) error
+		Purge(context.Context, entity.UserEntity) error
+		PurgeDeletedBefore(context.Context, time.Time) ([]uuid.UUID, error)
 	}
 
+	// AuditRepo is append-only: recorded events are never changed, except
+	// by Redact, which erases the values of their changes.
+	AuditRepo interface {
+		Append(context.Context, entity.AuditEvent) error
+		Redact(ctx context.Context, entityType string, entityID uuid.UUID) error
+		List(ctx context.Context, entityType string, entityID uuid.UUID, req PageRequest) (Page[entity.AuditEvent], error)
+	}
+
+	// OutboxRepo holds events until they are published. Events are appended
+	// in the transaction of the write that raised them.
+	OutboxRepo interface {
+		Append(context.Context, entity.Event) error
+		Redact(ctx context.Context, aggregateType string, aggregateID uuid.UUID, payload []byte) error
+		Claim(ctx context.Context, now time.Time, limit int) ([]entity.OutboxMessage, error)
+		SaveDelivery(context.Context, entity.OutboxMessage) error
+		DeletePublished(context.Context, time.Time) (int64, error)
+	}
+
+	// Publisher delivers events to their consumers. Delivery is at least
+	// once: an event may be published again, consumers drop it by Event.ID.
+	Publisher interface {
+		Publish(context.Context, entity.Event) error
+	}
+
+	Outbox interface {
+		Relay(context.Context) (int, error)
+		PurgePublished(context.Context) (int64, error)
+	}
+
+	// APIKey manages the static credentials of machine clients.
+	APIKey interface {
+		// CreateAPIKey returns the stored key and its secret, which is
+		// never available again.
+		CreateAPIKey(context.Context, entity.APIKey) (entity.APIKey, string, error)
+		ListAPIKeys(context.Context) ([]entity.APIKey, error)
+		RevokeAPIKey(ctx context.Context, id uuid.UUID) error
+		AuthenticateAPIKey(ctx context.Context, key string) (entity.Principal, error)
+	}
+
+	APIKeyRepo interface {
+		Create(context.Context, entity.APIKey) (entity.APIKey, error)
+		GetByPrefix(ctx context.Context, prefix string) (entity.APIKey, error)
+		List(context.Context) ([]entity.APIKey, error)
+		Revoke(ctx context.Context, id uuid.UUID, at time.Time) error
+		Touch(ctx context.Context, id uuid.UUID, at, since time.Time) error
+	}
+
+	// LogLevel changes the level of the application logs at runtime.
+	LogLevel interface {
+		GetLogLevel(context.Context) (entity.LogLevel, error)
+		SetLogLevel(ctx context.Context, level string, ttl time.Duration) (entity.LogLevel, error)
+		ResetLogLevel(context.Context) (entity.LogLevel, error)
+	}
+
+	// LogLevelController holds the level of the logger. A temporary level
+	// reverts to the configured one after its ttl.
+	LogLevelController interface {
+		LogLevel() entity.LogLevel
+		SetTemporary(level string, ttl time.Duration) (entity.LogLevel, error)
+		Revert() entity.LogLevel
+	}
+
+	// Authorizer decides whether the principal in the context may perform
+	// an operation on the user with the given ID, or on no single user when
+	// it is uuid.Nil. Denials are unauthorized or forbidden errors.
+	Authorizer interface {
+		Authorize(ctx context.Context, perm Permission, owner uuid.UUID) error
+	}
+
+	// Transactor runs fn in a transaction carried by the context it passes
+	// to fn, so repository calls made with that context join it. Calls nested
+	// in fn run in a savepoint. The transaction is rolled back if fn returns
+	// an error or panics; options only apply to the outermost call.
+	Transactor interface {
+		RunInTx(ctx context.Context, fn func(ctx context.Context) error, opts ...TxOption) error
+	}
+
+	Idempotency interface {
+		Begin(ctx context.Context, key, fingerprint string) (entity.IdempotencyRecord, error)
+		Complete(context.Context, entity.IdempotencyRecord) error
+		Release(ctx context.Context, key string) error
+		PurgeExpired(context.Context) (int64, error)
+	}
+
+	IdempotencyRepo interface {
+		Acquire(context.Context, entity.IdempotencyRecord) (bool, error)
+		Get(ctx context.Context, actor, key string) (entity.IdempotencyRecord, error)
+		SaveResponse(context.Context, entity.IdempotencyRecord) error
+		Delete(ctx context.Context, actor, key string) error
+		DeleteExpired(context.Context, time.Time) (int64, error)
+	}
+
+	// Observer is told about every use case call, such as to count calls
+	// and errors. Start returns the context the call runs with, and a
+	// function the decorator calls with the error of the call.
+	Observer interface {
+		Start(ctx context.Context, useCase, method string) (context.Context, func(error))
+	}
+
+	// UserPatch applies a partial update, such as a decoded JSON Patch
+	// document, to the current state of a user and returns the new state.
+	UserPatch func(entity.UserEntity) (entity.UserEntity, error)
+
 	// Add all use cases for use in NewRouter
 	UseCases interface {
 		UserUseCase() User
+		IdempotencyUseCase() Idempotency
+		APIKeyUseCase() APIKey
+		LogLevelUseCase() LogLevel
 	}
 )
 
 // Adjust the items below as new use cases are created
 type AppUseCases struct {
-	user User
+	user        User
+	idempotency Idempotency
+	apiKey      APIKey
+	logLevel    LogLevel
 }
 
 func (a *AppUseCases) UserUseCase() User {
 	return a.user
 }
 
-func NewAppUseCases(user User) *AppUseCases {
+func (a *AppUseCases) IdempotencyUseCase() Idempotency {
+	return a.idempotency
+}
+
+func (a *AppUseCases) APIKeyUseCase() APIKey {
+	return a.apiKey
+}
+
+func (a *AppUseCases) LogLevelUseCase() LogLevel {
+	return a.logLevel
+}
+
+func NewAppUseCases(user User, idempotency Idempotency, apiKey APIKey, logLevel LogLevel) *AppUseCases {
 	return &AppUseCases{
-		user: user,
+		user:        user,
+		idempotency: idempotency,
+		apiKey:      apiKey,
+		logLevel:    logLevel,
 	}
 }
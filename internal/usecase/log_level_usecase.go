@@ -0,0 +1,64 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/apperror"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/entity"
+	"github.com/google/uuid"
+)
+
+// MaxLogLevelTTL bounds how long a temporary log level lasts, so that a
+// forgotten debug level does not stay on.
+const MaxLogLevelTTL = 24 * time.Hour
+
+var logLevels = []string{entity.LogLevelDebug, entity.LogLevelInfo, entity.LogLevelWarn, entity.LogLevelError}
+
+type LogLevelUseCase struct {
+	ctl   LogLevelController
+	authz Authorizer
+}
+
+// NewLogLevel returns the log level use case. Every call requires the
+// PermManageLogLevel permission.
+func NewLogLevel(ctl LogLevelController, authz Authorizer) *LogLevelUseCase {
+	return &LogLevelUseCase{ctl: ctl, authz: authz}
+}
+
+func (uc *LogLevelUseCase) GetLogLevel(ctx context.Context) (entity.LogLevel, error) {
+	if err := uc.authz.Authorize(ctx, PermManageLogLevel, uuid.Nil); err != nil {
+		return entity.LogLevel{}, fmt.Errorf("GetLogLevel: %w", err)
+	}
+	return uc.ctl.LogLevel(), nil
+}
+
+// SetLogLevel changes the log level for ttl, after which the configured
+// level is restored.
+func (uc *LogLevelUseCase) SetLogLevel(ctx context.Context, level string, ttl time.Duration) (entity.LogLevel, error) {
+	if err := uc.authz.Authorize(ctx, PermManageLogLevel, uuid.Nil); err != nil {
+		return entity.LogLevel{}, fmt.Errorf("SetLogLevel: %w", err)
+	}
+	if !slices.Contains(logLevels, level) {
+		return entity.LogLevel{}, apperror.Validation(fmt.Sprintf("unknown log level %q", level), nil)
+	}
+	if ttl <= 0 || ttl > MaxLogLevelTTL {
+		return entity.LogLevel{}, apperror.Validation(fmt.Sprintf("the ttl must be positive and at most %s", MaxLogLevelTTL), nil)
+	}
+
+	state, err := uc.ctl.SetTemporary(level, ttl)
+	if err != nil {
+		return entity.LogLevel{}, fmt.Errorf("SetLogLevel: %w", err)
+	}
+	return state, nil
+}
+
+// ResetLogLevel restores the configured level at once.
+func (uc *LogLevelUseCase) ResetLogLevel(ctx context.Context) (entity.LogLevel, error) {
+	if err := uc.authz.Authorize(ctx, PermManageLogLevel, uuid.Nil); err != nil {
+		return entity.LogLevel{}, fmt.Errorf("ResetLogLevel: %w", err)
+	}
+	return uc.ctl.Revert(), nil
+}
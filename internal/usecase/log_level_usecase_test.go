@@ -0,0 +1,75 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/apperror"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/entity"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/reqctx"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/usecase"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestSetLogLevel(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctl := mocks.NewMockLogLevelController(ctrl)
+	at := time.Now().Add(10 * time.Minute)
+	want := entity.LogLevel{Level: entity.LogLevelDebug, Configured: entity.LogLevelInfo, RevertAt: &at}
+	ctl.EXPECT().SetTemporary(entity.LogLevelDebug, 10*time.Minute).Return(want, nil)
+
+	got, err := usecase.NewLogLevel(ctl, usecase.DefaultPolicy).SetLogLevel(adminContext(), entity.LogLevelDebug, 10*time.Minute)
+
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+}
+
+func TestSetLogLevel_Invalid(t *testing.T) {
+	tests := map[string]struct {
+		level string
+		ttl   time.Duration
+	}{
+		"unknown level": {"trace", time.Minute},
+		"no ttl":        {entity.LogLevelDebug, 0},
+		"ttl too long":  {entity.LogLevelDebug, usecase.MaxLogLevelTTL + time.Second},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			uc := usecase.NewLogLevel(mocks.NewMockLogLevelController(ctrl), usecase.DefaultPolicy)
+
+			_, err := uc.SetLogLevel(adminContext(), tt.level, tt.ttl)
+
+			assert.Equal(t, apperror.KindValidation, apperror.KindOf(err))
+		})
+	}
+}
+
+func TestLogLevel_Forbidden(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	uc := usecase.NewLogLevel(mocks.NewMockLogLevelController(ctrl), usecase.DefaultPolicy)
+	ctx := reqctx.WithPrincipal(context.Background(), entity.Principal{Subject: "support-1", Roles: []string{entity.RoleSupport}})
+
+	_, err := uc.GetLogLevel(ctx)
+	assert.Equal(t, apperror.KindForbidden, apperror.KindOf(err))
+	_, err = uc.SetLogLevel(ctx, entity.LogLevelDebug, time.Minute)
+	assert.Equal(t, apperror.KindForbidden, apperror.KindOf(err))
+	_, err = uc.ResetLogLevel(ctx)
+	assert.Equal(t, apperror.KindForbidden, apperror.KindOf(err))
+}
+
+func TestResetLogLevel(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctl := mocks.NewMockLogLevelController(ctrl)
+	want := entity.LogLevel{Level: entity.LogLevelInfo, Configured: entity.LogLevelInfo}
+	ctl.EXPECT().Revert().Return(want)
+
+	got, err := usecase.NewLogLevel(ctl, usecase.DefaultPolicy).ResetLogLevel(adminContext())
+
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+}
@@ -0,0 +1,271 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/entity"
+	"github.com/google/uuid"
+)
+
+// Use case names given to an Observer.
+const (
+	ObservedUser        = "user"
+	ObservedAPIKey      = "api_key"
+	ObservedIdempotency = "idempotency"
+	ObservedLogLevel    = "log_level"
+)
+
+// ObserveUseCases returns use cases telling obs about every call of uc.
+func ObserveUseCases(uc UseCases, obs Observer) *AppUseCases {
+	return NewAppUseCases(
+		ObserveUser(uc.UserUseCase(), obs),
+		ObserveIdempotency(uc.IdempotencyUseCase(), obs),
+		ObserveAPIKey(uc.APIKeyUseCase(), obs),
+		ObserveLogLevel(uc.LogLevelUseCase(), obs),
+	)
+}
+
+// Observers returns an Observer telling every one of obs, in order. Each
+// starts with the context returned by the one before, and they are told
+// about the end of the call in reverse order.
+func Observers(obs ...Observer) Observer {
+	return observers(obs)
+}
+
+type observers []Observer
+
+func (o observers) Start(ctx context.Context, useCase, method string) (context.Context, func(error)) {
+	dones := make([]func(error), len(o))
+	for i, obs := range o {
+		ctx, dones[i] = obs.Start(ctx, useCase, method)
+	}
+	return ctx, func(err error) {
+		for i := len(dones) - 1; i >= 0; i-- {
+			dones[i](err)
+		}
+	}
+}
+
+// observe runs call under obs.
+func observe(ctx context.Context, obs Observer, useCase, method string, call func(context.Context) error) {
+	ctx, done := obs.Start(ctx, useCase, method)
+	done(call(ctx))
+}
+
+// ObserveUser returns a User telling obs about every call of uc.
+func ObserveUser(uc User, obs Observer) User {
+	return observedUser{next: uc, obs: obs}
+}
+
+type observedUser struct {
+	next User
+	obs  Observer
+}
+
+func (o observedUser) CreateUser(ctx context.Context, user entity.UserEntity) (created entity.UserEntity, err error) {
+	observe(ctx, o.obs, ObservedUser, "CreateUser", func(ctx context.Context) error {
+		created, err = o.next.CreateUser(ctx, user)
+		return err
+	})
+	return created, err
+}
+
+func (o observedUser) UpdateUser(ctx context.Context, user entity.UserEntity) (err error) {
+	observe(ctx, o.obs, ObservedUser, "UpdateUser", func(ctx context.Context) error {
+		err = o.next.UpdateUser(ctx, user)
+		return err
+	})
+	return err
+}
+
+func (o observedUser) PatchUser(ctx context.Context, user entity.UserEntity, patch UserPatch) (patched entity.UserEntity, err error) {
+	observe(ctx, o.obs, ObservedUser, "PatchUser", func(ctx context.Context) error {
+		patched, err = o.next.PatchUser(ctx, user, patch)
+		return err
+	})
+	return patched, err
+}
+
+func (o observedUser) DeleteUser(ctx context.Context, user entity.UserEntity) (err error) {
+	observe(ctx, o.obs, ObservedUser, "DeleteUser", func(ctx context.Context) error {
+		err = o.next.DeleteUser(ctx, user)
+		return err
+	})
+	return err
+}
+
+func (o observedUser) GetUserById(ctx context.Context, user entity.UserEntity) (found entity.UserEntity, err error) {
+	observe(ctx, o.obs, ObservedUser, "GetUserById", func(ctx context.Context) error {
+		found, err = o.next.GetUserById(ctx, user)
+		return err
+	})
+	return found, err
+}
+
+func (o observedUser) ListUsers(ctx context.Context, c Criteria, req PageRequest) (page Page[entity.UserEntity], err error) {
+	observe(ctx, o.obs, ObservedUser, "ListUsers", func(ctx context.Context) error {
+		page, err = o.next.ListUsers(ctx, c, req)
+		return err
+	})
+	return page, err
+}
+
+func (o observedUser) ListDeletedUsers(ctx context.Context, c Criteria, req PageRequest) (page Page[entity.UserEntity], err error) {
+	observe(ctx, o.obs, ObservedUser, "ListDeletedUsers", func(ctx context.Context) error {
+		page, err = o.next.ListDeletedUsers(ctx, c, req)
+		return err
+	})
+	return page, err
+}
+
+func (o observedUser) RestoreUser(ctx context.Context, user entity.UserEntity) (restored entity.UserEntity, err error) {
+	observe(ctx, o.obs, ObservedUser, "RestoreUser", func(ctx context.Context) error {
+		restored, err = o.next.RestoreUser(ctx, user)
+		return err
+	})
+	return restored, err
+}
+
+func (o observedUser) PurgeUser(ctx context.Context, user entity.UserEntity) (err error) {
+	observe(ctx, o.obs, ObservedUser, "PurgeUser", func(ctx context.Context) error {
+		err = o.next.PurgeUser(ctx, user)
+		return err
+	})
+	return err
+}
+
+func (o observedUser) PurgeDeletedUsers(ctx context.Context, before time.Time) (n int64, err error) {
+	observe(ctx, o.obs, ObservedUser, "PurgeDeletedUsers", func(ctx context.Context) error {
+		n, err = o.next.PurgeDeletedUsers(ctx, before)
+		return err
+	})
+	return n, err
+}
+
+func (o observedUser) UserHistory(ctx context.Context, user entity.UserEntity, req PageRequest) (page Page[entity.AuditEvent], err error) {
+	observe(ctx, o.obs, ObservedUser, "UserHistory", func(ctx context.Context) error {
+		page, err = o.next.UserHistory(ctx, user, req)
+		return err
+	})
+	return page, err
+}
+
+// ObserveAPIKey returns an APIKey telling obs about every call of uc.
+func ObserveAPIKey(uc APIKey, obs Observer) APIKey {
+	return observedAPIKey{next: uc, obs: obs}
+}
+
+type observedAPIKey struct {
+	next APIKey
+	obs  Observer
+}
+
+func (o observedAPIKey) CreateAPIKey(ctx context.Context, key entity.APIKey) (created entity.APIKey, secret string, err error) {
+	observe(ctx, o.obs, ObservedAPIKey, "CreateAPIKey", func(ctx context.Context) error {
+		created, secret, err = o.next.CreateAPIKey(ctx, key)
+		return err
+	})
+	return created, secret, err
+}
+
+func (o observedAPIKey) ListAPIKeys(ctx context.Context) (keys []entity.APIKey, err error) {
+	observe(ctx, o.obs, ObservedAPIKey, "ListAPIKeys", func(ctx context.Context) error {
+		keys, err = o.next.ListAPIKeys(ctx)
+		return err
+	})
+	return keys, err
+}
+
+func (o observedAPIKey) RevokeAPIKey(ctx context.Context, id uuid.UUID) (err error) {
+	observe(ctx, o.obs, ObservedAPIKey, "RevokeAPIKey", func(ctx context.Context) error {
+		err = o.next.RevokeAPIKey(ctx, id)
+		return err
+	})
+	return err
+}
+
+func (o observedAPIKey) AuthenticateAPIKey(ctx context.Context, key string) (p entity.Principal, err error) {
+	observe(ctx, o.obs, ObservedAPIKey, "AuthenticateAPIKey", func(ctx context.Context) error {
+		p, err = o.next.AuthenticateAPIKey(ctx, key)
+		return err
+	})
+	return p, err
+}
+
+// ObserveLogLevel returns a LogLevel telling obs about every call of uc.
+func ObserveLogLevel(uc LogLevel, obs Observer) LogLevel {
+	return observedLogLevel{next: uc, obs: obs}
+}
+
+type observedLogLevel struct {
+	next LogLevel
+	obs  Observer
+}
+
+func (o observedLogLevel) GetLogLevel(ctx context.Context) (level entity.LogLevel, err error) {
+	observe(ctx, o.obs, ObservedLogLevel, "GetLogLevel", func(ctx context.Context) error {
+		level, err = o.next.GetLogLevel(ctx)
+		return err
+	})
+	return level, err
+}
+
+func (o observedLogLevel) SetLogLevel(ctx context.Context, name string, ttl time.Duration) (level entity.LogLevel, err error) {
+	observe(ctx, o.obs, ObservedLogLevel, "SetLogLevel", func(ctx context.Context) error {
+		level, err = o.next.SetLogLevel(ctx, name, ttl)
+		return err
+	})
+	return level, err
+}
+
+func (o observedLogLevel) ResetLogLevel(ctx context.Context) (level entity.LogLevel, err error) {
+	observe(ctx, o.obs, ObservedLogLevel, "ResetLogLevel", func(ctx context.Context) error {
+		level, err = o.next.ResetLogLevel(ctx)
+		return err
+	})
+	return level, err
+}
+
+// ObserveIdempotency returns an Idempotency telling obs about every call of
+// uc.
+func ObserveIdempotency(uc Idempotency, obs Observer) Idempotency {
+	return observedIdempotency{next: uc, obs: obs}
+}
+
+type observedIdempotency struct {
+	next Idempotency
+	obs  Observer
+}
+
+func (o observedIdempotency) Begin(ctx context.Context, key, fingerprint string) (rec entity.IdempotencyRecord, err error) {
+	observe(ctx, o.obs, ObservedIdempotency, "Begin", func(ctx context.Context) error {
+		rec, err = o.next.Begin(ctx, key, fingerprint)
+		return err
+	})
+	return rec, err
+}
+
+func (o observedIdempotency) Complete(ctx context.Context, rec entity.IdempotencyRecord) (err error) {
+	observe(ctx, o.obs, ObservedIdempotency, "Complete", func(ctx context.Context) error {
+		err = o.next.Complete(ctx, rec)
+		return err
+	})
+	return err
+}
+
+func (o observedIdempotency) Release(ctx context.Context, key string) (err error) {
+	observe(ctx, o.obs, ObservedIdempotency, "Release", func(ctx context.Context) error {
+		err = o.next.Release(ctx, key)
+		return err
+	})
+	return err
+}
+
+func (o observedIdempotency) PurgeExpired(ctx context.Context) (n int64, err error) {
+	observe(ctx, o.obs, ObservedIdempotency, "PurgeExpired", func(ctx context.Context) error {
+		n, err = o.next.PurgeExpired(ctx)
+		return err
+	})
+	return n, err
+}
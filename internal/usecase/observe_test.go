@@ -0,0 +1,96 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/entity"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/usecase"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+type observedKey struct{}
+
+// recordingObserver records the calls it is told about and marks the
+// context the call runs with.
+type recordingObserver struct {
+	calls []string
+	errs  []error
+}
+
+func (r *recordingObserver) Start(ctx context.Context, useCase, method string) (context.Context, func(error)) {
+	r.calls = append(r.calls, useCase+"."+method)
+	return context.WithValue(ctx, observedKey{}, true), func(err error) {
+		r.errs = append(r.errs, err)
+	}
+}
+
+func TestObserveUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	next := mocks.NewMockUser(ctrl)
+	obs := &recordingObserver{}
+	uc := usecase.ObserveUser(next, obs)
+
+	user := entity.UserEntity{ID: uuid.New(), Name: "Ana"}
+	next.EXPECT().GetUserById(gomock.Any(), user).DoAndReturn(func(ctx context.Context, u entity.UserEntity) (entity.UserEntity, error) {
+		assert.Equal(t, true, ctx.Value(observedKey{}))
+		return u, nil
+	})
+	errBoom := errors.New("boom")
+	next.EXPECT().DeleteUser(gomock.Any(), user).Return(errBoom)
+
+	got, err := uc.GetUserById(context.Background(), user)
+	assert.NoError(t, err)
+	assert.Equal(t, user, got)
+	assert.ErrorIs(t, uc.DeleteUser(context.Background(), user), errBoom)
+
+	assert.Equal(t, []string{"user.GetUserById", "user.DeleteUser"}, obs.calls)
+	assert.Equal(t, []error{nil, errBoom}, obs.errs)
+}
+
+func TestObservers(t *testing.T) {
+	var events []string
+	named := func(name string) usecase.Observer {
+		return observerFunc(func(ctx context.Context, useCase, method string) (context.Context, func(error)) {
+			events = append(events, "start "+name)
+			return context.WithValue(ctx, observedKey{}, name), func(error) {
+				events = append(events, "done "+name)
+			}
+		})
+	}
+
+	ctx, done := usecase.Observers(named("tracing"), named("metrics")).Start(context.Background(), "user", "GetUserById")
+	done(nil)
+
+	assert.Equal(t, "metrics", ctx.Value(observedKey{}))
+	assert.Equal(t, []string{"start tracing", "start metrics", "done metrics", "done tracing"}, events)
+}
+
+type observerFunc func(ctx context.Context, useCase, method string) (context.Context, func(error))
+
+func (f observerFunc) Start(ctx context.Context, useCase, method string) (context.Context, func(error)) {
+	return f(ctx, useCase, method)
+}
+
+func TestObserveUseCases(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	apiKeys := mocks.NewMockAPIKey(ctrl)
+	idempotency := mocks.NewMockIdempotency(ctrl)
+	obs := &recordingObserver{}
+	logLevel := mocks.NewMockLogLevel(ctrl)
+	uc := usecase.ObserveUseCases(usecase.NewAppUseCases(mocks.NewMockUser(ctrl), idempotency, apiKeys, logLevel), obs)
+
+	apiKeys.EXPECT().ListAPIKeys(gomock.Any()).Return(nil, nil)
+	idempotency.EXPECT().Release(gomock.Any(), "key").Return(nil)
+	logLevel.EXPECT().ResetLogLevel(gomock.Any()).Return(entity.LogLevel{}, nil)
+
+	_, _ = uc.APIKeyUseCase().ListAPIKeys(context.Background())
+	_ = uc.IdempotencyUseCase().Release(context.Background(), "key")
+	_, _ = uc.LogLevelUseCase().ResetLogLevel(context.Background())
+
+	assert.Equal(t, []string{"api_key.ListAPIKeys", "idempotency.Release", "log_level.ResetLogLevel"}, obs.calls)
+}
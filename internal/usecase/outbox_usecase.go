@@ -0,0 +1,113 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+const (
+	DefaultOutboxBatchSize  = 100
+	DefaultOutboxMinBackoff = time.Second
+	DefaultOutboxMaxBackoff = 5 * time.Minute
+	DefaultOutboxRetention  = 7 * 24 * time.Hour
+)
+
+// OutboxOptions tune the relay. Zero values take the defaults.
+type OutboxOptions struct {
+	BatchSize  int
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+	// Retention is how long published messages are kept.
+	Retention time.Duration
+}
+
+type OutboxUseCase struct {
+	repo      OutboxRepo
+	tx        Transactor
+	publisher Publisher
+	opts      OutboxOptions
+	now       func() time.Time
+}
+
+func NewOutbox(r OutboxRepo, tx Transactor, p Publisher, opts OutboxOptions) *OutboxUseCase {
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = DefaultOutboxBatchSize
+	}
+	if opts.MinBackoff <= 0 {
+		opts.MinBackoff = DefaultOutboxMinBackoff
+	}
+	if opts.MaxBackoff < opts.MinBackoff {
+		opts.MaxBackoff = max(DefaultOutboxMaxBackoff, opts.MinBackoff)
+	}
+	if opts.Retention <= 0 {
+		opts.Retention = DefaultOutboxRetention
+	}
+	return &OutboxUseCase{
+		repo:      r,
+		tx:        tx,
+		publisher: p,
+		opts:      opts,
+		now:       time.Now,
+	}
+}
+
+// Relay publishes a batch of due messages and returns how many were
+// published. A message that fails to publish is retried after a backoff
+// that doubles with every attempt. The outcome is stored after publishing,
+// so a message is published again if storing it fails.
+func (uc *OutboxUseCase) Relay(ctx context.Context) (int, error) {
+
+	published := 0
+	err := uc.tx.RunInTx(ctx, func(ctx context.Context) error {
+		published = 0
+		msgs, err := uc.repo.Claim(ctx, uc.now(), uc.opts.BatchSize)
+		if err != nil {
+			return err
+		}
+
+		for _, msg := range msgs {
+			msg.Attempts++
+			now := uc.now()
+			if err := uc.publisher.Publish(ctx, msg.Event); err != nil {
+				msg.NextAttemptAt = now.Add(uc.backoff(msg.Attempts))
+				msg.LastError = err.Error()
+			} else {
+				msg.PublishedAt = &now
+				msg.LastError = ""
+				published++
+			}
+
+			if err := uc.repo.SaveDelivery(ctx, msg); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("Relay: %w", err)
+	}
+
+	return published, nil
+}
+
+// PurgePublished deletes the messages published longer than the retention ago.
+func (uc *OutboxUseCase) PurgePublished(ctx context.Context) (int64, error) {
+
+	n, err := uc.repo.DeletePublished(ctx, uc.now().Add(-uc.opts.Retention))
+	if err != nil {
+		return 0, fmt.Errorf("PurgePublished: %w", err)
+	}
+
+	return n, nil
+}
+
+// backoff returns the delay before the next attempt of a message that has
+// failed attempts times.
+func (uc *OutboxUseCase) backoff(attempts int) time.Duration {
+	d := uc.opts.MinBackoff
+	for i := 1; i < attempts && d < uc.opts.MaxBackoff; i++ {
+		d *= 2
+	}
+	return min(d, uc.opts.MaxBackoff)
+}
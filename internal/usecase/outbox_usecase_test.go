@@ -0,0 +1,92 @@
+package usecase_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/entity"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/usecase"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestOutboxRelay(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockOutboxRepo(ctrl)
+	mockPublisher := mocks.NewMockPublisher(ctrl)
+	uc := usecase.NewOutbox(mockRepo, inlineTx{}, mockPublisher, usecase.OutboxOptions{BatchSize: 10, MinBackoff: time.Second, MaxBackoff: 3 * time.Second})
+
+	ok := entity.OutboxMessage{Event: entity.Event{ID: uuid.New(), Type: entity.UserCreated}}
+	failing := entity.OutboxMessage{Event: entity.Event{ID: uuid.New(), Type: entity.UserUpdated}, Attempts: 2}
+
+	var saved []entity.OutboxMessage
+	mockRepo.EXPECT().Claim(gomock.Any(), gomock.Any(), 10).Return([]entity.OutboxMessage{ok, failing}, nil)
+	mockPublisher.EXPECT().Publish(gomock.Any(), ok.Event).Return(nil)
+	mockPublisher.EXPECT().Publish(gomock.Any(), failing.Event).Return(fmt.Errorf("broker down"))
+	mockRepo.EXPECT().SaveDelivery(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, msg entity.OutboxMessage) error {
+		saved = append(saved, msg)
+		return nil
+	}).Times(2)
+
+	n, err := uc.Relay(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+	require.Len(t, saved, 2)
+
+	assert.Equal(t, 1, saved[0].Attempts)
+	require.NotNil(t, saved[0].PublishedAt)
+	assert.WithinDuration(t, time.Now(), *saved[0].PublishedAt, time.Minute)
+	assert.Empty(t, saved[0].LastError)
+
+	// The third attempt backs off 4s, capped at MaxBackoff.
+	assert.Equal(t, 3, saved[1].Attempts)
+	assert.Nil(t, saved[1].PublishedAt)
+	assert.Equal(t, "broker down", saved[1].LastError)
+	assert.WithinDuration(t, time.Now().Add(3*time.Second), saved[1].NextAttemptAt, time.Second)
+}
+
+func TestOutboxRelay_Errors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockOutboxRepo(ctrl)
+	mockPublisher := mocks.NewMockPublisher(ctrl)
+	uc := usecase.NewOutbox(mockRepo, inlineTx{}, mockPublisher, usecase.OutboxOptions{})
+
+	mockRepo.EXPECT().Claim(gomock.Any(), gomock.Any(), usecase.DefaultOutboxBatchSize).Return(nil, fmt.Errorf("db down"))
+	_, err := uc.Relay(context.Background())
+	assert.EqualError(t, err, "Relay: db down")
+
+	msg := entity.OutboxMessage{Event: entity.Event{ID: uuid.New()}}
+	mockRepo.EXPECT().Claim(gomock.Any(), gomock.Any(), gomock.Any()).Return([]entity.OutboxMessage{msg}, nil)
+	mockPublisher.EXPECT().Publish(gomock.Any(), msg.Event).Return(nil)
+	mockRepo.EXPECT().SaveDelivery(gomock.Any(), gomock.Any()).Return(fmt.Errorf("db down"))
+	n, err := uc.Relay(context.Background())
+	assert.EqualError(t, err, "Relay: db down")
+	assert.Zero(t, n)
+}
+
+func TestOutboxPurgePublished(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockOutboxRepo(ctrl)
+	uc := usecase.NewOutbox(mockRepo, inlineTx{}, mocks.NewMockPublisher(ctrl), usecase.OutboxOptions{Retention: time.Hour})
+
+	mockRepo.EXPECT().DeletePublished(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, before time.Time) (int64, error) {
+		assert.WithinDuration(t, time.Now().Add(-time.Hour), before, time.Minute)
+		return 3, nil
+	})
+
+	n, err := uc.PurgePublished(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), n)
+}
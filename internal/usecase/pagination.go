@@ -0,0 +1,99 @@
+package usecase
+
+import (
+	"encoding/base64"
+	"encoding/json"
+
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/apperror"
+	"github.com/google/uuid"
+)
+
+const (
+	DefaultPageLimit = 20
+	MaxPageLimit     = 100
+)
+
+// PageRequest selects a page either by Offset or by Cursor (keyset), never both.
+type PageRequest struct {
+	Limit     int
+	Offset    int
+	Cursor    *Cursor
+	WithTotal bool
+}
+
+// Page is one page of a listing. Total is only set when requested.
+type Page[T any] struct {
+	Items      []T
+	Total      *int64
+	NextCursor *Cursor
+	HasMore    bool
+}
+
+// Cursor is the keyset position of the last item of a page: the values of
+// the sort fields, in sort order, followed by the id as tie-breaker. Sort
+// records the sort the cursor was issued for so it cannot be replayed with
+// a different one.
+type Cursor struct {
+	Sort   string    `json:"s"`
+	Values []any     `json:"v"`
+	ID     uuid.UUID `json:"i"`
+}
+
+// Encode returns the opaque representation handed out to clients.
+func (c Cursor) Encode() string {
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func DecodeCursor(s string) (*Cursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, invalidCursor(err)
+	}
+
+	var c Cursor
+	if err := json.Unmarshal(b, &c); err != nil || c.ID == uuid.Nil || len(c.Values) == 0 {
+		return nil, invalidCursor(err)
+	}
+
+	return &c, nil
+}
+
+// resolve checks that the cursor belongs to the resolved criteria sort and
+// converts its values to the field types.
+func (c *Cursor) resolve(criteria Criteria, fields Fields) (*Cursor, error) {
+	if c.Sort != criteria.SortKey() || len(c.Values) != len(criteria.Sort) {
+		return nil, apperror.Wrap(nil, apperror.KindValidation, "invalid_cursor", "cursor does not match the requested sort")
+	}
+
+	resolved := &Cursor{Sort: c.Sort, Values: make([]any, len(c.Values)), ID: c.ID}
+	for i, o := range criteria.Sort {
+		v, err := parseValue(fields[o.Field].Type, c.Values[i])
+		if err != nil {
+			return nil, invalidCursor(err)
+		}
+		resolved.Values[i] = v
+	}
+
+	return resolved, nil
+}
+
+func invalidCursor(err error) error {
+	return apperror.Wrap(err, apperror.KindValidation, "invalid_cursor", "invalid cursor")
+}
+
+func (r PageRequest) normalize() (PageRequest, error) {
+	if r.Cursor != nil && r.Offset > 0 {
+		return r, apperror.Validation("offset and cursor cannot be combined", nil)
+	}
+	if r.Offset < 0 {
+		return r, apperror.Validation("offset must not be negative", nil)
+	}
+	switch {
+	case r.Limit <= 0:
+		r.Limit = DefaultPageLimit
+	case r.Limit > MaxPageLimit:
+		r.Limit = MaxPageLimit
+	}
+	return r, nil
+}
@@ -0,0 +1,27 @@
+package usecase_test
+
+import (
+	"testing"
+
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/apperror"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/usecase"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCursor_EncodeDecode(t *testing.T) {
+	c := usecase.Cursor{Sort: "-created_at,name", Values: []any{"2026-01-02T03:04:05.123456Z", "Ana"}, ID: uuid.New()}
+
+	decoded, err := usecase.DecodeCursor(c.Encode())
+
+	require.NoError(t, err)
+	assert.Equal(t, c, *decoded)
+}
+
+func TestDecodeCursor_Invalid(t *testing.T) {
+	for _, s := range []string{"not base64!", "e30", "bm90IGpzb24"} {
+		_, err := usecase.DecodeCursor(s)
+		assert.ErrorIs(t, err, apperror.ErrValidation, s)
+	}
+}
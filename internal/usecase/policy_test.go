@@ -0,0 +1,98 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/apperror"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/entity"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/reqctx"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/usecase"
+	"github.com/DeSouzaRafael/go-clean-architecture-template/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestDefaultPolicy(t *testing.T) {
+	self := uuid.New()
+	other := uuid.New()
+	admin := entity.Principal{Subject: "admin-1", Roles: []string{entity.RoleAdmin}}
+	support := entity.Principal{Subject: "support-1", Roles: []string{entity.RoleSupport}}
+	user := entity.Principal{Subject: self.String()}
+	reader := entity.Principal{Subject: "client-1", Scopes: []string{usecase.ScopeUsersRead}}
+
+	tests := []struct {
+		name      string
+		principal entity.Principal
+		perm      usecase.Permission
+		owner     uuid.UUID
+		allowed   bool
+	}{
+		{"admin deletes", admin, usecase.PermDeleteUser, other, true},
+		{"admin purges", admin, usecase.PermPurgeUser, uuid.Nil, true},
+		{"support reads", support, usecase.PermReadUser, other, true},
+		{"support lists", support, usecase.PermListUsers, uuid.Nil, true},
+		{"support updates", support, usecase.PermUpdateUser, other, true},
+		{"support cannot delete", support, usecase.PermDeleteUser, other, false},
+		{"support cannot restore", support, usecase.PermRestoreUser, other, false},
+		{"user reads self", user, usecase.PermReadUser, self, true},
+		{"user updates self", user, usecase.PermUpdateUser, self, true},
+		{"user reads own history", user, usecase.PermReadUserHistory, self, true},
+		{"user cannot read others", user, usecase.PermReadUser, other, false},
+		{"user cannot update others", user, usecase.PermUpdateUser, other, false},
+		{"user cannot delete self", user, usecase.PermDeleteUser, self, false},
+		{"user cannot list", user, usecase.PermListUsers, uuid.Nil, false},
+		{"scope reads", reader, usecase.PermReadUser, other, true},
+		{"scope cannot write", reader, usecase.PermUpdateUser, other, false},
+		{"unknown permission", admin, usecase.Permission("user.unknown"), other, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := reqctx.WithPrincipal(context.Background(), tt.principal)
+
+			err := usecase.DefaultPolicy.Authorize(ctx, tt.perm, tt.owner)
+
+			if tt.allowed {
+				assert.NoError(t, err)
+			} else {
+				assert.Equal(t, apperror.KindForbidden, apperror.KindOf(err))
+			}
+		})
+	}
+}
+
+func TestPolicy_Unauthenticated(t *testing.T) {
+	err := usecase.DefaultPolicy.Authorize(context.Background(), usecase.PermReadUser, uuid.New())
+	assert.Equal(t, apperror.KindUnauthorized, apperror.KindOf(err))
+}
+
+func TestUserUseCase_Forbidden(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	uc := usecase.NewUser(mocks.NewMockUserRepo(ctrl), inlineTx{}, nopAudit{}, nopOutbox{}, usecase.DefaultPolicy)
+	ctx := reqctx.WithPrincipal(context.Background(), entity.Principal{Subject: "support-1", Roles: []string{entity.RoleSupport}})
+
+	// The repository is never reached: the mock has no expectations.
+	err := uc.DeleteUser(ctx, entity.UserEntity{ID: uuid.New()})
+
+	require.Error(t, err)
+	assert.Equal(t, apperror.KindForbidden, apperror.KindOf(err))
+}
+
+func TestUserUseCase_OwnRecord(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockRepo := mocks.NewMockUserRepo(ctrl)
+	uc := usecase.NewUser(mockRepo, inlineTx{}, nopAudit{}, nopOutbox{}, usecase.DefaultPolicy)
+	self := entity.UserEntity{ID: uuid.New(), Name: "Ana"}
+	ctx := reqctx.WithPrincipal(context.Background(), entity.Principal{Subject: self.ID.String()})
+
+	mockRepo.EXPECT().GetById(ctx, entity.UserEntity{ID: self.ID}).Return(self, nil)
+
+	got, err := uc.GetUserById(ctx, entity.UserEntity{ID: self.ID})
+	require.NoError(t, err)
+	assert.Equal(t, self, got)
+
+	_, err = uc.GetUserById(ctx, entity.UserEntity{ID: uuid.New()})
+	assert.Equal(t, apperror.KindForbidden, apperror.KindOf(err))
+}
@@ -0,0 +1,67 @@
+package usecase
+
+import (
+	"fmt"
+	"strings"
+)
+
+// IsolationLevel is a storage-agnostic transaction isolation level.
+type IsolationLevel int
+
+const (
+	IsolationDefault IsolationLevel = iota
+	IsolationReadCommitted
+	IsolationRepeatableRead
+	IsolationSerializable
+)
+
+func (l IsolationLevel) String() string {
+	switch l {
+	case IsolationReadCommitted:
+		return "read committed"
+	case IsolationRepeatableRead:
+		return "repeatable read"
+	case IsolationSerializable:
+		return "serializable"
+	default:
+		return "default"
+	}
+}
+
+// ParseIsolationLevel accepts the names returned by String, with spaces,
+// dashes or underscores. An empty string is IsolationDefault.
+func ParseIsolationLevel(s string) (IsolationLevel, error) {
+	name := strings.NewReplacer("-", " ", "_", " ").Replace(strings.ToLower(strings.TrimSpace(s)))
+	for _, l := range []IsolationLevel{IsolationDefault, IsolationReadCommitted, IsolationRepeatableRead, IsolationSerializable} {
+		if name == l.String() {
+			return l, nil
+		}
+	}
+	if name == "" {
+		return IsolationDefault, nil
+	}
+	return IsolationDefault, fmt.Errorf("unknown isolation level %q", s)
+}
+
+type TxOptions struct {
+	Isolation IsolationLevel
+	ReadOnly  bool
+}
+
+type TxOption func(*TxOptions)
+
+func WithIsolation(l IsolationLevel) TxOption {
+	return func(o *TxOptions) { o.Isolation = l }
+}
+
+func ReadOnly() TxOption {
+	return func(o *TxOptions) { o.ReadOnly = true }
+}
+
+// ApplyTxOptions returns base with opts applied.
+func ApplyTxOptions(base TxOptions, opts ...TxOption) TxOptions {
+	for _, opt := range opts {
+		opt(&base)
+	}
+	return base
+}
@@ -0,0 +1,34 @@
+package usecase_test
+
+import (
+	"testing"
+
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/usecase"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseIsolationLevel(t *testing.T) {
+	tests := map[string]usecase.IsolationLevel{
+		"":                usecase.IsolationDefault,
+		"read_committed":  usecase.IsolationReadCommitted,
+		"Repeatable Read": usecase.IsolationRepeatableRead,
+		"serializable":    usecase.IsolationSerializable,
+	}
+	for in, expected := range tests {
+		l, err := usecase.ParseIsolationLevel(in)
+		assert.NoError(t, err, in)
+		assert.Equal(t, expected, l, in)
+	}
+
+	_, err := usecase.ParseIsolationLevel("snapshot")
+	assert.Error(t, err)
+}
+
+func TestApplyTxOptions(t *testing.T) {
+	base := usecase.TxOptions{Isolation: usecase.IsolationReadCommitted}
+
+	o := usecase.ApplyTxOptions(base, usecase.WithIsolation(usecase.IsolationSerializable), usecase.ReadOnly())
+
+	assert.Equal(t, usecase.TxOptions{Isolation: usecase.IsolationSerializable, ReadOnly: true}, o)
+	assert.Equal(t, usecase.IsolationReadCommitted, base.Isolation)
+}
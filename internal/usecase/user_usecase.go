@@ -2,27 +2,64 @@ package usecase
 
 import (
 	"context"
+	"time"
 
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/apperror"
 	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/entity"
+	"github.com/google/uuid"
 
 	"fmt"
 )
 
+// UserFields are the user attributes that listings may filter and sort on.
+var UserFields = Fields{
+	"name":       {Type: FieldString, Sortable: true},
+	"phone":      {Type: FieldString},
+	"created_at": {Type: FieldTime, Sortable: true},
+	"updated_at": {Type: FieldTime, Sortable: true},
+}
+
+// DeletedUserFields are the attributes listings of deleted users may filter
+// and sort on.
+var DeletedUserFields = Fields{
+	"name":       {Type: FieldString, Sortable: true},
+	"phone":      {Type: FieldString},
+	"created_at": {Type: FieldTime, Sortable: true},
+	"updated_at": {Type: FieldTime, Sortable: true},
+	"deleted_at": {Type: FieldTime, Sortable: true},
+}
+
 type UserUseCase struct {
-	repo UserRepo
+	repo   UserRepo
+	tx     Transactor
+	audit  AuditRepo
+	outbox OutboxRepo
+	authz  Authorizer
 }
 
-func NewUser(c UserRepo) *UserUseCase {
+// NewUser returns the user use case. Every mutation is recorded in audit and
+// raises an event in outbox, both in the same transaction as the write itself.
+// Every operation is first checked by authz, so all transports enforce the
+// same permissions.
+func NewUser(c UserRepo, tx Transactor, audit AuditRepo, outbox OutboxRepo, authz Authorizer) *UserUseCase {
 	return &UserUseCase{
-		repo: c,
+		repo:   c,
+		tx:     tx,
+		audit:  audit,
+		outbox: outbox,
+		authz:  authz,
 	}
 }
 
 func (uc *UserUseCase) GetUserById(ctx context.Context, user entity.UserEntity) (entity.UserEntity, error) {
 
+	if err := uc.authz.Authorize(ctx, PermReadUser, user.ID); err != nil {
+		return entity.UserEntity{}, fmt.Errorf("GetUserById: %w", err)
+	}
+
 	user, err := uc.repo.GetById(ctx, user)
 	if err != nil {
-		return entity.UserEntity{}, fmt.Errorf("GetUserById: %w", err)
+		return entity.UserEntity{}, fmt.Errorf("GetUserById: %w", userError(err))
 	}
 
 	return user, nil
@@ -30,7 +67,18 @@ func (uc *UserUseCase) GetUserById(ctx context.Context, user entity.UserEntity)
 
 func (uc *UserUseCase) CreateUser(ctx context.Context, user entity.UserEntity) (entity.UserEntity, error) {
 
-	user, err := uc.repo.Create(ctx, user)
+	if err := uc.authz.Authorize(ctx, PermCreateUser, uuid.Nil); err != nil {
+		return entity.UserEntity{}, fmt.Errorf("CreateUser: %w", err)
+	}
+
+	err := uc.tx.RunInTx(ctx, func(ctx context.Context) error {
+		var err error
+		if user, err = uc.repo.Create(ctx, user); err != nil {
+			return userError(err)
+		}
+
+		return uc.record(ctx, user.ID, entity.AuditCreate, nil, &user)
+	})
 	if err != nil {
 		return entity.UserEntity{}, fmt.Errorf("CreateUser: %w", err)
 	}
@@ -38,14 +86,34 @@ func (uc *UserUseCase) CreateUser(ctx context.Context, user entity.UserEntity) (
 	return user, nil
 }
 
+// UpdateUser replaces the user's mutable fields in one transaction. A non-zero
+// user.Version must match the stored version; the write itself is
+// conditional on it either way.
 func (uc *UserUseCase) UpdateUser(ctx context.Context, user entity.UserEntity) error {
 
-	_, err := uc.repo.GetById(ctx, user)
-	if err != nil {
+	if err := uc.authz.Authorize(ctx, PermUpdateUser, user.ID); err != nil {
 		return fmt.Errorf("UpdateUser: %w", err)
 	}
 
-	err = uc.repo.Update(ctx, user)
+	err := uc.tx.RunInTx(ctx, func(ctx context.Context) error {
+		current, err := uc.repo.GetById(ctx, user)
+		if err != nil {
+			return userError(err)
+		}
+
+		if err := checkUserVersion(current, user.Version); err != nil {
+			return userError(err)
+		}
+		user.Version = current.Version
+
+		if err := uc.repo.Update(ctx, user); err != nil {
+			return userError(err)
+		}
+
+		updated := current
+		updated.Name, updated.Phone, updated.Version = user.Name, user.Phone, current.Version+1
+		return uc.record(ctx, user.ID, entity.AuditUpdate, &current, &updated)
+	})
 	if err != nil {
 		return fmt.Errorf("UpdateUser: %w", err)
 	}
@@ -53,17 +121,361 @@ func (uc *UserUseCase) UpdateUser(ctx context.Context, user entity.UserEntity) e
 	return nil
 }
 
-func (uc *UserUseCase) DeleteUser(ctx context.Context, user entity.UserEntity) error {
+// PatchUser applies patch to the stored user and persists only the fields it
+// changed. Identity, timestamps and version cannot be patched. A non-zero
+// user.Version must match the stored version.
+func (uc *UserUseCase) PatchUser(ctx context.Context, user entity.UserEntity, patch UserPatch) (entity.UserEntity, error) {
+
+	if err := uc.authz.Authorize(ctx, PermUpdateUser, user.ID); err != nil {
+		return entity.UserEntity{}, fmt.Errorf("PatchUser: %w", err)
+	}
+
+	var updated entity.UserEntity
+	err := uc.tx.RunInTx(ctx, func(ctx context.Context) error {
+		current, err := uc.repo.GetById(ctx, user)
+		if err != nil {
+			return userError(err)
+		}
+
+		if err := checkUserVersion(current, user.Version); err != nil {
+			return userError(err)
+		}
+
+		patched, err := patch(current)
+		if err != nil {
+			return err
+		}
+
+		changed := changedUserFields(current, patched)
+		if len(changed) == 0 {
+			updated = current
+			return nil
+		}
 
-	_, err := uc.repo.GetById(ctx, user)
+		patched.ID = current.ID
+		patched.CreatedAt = current.CreatedAt
+		patched.UpdatedAt = current.UpdatedAt
+		patched.DeletedAt = current.DeletedAt
+		patched.Version = current.Version
+
+		updated, err = uc.repo.UpdateFields(ctx, patched, changed...)
+		if err != nil {
+			return userError(err)
+		}
+
+		return uc.record(ctx, updated.ID, entity.AuditUpdate, &current, &updated)
+	})
 	if err != nil {
+		return entity.UserEntity{}, fmt.Errorf("PatchUser: %w", err)
+	}
+
+	return updated, nil
+}
+
+// DeleteUser deletes the user. A non-zero user.Version must match the stored version.
+func (uc *UserUseCase) DeleteUser(ctx context.Context, user entity.UserEntity) error {
+
+	if err := uc.authz.Authorize(ctx, PermDeleteUser, user.ID); err != nil {
 		return fmt.Errorf("DeleteUser: %w", err)
 	}
 
-	err = uc.repo.DeleteById(ctx, user)
+	err := uc.tx.RunInTx(ctx, func(ctx context.Context) error {
+		current, err := uc.repo.GetById(ctx, user)
+		if err != nil {
+			return userError(err)
+		}
+
+		if err := checkUserVersion(current, user.Version); err != nil {
+			return userError(err)
+		}
+		user.Version = current.Version
+
+		if err := uc.repo.DeleteById(ctx, user); err != nil {
+			return userError(err)
+		}
+
+		return uc.record(ctx, current.ID, entity.AuditDelete, &current, nil)
+	})
 	if err != nil {
 		return fmt.Errorf("DeleteUser: %w", err)
 	}
 
 	return nil
 }
+
+func (uc *UserUseCase) ListUsers(ctx context.Context, criteria Criteria, req PageRequest) (Page[entity.UserEntity], error) {
+
+	if err := uc.authz.Authorize(ctx, PermListUsers, uuid.Nil); err != nil {
+		return Page[entity.UserEntity]{}, fmt.Errorf("ListUsers: %w", err)
+	}
+
+	page, err := listUsers(ctx, criteria, req, UserFields, uc.repo.List)
+	if err != nil {
+		return Page[entity.UserEntity]{}, fmt.Errorf("ListUsers: %w", err)
+	}
+
+	return page, nil
+}
+
+// ListDeletedUsers lists soft-deleted users.
+func (uc *UserUseCase) ListDeletedUsers(ctx context.Context, criteria Criteria, req PageRequest) (Page[entity.UserEntity], error) {
+
+	if err := uc.authz.Authorize(ctx, PermListDeleted, uuid.Nil); err != nil {
+		return Page[entity.UserEntity]{}, fmt.Errorf("ListDeletedUsers: %w", err)
+	}
+
+	page, err := listUsers(ctx, criteria, req, DeletedUserFields, uc.repo.ListDeleted)
+	if err != nil {
+		return Page[entity.UserEntity]{}, fmt.Errorf("ListDeletedUsers: %w", err)
+	}
+
+	return page, nil
+}
+
+// RestoreUser undeletes a soft-deleted user and returns it with its new version.
+func (uc *UserUseCase) RestoreUser(ctx context.Context, user entity.UserEntity) (entity.UserEntity, error) {
+
+	if err := uc.authz.Authorize(ctx, PermRestoreUser, user.ID); err != nil {
+		return entity.UserEntity{}, fmt.Errorf("RestoreUser: %w", err)
+	}
+
+	var restored entity.UserEntity
+	err := uc.tx.RunInTx(ctx, func(ctx context.Context) error {
+		if err := uc.repo.Restore(ctx, user); err != nil {
+			return deletedUserError(err)
+		}
+
+		var err error
+		if restored, err = uc.repo.GetById(ctx, user); err != nil {
+			return userError(err)
+		}
+
+		return uc.record(ctx, restored.ID, entity.AuditRestore, nil, &restored)
+	})
+	if err != nil {
+		return entity.UserEntity{}, fmt.Errorf("RestoreUser: %w", err)
+	}
+
+	return restored, nil
+}
+
+// PurgeUser permanently erases a user. Only soft-deleted users can be purged.
+func (uc *UserUseCase) PurgeUser(ctx context.Context, user entity.UserEntity) error {
+
+	if err := uc.authz.Authorize(ctx, PermPurgeUser, user.ID); err != nil {
+		return fmt.Errorf("PurgeUser: %w", err)
+	}
+
+	err := uc.tx.RunInTx(ctx, func(ctx context.Context) error {
+		if err := uc.repo.Purge(ctx, user); err != nil {
+			return deletedUserError(err)
+		}
+
+		return uc.recordPurge(ctx, user.ID)
+	})
+	if err != nil {
+		return fmt.Errorf("PurgeUser: %w", err)
+	}
+
+	return nil
+}
+
+// PurgeDeletedUsers permanently erases the users deleted before the cutoff
+// and returns how many were erased.
+func (uc *UserUseCase) PurgeDeletedUsers(ctx context.Context, before time.Time) (int64, error) {
+
+	if err := uc.authz.Authorize(ctx, PermPurgeUser, uuid.Nil); err != nil {
+		return 0, fmt.Errorf("PurgeDeletedUsers: %w", err)
+	}
+
+	if before.IsZero() {
+		return 0, fmt.Errorf("PurgeDeletedUsers: %w", apperror.Validation("a cutoff time is required", nil))
+	}
+
+	var purged []uuid.UUID
+	err := uc.tx.RunInTx(ctx, func(ctx context.Context) error {
+		var err error
+		if purged, err = uc.repo.PurgeDeletedBefore(ctx, before); err != nil {
+			return err
+		}
+
+		for _, id := range purged {
+			if err := uc.recordPurge(ctx, id); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("PurgeDeletedUsers: %w", err)
+	}
+
+	return int64(len(purged)), nil
+}
+
+// UserHistory returns the audit events of a user, most recent first. The
+// history outlives the user: after a purge it still tells who changed which
+// field and when, but no longer the values.
+func (uc *UserUseCase) UserHistory(ctx context.Context, user entity.UserEntity, req PageRequest) (Page[entity.AuditEvent], error) {
+
+	if err := uc.authz.Authorize(ctx, PermReadUserHistory, user.ID); err != nil {
+		return Page[entity.AuditEvent]{}, fmt.Errorf("UserHistory: %w", err)
+	}
+
+	req, err := checkHistoryPage(req)
+	if err != nil {
+		return Page[entity.AuditEvent]{}, fmt.Errorf("UserHistory: %w", err)
+	}
+
+	page, err := uc.audit.List(ctx, auditEntityUser, user.ID, req)
+	if err != nil {
+		return Page[entity.AuditEvent]{}, fmt.Errorf("UserHistory: %w", err)
+	}
+
+	return page, nil
+}
+
+// record appends the audit event and the domain event of a user mutation.
+// It must run in the transaction of the mutation so that all of them are
+// committed or none is.
+func (uc *UserUseCase) record(ctx context.Context, id uuid.UUID, action entity.AuditAction, before, after *entity.UserEntity) error {
+	if err := uc.audit.Append(ctx, newAuditEvent(ctx, auditEntityUser, id, action, diffUser(before, after))); err != nil {
+		return err
+	}
+
+	state := after
+	if state == nil {
+		state = before
+	}
+	if state == nil {
+		state = &entity.UserEntity{ID: id}
+	}
+	event, err := newUserEvent(userEvents[action], *state)
+	if err != nil {
+		return err
+	}
+	return uc.outbox.Append(ctx, event)
+}
+
+// recordPurge redacts the audit events and the domain events recorded for a
+// user, so that the erased data does not live on in them, and records the
+// purge, which has neither state. It must run in the transaction of the purge.
+func (uc *UserUseCase) recordPurge(ctx context.Context, id uuid.UUID) error {
+	if err := uc.audit.Redact(ctx, auditEntityUser, id); err != nil {
+		return err
+	}
+
+	payload, err := userPayload(entity.UserEntity{ID: id})
+	if err != nil {
+		return err
+	}
+	if err := uc.outbox.Redact(ctx, aggregateUser, id, payload); err != nil {
+		return err
+	}
+
+	return uc.record(ctx, id, entity.AuditPurge, nil, nil)
+}
+
+func listUsers(
+	ctx context.Context,
+	criteria Criteria,
+	req PageRequest,
+	fields Fields,
+	list func(context.Context, Criteria, PageRequest) (Page[entity.UserEntity], error),
+) (Page[entity.UserEntity], error) {
+
+	req, err := req.normalize()
+	if err != nil {
+		return Page[entity.UserEntity]{}, err
+	}
+
+	criteria, err = criteria.resolve(fields)
+	if err != nil {
+		return Page[entity.UserEntity]{}, err
+	}
+
+	if req.Cursor != nil {
+		if req.Cursor, err = req.Cursor.resolve(criteria, fields); err != nil {
+			return Page[entity.UserEntity]{}, err
+		}
+	}
+
+	page, err := list(ctx, criteria, req)
+	if err != nil {
+		return Page[entity.UserEntity]{}, err
+	}
+
+	if page.HasMore && len(page.Items) > 0 {
+		last := page.Items[len(page.Items)-1]
+		page.NextCursor = &Cursor{Sort: criteria.SortKey(), Values: make([]any, 0, len(criteria.Sort)), ID: last.ID}
+		for _, o := range criteria.Sort {
+			page.NextCursor.Values = append(page.NextCursor.Values, userFieldValue(last, o.Field))
+		}
+	}
+
+	return page, nil
+}
+
+// userError gives repository errors a user specific code and message.
+func userError(err error) error {
+	switch apperror.KindOf(err) {
+	case apperror.KindNotFound:
+		return apperror.Wrap(err, apperror.KindNotFound, "user_not_found", "user not found")
+	case apperror.KindConflict:
+		return apperror.Wrap(err, apperror.KindConflict, "user_conflict", "user already exists")
+	case apperror.KindPreconditionFailed:
+		return apperror.Wrap(err, apperror.KindPreconditionFailed, "user_version_mismatch", "user has been modified since it was read")
+	default:
+		return err
+	}
+}
+
+// deletedUserError reports a missing soft-deleted user with its own code, as
+// the user may well exist without being deleted.
+func deletedUserError(err error) error {
+	if apperror.KindOf(err) == apperror.KindNotFound {
+		return apperror.Wrap(err, apperror.KindNotFound, "deleted_user_not_found", "deleted user not found")
+	}
+	return userError(err)
+}
+
+// checkUserVersion fails when an expected version is given and differs from
+// the stored one. Zero means the caller has no precondition.
+func checkUserVersion(current entity.UserEntity, version int64) error {
+	if version != 0 && version != current.Version {
+		return apperror.PreconditionFailed("version mismatch", nil)
+	}
+	return nil
+}
+
+func userFieldValue(u entity.UserEntity, field string) any {
+	switch field {
+	case "name":
+		return u.Name
+	case "phone":
+		return u.Phone
+	case "created_at":
+		return u.CreatedAt
+	case "updated_at":
+		return u.UpdatedAt
+	case "deleted_at":
+		if u.DeletedAt != nil {
+			return *u.DeletedAt
+		}
+		return nil
+	default:
+		return nil
+	}
+}
+
+// changedUserFields returns the mutable fields that differ between a and b.
+func changedUserFields(a, b entity.UserEntity) []string {
+	var fields []string
+	if a.Name != b.Name {
+		fields = append(fields, "name")
+	}
+	if a.Phone != b.Phone {
+		fields = append(fields, "phone")
+	}
+	return fields
+}
@@ -3,15 +3,19 @@ package usecase_test
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"testing"
+	"time"
 
+	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/apperror"
 	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/entity"
 	"github.com/DeSouzaRafael/go-clean-architecture-template/internal/usecase"
 	"github.com/DeSouzaRafael/go-clean-architecture-template/mocks"
 	"go.uber.org/mock/gomock"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestNewAppUseCases(t *testing.T) {
@@ -19,12 +23,18 @@ func TestNewAppUseCases(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepo := mocks.NewMockUserRepo(ctrl)
-	userUseCase := usecase.NewUser(mockRepo)
+	userUseCase := usecase.NewUser(mockRepo, inlineTx{}, nopAudit{}, nopOutbox{}, usecase.AllowAll)
+	idempotencyUseCase := usecase.NewIdempotency(mocks.NewMockIdempotencyRepo(ctrl), 0)
+	apiKeyUseCase := usecase.NewAPIKey(mocks.NewMockAPIKeyRepo(ctrl), usecase.AllowAll)
+	logLevelUseCase := usecase.NewLogLevel(mocks.NewMockLogLevelController(ctrl), usecase.AllowAll)
 
-	appUseCases := usecase.NewAppUseCases(userUseCase)
+	appUseCases := usecase.NewAppUseCases(userUseCase, idempotencyUseCase, apiKeyUseCase, logLevelUseCase)
 
 	assert.NotNil(t, appUseCases)
 	assert.Equal(t, userUseCase, appUseCases.UserUseCase())
+	assert.Equal(t, idempotencyUseCase, appUseCases.IdempotencyUseCase())
+	assert.Equal(t, apiKeyUseCase, appUseCases.APIKeyUseCase())
+	assert.Equal(t, logLevelUseCase, appUseCases.LogLevelUseCase())
 }
 
 func TestCreateUser(t *testing.T) {
@@ -32,7 +42,7 @@ func TestCreateUser(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepo := mocks.NewMockUserRepo(ctrl)
-	uc := usecase.NewUser(mockRepo)
+	uc := usecase.NewUser(mockRepo, inlineTx{}, nopAudit{}, nopOutbox{}, usecase.AllowAll)
 
 	user := entity.UserEntity{
 		ID:   uuid.New(),
@@ -61,7 +71,7 @@ func TestGetUserById(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepo := mocks.NewMockUserRepo(ctrl)
-	uc := usecase.NewUser(mockRepo)
+	uc := usecase.NewUser(mockRepo, inlineTx{}, nopAudit{}, nopOutbox{}, usecase.AllowAll)
 
 	user := entity.UserEntity{
 		ID:   uuid.New(),
@@ -90,7 +100,7 @@ func TestUpdateUser(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepo := mocks.NewMockUserRepo(ctrl)
-	uc := usecase.NewUser(mockRepo)
+	uc := usecase.NewUser(mockRepo, inlineTx{}, nopAudit{}, nopOutbox{}, usecase.AllowAll)
 
 	user := entity.UserEntity{
 		ID:   uuid.New(),
@@ -130,7 +140,7 @@ func TestDeleteUser(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepo := mocks.NewMockUserRepo(ctrl)
-	uc := usecase.NewUser(mockRepo)
+	uc := usecase.NewUser(mockRepo, inlineTx{}, nopAudit{}, nopOutbox{}, usecase.AllowAll)
 
 	user := entity.UserEntity{
 		ID:   uuid.New(),
@@ -164,3 +174,483 @@ func TestDeleteUser(t *testing.T) {
 	assert.Error(t, err)
 	assert.EqualError(t, err, "DeleteUser: failed to delete")
 }
+
+func TestGetUserById_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepo(ctrl)
+	uc := usecase.NewUser(mockRepo, inlineTx{}, nopAudit{}, nopOutbox{}, usecase.AllowAll)
+
+	user := entity.UserEntity{ID: uuid.New()}
+
+	mockRepo.EXPECT().GetById(gomock.Any(), user).Return(entity.UserEntity{}, apperror.NotFound("record not found", nil))
+
+	_, err := uc.GetUserById(context.Background(), user)
+
+	assert.ErrorIs(t, err, apperror.ErrNotFound)
+	e, ok := apperror.As(err)
+	if assert.True(t, ok) {
+		assert.Equal(t, "user_not_found", e.ErrorCode())
+		assert.Equal(t, "user not found", e.Message)
+	}
+}
+
+func TestListUsers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepo(ctrl)
+	uc := usecase.NewUser(mockRepo, inlineTx{}, nopAudit{}, nopOutbox{}, usecase.AllowAll)
+
+	createdAt := time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC)
+	users := []entity.UserEntity{{ID: uuid.New(), Name: "Ana"}, {ID: uuid.New(), Name: "Bia", CreatedAt: createdAt}}
+	defaultCriteria := usecase.Criteria{Conditions: []usecase.Condition{}, Sort: []usecase.Order{{Field: "created_at"}}}
+
+	mockRepo.EXPECT().
+		List(gomock.Any(), defaultCriteria, usecase.PageRequest{Limit: usecase.DefaultPageLimit}).
+		Return(usecase.Page[entity.UserEntity]{Items: users, HasMore: true}, nil)
+
+	result, err := uc.ListUsers(context.Background(), usecase.Criteria{}, usecase.PageRequest{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, users, result.Items)
+	assert.Equal(t, &usecase.Cursor{Sort: "created_at", Values: []any{createdAt}, ID: users[1].ID}, result.NextCursor)
+
+	mockRepo.EXPECT().
+		List(gomock.Any(), defaultCriteria, usecase.PageRequest{Limit: usecase.MaxPageLimit, Offset: 10}).
+		Return(usecase.Page[entity.UserEntity]{Items: users}, nil)
+
+	result, err = uc.ListUsers(context.Background(), usecase.Criteria{}, usecase.PageRequest{Limit: 1000, Offset: 10})
+
+	assert.NoError(t, err)
+	assert.Nil(t, result.NextCursor)
+
+	mockRepo.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).Return(usecase.Page[entity.UserEntity]{}, fmt.Errorf("failed to list"))
+
+	_, err = uc.ListUsers(context.Background(), usecase.Criteria{}, usecase.PageRequest{Limit: 5})
+
+	assert.EqualError(t, err, "ListUsers: failed to list")
+}
+
+func TestListUsers_Criteria(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepo(ctrl)
+	uc := usecase.NewUser(mockRepo, inlineTx{}, nopAudit{}, nopOutbox{}, usecase.AllowAll)
+
+	criteria := usecase.Criteria{
+		Conditions: []usecase.Condition{
+			{Field: "name", Op: usecase.OpLike, Value: "ana*"},
+			{Field: "created_at", Op: usecase.OpGt, Value: "2026-01-01"},
+			{Field: "updated_at", Op: usecase.OpLte, Value: "2026-02-01T10:00:00Z"},
+		},
+		Sort: []usecase.Order{{Field: "name", Desc: true}},
+	}
+	resolved := usecase.Criteria{
+		Conditions: []usecase.Condition{
+			{Field: "name", Op: usecase.OpLike, Value: "ana*"},
+			{Field: "created_at", Op: usecase.OpGt, Value: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)},
+			{Field: "updated_at", Op: usecase.OpLte, Value: time.Date(2026, 2, 1, 10, 0, 0, 0, time.UTC)},
+		},
+		Sort: []usecase.Order{{Field: "name", Desc: true}},
+	}
+	last := entity.UserEntity{ID: uuid.New(), Name: "Ana Maria"}
+	cursor := &usecase.Cursor{Sort: "-name", Values: []any{"Ana Paula"}, ID: uuid.New()}
+
+	mockRepo.EXPECT().
+		List(gomock.Any(), resolved, usecase.PageRequest{Limit: 1, Cursor: cursor}).
+		Return(usecase.Page[entity.UserEntity]{Items: []entity.UserEntity{last}, HasMore: true}, nil)
+
+	result, err := uc.ListUsers(context.Background(), criteria, usecase.PageRequest{Limit: 1, Cursor: cursor})
+
+	assert.NoError(t, err)
+	assert.Equal(t, &usecase.Cursor{Sort: "-name", Values: []any{"Ana Maria"}, ID: last.ID}, result.NextCursor)
+}
+
+func TestListUsers_InvalidRequest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepo(ctrl)
+	uc := usecase.NewUser(mockRepo, inlineTx{}, nopAudit{}, nopOutbox{}, usecase.AllowAll)
+
+	tests := []struct {
+		name     string
+		criteria usecase.Criteria
+		req      usecase.PageRequest
+	}{
+		{"offset with cursor", usecase.Criteria{}, usecase.PageRequest{Offset: 5, Cursor: &usecase.Cursor{}}},
+		{"negative offset", usecase.Criteria{}, usecase.PageRequest{Offset: -1}},
+		{"unknown filter field", usecase.Criteria{Conditions: []usecase.Condition{{Field: "password", Op: usecase.OpEq, Value: "x"}}}, usecase.PageRequest{}},
+		{"like on time field", usecase.Criteria{Conditions: []usecase.Condition{{Field: "created_at", Op: usecase.OpLike, Value: "2026*"}}}, usecase.PageRequest{}},
+		{"invalid time", usecase.Criteria{Conditions: []usecase.Condition{{Field: "created_at", Op: usecase.OpGt, Value: "yesterday"}}}, usecase.PageRequest{}},
+		{"unknown sort field", usecase.Criteria{Sort: []usecase.Order{{Field: "id"}}}, usecase.PageRequest{}},
+		{"non sortable field", usecase.Criteria{Sort: []usecase.Order{{Field: "phone"}}}, usecase.PageRequest{}},
+		{"duplicate sort field", usecase.Criteria{Sort: []usecase.Order{{Field: "name"}, {Field: "name", Desc: true}}}, usecase.PageRequest{}},
+		{"cursor for another sort", usecase.Criteria{}, usecase.PageRequest{Cursor: &usecase.Cursor{Sort: "-name", Values: []any{"Ana"}, ID: uuid.New()}}},
+		{"cursor with invalid value", usecase.Criteria{}, usecase.PageRequest{Cursor: &usecase.Cursor{Sort: "created_at", Values: []any{"Ana"}, ID: uuid.New()}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := uc.ListUsers(context.Background(), tt.criteria, tt.req)
+			assert.ErrorIs(t, err, apperror.ErrValidation)
+		})
+	}
+}
+
+func TestPatchUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepo(ctrl)
+	uc := usecase.NewUser(mockRepo, inlineTx{}, nopAudit{}, nopOutbox{}, usecase.AllowAll)
+
+	createdAt := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	current := entity.UserEntity{ID: uuid.New(), Name: "Ana", Phone: "+5511999999999", CreatedAt: createdAt}
+	patched := current
+	patched.Name = "Bia"
+	updated := patched
+	updated.UpdatedAt = time.Now()
+
+	gomock.InOrder(
+		mockRepo.EXPECT().GetById(gomock.Any(), entity.UserEntity{ID: current.ID}).Return(current, nil),
+		mockRepo.EXPECT().UpdateFields(gomock.Any(), patched, "name").Return(updated, nil),
+	)
+
+	result, err := uc.PatchUser(context.Background(), entity.UserEntity{ID: current.ID}, func(u entity.UserEntity) (entity.UserEntity, error) {
+		u.Name = "Bia"
+		u.ID = uuid.New()
+		u.CreatedAt = time.Now()
+		return u, nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, updated, result)
+}
+
+func TestPatchUser_NoChanges(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepo(ctrl)
+	uc := usecase.NewUser(mockRepo, inlineTx{}, nopAudit{}, nopOutbox{}, usecase.AllowAll)
+
+	current := entity.UserEntity{ID: uuid.New(), Name: "Ana"}
+	mockRepo.EXPECT().GetById(gomock.Any(), gomock.Any()).Return(current, nil)
+
+	result, err := uc.PatchUser(context.Background(), current, func(u entity.UserEntity) (entity.UserEntity, error) {
+		return u, nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, current, result)
+}
+
+func TestPatchUser_Errors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepo(ctrl)
+	uc := usecase.NewUser(mockRepo, inlineTx{}, nopAudit{}, nopOutbox{}, usecase.AllowAll)
+
+	user := entity.UserEntity{ID: uuid.New(), Name: "Ana"}
+	rename := func(u entity.UserEntity) (entity.UserEntity, error) {
+		u.Name = "Bia"
+		return u, nil
+	}
+
+	mockRepo.EXPECT().GetById(gomock.Any(), user).Return(entity.UserEntity{}, apperror.NotFound("record not found", nil))
+	_, err := uc.PatchUser(context.Background(), user, rename)
+	assert.ErrorIs(t, err, apperror.ErrNotFound)
+
+	mockRepo.EXPECT().GetById(gomock.Any(), user).Return(user, nil)
+	_, err = uc.PatchUser(context.Background(), user, func(u entity.UserEntity) (entity.UserEntity, error) {
+		return u, apperror.Validation("invalid", nil)
+	})
+	assert.ErrorIs(t, err, apperror.ErrValidation)
+
+	gomock.InOrder(
+		mockRepo.EXPECT().GetById(gomock.Any(), user).Return(user, nil),
+		mockRepo.EXPECT().UpdateFields(gomock.Any(), gomock.Any(), "name").Return(entity.UserEntity{}, fmt.Errorf("failed to update")),
+	)
+	_, err = uc.PatchUser(context.Background(), user, rename)
+	assert.EqualError(t, err, "PatchUser: failed to update")
+}
+
+func TestUserVersionPrecondition(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepo(ctrl)
+	uc := usecase.NewUser(mockRepo, inlineTx{}, nopAudit{}, nopOutbox{}, usecase.AllowAll)
+
+	current := entity.UserEntity{ID: uuid.New(), Name: "Ana", Version: 3}
+	stale := entity.UserEntity{ID: current.ID, Name: "Bia", Version: 2}
+	rename := func(u entity.UserEntity) (entity.UserEntity, error) {
+		u.Name = "Bia"
+		return u, nil
+	}
+
+	mockRepo.EXPECT().GetById(gomock.Any(), gomock.Any()).Return(current, nil).Times(3)
+
+	err := uc.UpdateUser(context.Background(), stale)
+	assert.ErrorIs(t, err, apperror.ErrPreconditionFailed)
+
+	_, err = uc.PatchUser(context.Background(), stale, rename)
+	assert.ErrorIs(t, err, apperror.ErrPreconditionFailed)
+
+	err = uc.DeleteUser(context.Background(), stale)
+	assert.ErrorIs(t, err, apperror.ErrPreconditionFailed)
+
+	e, _ := apperror.As(err)
+	assert.Equal(t, "user_version_mismatch", e.ErrorCode())
+}
+
+func TestUserVersionPrecondition_Match(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepo(ctrl)
+	uc := usecase.NewUser(mockRepo, inlineTx{}, nopAudit{}, nopOutbox{}, usecase.AllowAll)
+
+	current := entity.UserEntity{ID: uuid.New(), Name: "Ana", Version: 3}
+
+	// Without an expected version the write is still conditional on the
+	// version that was read.
+	update := entity.UserEntity{ID: current.ID, Name: "Bia"}
+	expected := update
+	expected.Version = current.Version
+	gomock.InOrder(
+		mockRepo.EXPECT().GetById(gomock.Any(), update).Return(current, nil),
+		mockRepo.EXPECT().Update(gomock.Any(), expected).Return(nil),
+	)
+	assert.NoError(t, uc.UpdateUser(context.Background(), update))
+
+	gomock.InOrder(
+		mockRepo.EXPECT().GetById(gomock.Any(), current).Return(current, nil),
+		mockRepo.EXPECT().DeleteById(gomock.Any(), current).Return(nil),
+	)
+	assert.NoError(t, uc.DeleteUser(context.Background(), current))
+
+	gomock.InOrder(
+		mockRepo.EXPECT().GetById(gomock.Any(), current).Return(current, nil),
+		mockRepo.EXPECT().Update(gomock.Any(), current).Return(apperror.PreconditionFailed("record was modified concurrently", nil)),
+	)
+	err := uc.UpdateUser(context.Background(), current)
+	assert.ErrorIs(t, err, apperror.ErrPreconditionFailed)
+}
+
+// inlineTx runs transactions inline, without a database.
+type inlineTx struct{}
+
+func (inlineTx) RunInTx(ctx context.Context, fn func(context.Context) error, _ ...usecase.TxOption) error {
+	return fn(ctx)
+}
+
+// nopAudit discards audit events.
+type nopAudit struct{}
+
+func (nopAudit) Append(context.Context, entity.AuditEvent) error { return nil }
+
+func (nopAudit) Redact(context.Context, string, uuid.UUID) error { return nil }
+
+func (nopAudit) List(context.Context, string, uuid.UUID, usecase.PageRequest) (usecase.Page[entity.AuditEvent], error) {
+	return usecase.Page[entity.AuditEvent]{}, nil
+}
+
+// nopOutbox discards raised events; the user use case only appends and
+// redacts.
+type nopOutbox struct{ usecase.OutboxRepo }
+
+func (nopOutbox) Append(context.Context, entity.Event) error { return nil }
+
+func (nopOutbox) Redact(context.Context, string, uuid.UUID, []byte) error { return nil }
+
+func TestUserWritesRunInTx(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepo(ctrl)
+	mockTx := mocks.NewMockTransactor(ctrl)
+	mockAudit := mocks.NewMockAuditRepo(ctrl)
+	mockOutbox := mocks.NewMockOutboxRepo(ctrl)
+	uc := usecase.NewUser(mockRepo, mockTx, mockAudit, mockOutbox, usecase.AllowAll)
+
+	type txKey struct{}
+	user := entity.UserEntity{ID: uuid.New(), Name: "Ana", Version: 1}
+
+	// The repository must be called with the context handed out by RunInTx.
+	inTx := gomock.Cond(func(ctx any) bool { return ctx.(context.Context).Value(txKey{}) != nil })
+	mockTx.EXPECT().RunInTx(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(context.Context) error, _ ...usecase.TxOption) error {
+		return fn(context.WithValue(ctx, txKey{}, true))
+	}).Times(2)
+
+	gomock.InOrder(
+		mockRepo.EXPECT().GetById(inTx, user).Return(user, nil),
+		mockRepo.EXPECT().Update(inTx, user).Return(nil),
+		mockAudit.EXPECT().Append(inTx, gomock.Any()).Return(nil),
+		mockOutbox.EXPECT().Append(inTx, gomock.Any()).Return(nil),
+	)
+	assert.NoError(t, uc.UpdateUser(context.Background(), user))
+
+	gomock.InOrder(
+		mockRepo.EXPECT().GetById(inTx, user).Return(user, nil),
+		mockRepo.EXPECT().DeleteById(inTx, user).Return(nil),
+		mockAudit.EXPECT().Append(inTx, gomock.Any()).Return(nil),
+		mockOutbox.EXPECT().Append(inTx, gomock.Any()).Return(nil),
+	)
+	assert.NoError(t, uc.DeleteUser(context.Background(), user))
+
+	mockTx.EXPECT().RunInTx(gomock.Any(), gomock.Any()).Return(fmt.Errorf("could not begin"))
+	_, err := uc.PatchUser(context.Background(), user, func(u entity.UserEntity) (entity.UserEntity, error) { return u, nil })
+	assert.EqualError(t, err, "PatchUser: could not begin")
+}
+
+func TestListDeletedUsers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepo(ctrl)
+	uc := usecase.NewUser(mockRepo, inlineTx{}, nopAudit{}, nopOutbox{}, usecase.AllowAll)
+
+	deletedAt := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	users := []entity.UserEntity{{ID: uuid.New(), Name: "Ana", DeletedAt: &deletedAt}}
+
+	mockRepo.EXPECT().
+		ListDeleted(gomock.Any(),
+			usecase.Criteria{
+				Conditions: []usecase.Condition{{Field: "deleted_at", Op: usecase.OpLt, Value: time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)}},
+				Sort:       []usecase.Order{{Field: "deleted_at", Desc: true}},
+			},
+			usecase.PageRequest{Limit: 1}).
+		Return(usecase.Page[entity.UserEntity]{Items: users, HasMore: true}, nil)
+
+	result, err := uc.ListDeletedUsers(context.Background(),
+		usecase.Criteria{
+			Conditions: []usecase.Condition{{Field: "deleted_at", Op: usecase.OpLt, Value: "2026-04-01"}},
+			Sort:       []usecase.Order{{Field: "deleted_at", Desc: true}},
+		},
+		usecase.PageRequest{Limit: 1})
+
+	assert.NoError(t, err)
+	assert.Equal(t, users, result.Items)
+	assert.Equal(t, &usecase.Cursor{Sort: "-deleted_at", Values: []any{deletedAt}, ID: users[0].ID}, result.NextCursor)
+
+	_, err = uc.ListUsers(context.Background(), usecase.Criteria{Sort: []usecase.Order{{Field: "deleted_at"}}}, usecase.PageRequest{})
+
+	assert.ErrorIs(t, err, apperror.ErrValidation)
+}
+
+func TestRestoreUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepo(ctrl)
+	uc := usecase.NewUser(mockRepo, inlineTx{}, nopAudit{}, nopOutbox{}, usecase.AllowAll)
+
+	user := entity.UserEntity{ID: uuid.New()}
+	restored := entity.UserEntity{ID: user.ID, Name: "Ana", Version: 2}
+
+	gomock.InOrder(
+		mockRepo.EXPECT().Restore(gomock.Any(), user).Return(nil),
+		mockRepo.EXPECT().GetById(gomock.Any(), user).Return(restored, nil),
+	)
+
+	result, err := uc.RestoreUser(context.Background(), user)
+
+	assert.NoError(t, err)
+	assert.Equal(t, restored, result)
+
+	mockRepo.EXPECT().Restore(gomock.Any(), user).Return(apperror.NotFound("record not found", nil))
+
+	_, err = uc.RestoreUser(context.Background(), user)
+
+	appErr, ok := apperror.As(err)
+	require.True(t, ok)
+	assert.Equal(t, "deleted_user_not_found", appErr.ErrorCode())
+}
+
+func TestPurgeUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepo(ctrl)
+	mockAudit := mocks.NewMockAuditRepo(ctrl)
+	mockOutbox := mocks.NewMockOutboxRepo(ctrl)
+	uc := usecase.NewUser(mockRepo, inlineTx{}, mockAudit, mockOutbox, usecase.AllowAll)
+
+	user := entity.UserEntity{ID: uuid.New()}
+
+	gomock.InOrder(
+		mockRepo.EXPECT().Purge(gomock.Any(), user).Return(nil),
+		mockAudit.EXPECT().Redact(gomock.Any(), "user", user.ID).Return(nil),
+		mockOutbox.EXPECT().Redact(gomock.Any(), "user", user.ID, gomock.Any()).DoAndReturn(func(_ context.Context, _ string, _ uuid.UUID, payload []byte) error {
+			assert.JSONEq(t, `{"id":"`+user.ID.String()+`","name":"","phone":"","version":0}`, string(payload))
+			return nil
+		}),
+		mockAudit.EXPECT().Append(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, e entity.AuditEvent) error {
+			assert.Equal(t, entity.AuditPurge, e.Action)
+			assert.Equal(t, user.ID, e.EntityID)
+			assert.Empty(t, e.Changes)
+			return nil
+		}),
+		mockOutbox.EXPECT().Append(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, e entity.Event) error {
+			assert.Equal(t, entity.UserPurged, e.Type)
+			assert.Equal(t, user.ID, e.AggregateID)
+			return nil
+		}),
+	)
+
+	assert.NoError(t, uc.PurgeUser(context.Background(), user))
+
+	mockRepo.EXPECT().Purge(gomock.Any(), user).Return(apperror.NotFound("record not found", nil))
+
+	err := uc.PurgeUser(context.Background(), user)
+
+	assert.ErrorIs(t, err, apperror.ErrNotFound)
+	assert.EqualError(t, err, "PurgeUser: deleted user not found: record not found")
+
+	mockRepo.EXPECT().Purge(gomock.Any(), user).Return(nil)
+	mockAudit.EXPECT().Redact(gomock.Any(), "user", user.ID).Return(errors.New("audit_log is append-only"))
+
+	err = uc.PurgeUser(context.Background(), user)
+
+	assert.EqualError(t, err, "PurgeUser: audit_log is append-only")
+}
+
+func TestPurgeDeletedUsers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepo(ctrl)
+	mockAudit := mocks.NewMockAuditRepo(ctrl)
+	mockOutbox := mocks.NewMockOutboxRepo(ctrl)
+	uc := usecase.NewUser(mockRepo, inlineTx{}, mockAudit, mockOutbox, usecase.AllowAll)
+
+	before := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	purged := []uuid.UUID{uuid.New(), uuid.New()}
+
+	mockRepo.EXPECT().PurgeDeletedBefore(gomock.Any(), before).Return(purged, nil)
+	for _, id := range purged {
+		mockAudit.EXPECT().Redact(gomock.Any(), "user", id).Return(nil)
+		mockOutbox.EXPECT().Redact(gomock.Any(), "user", id, gomock.Any()).Return(nil)
+		mockAudit.EXPECT().Append(gomock.Any(), gomock.Cond(func(e any) bool {
+			return e.(entity.AuditEvent).EntityID == id && e.(entity.AuditEvent).Action == entity.AuditPurge
+		})).Return(nil)
+		mockOutbox.EXPECT().Append(gomock.Any(), gomock.Cond(func(e any) bool {
+			return e.(entity.Event).AggregateID == id && e.(entity.Event).Type == entity.UserPurged
+		})).Return(nil)
+	}
+
+	n, err := uc.PurgeDeletedUsers(context.Background(), before)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), n)
+
+	_, err = uc.PurgeDeletedUsers(context.Background(), time.Time{})
+
+	assert.ErrorIs(t, err, apperror.ErrValidation)
+}
@@ -1,5 +1,10 @@
 // Code generated by MockGen. DO NOT EDIT.
 // Source: ./internal/usecase/interfaces.go
+//
+// Generated by this command:
+//
+//	mockgen -source ./internal/usecase/interfaces.go -package mocks
+//
 
 // Package mocks is a generated GoMock package.
 package mocks
@@ -7,9 +12,11 @@ package mocks
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	entity "github.com/DeSouzaRafael/go-clean-architecture-template/internal/entity"
 	usecase "github.com/DeSouzaRafael/go-clean-architecture-template/internal/usecase"
+	uuid "github.com/google/uuid"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -17,6 +24,7 @@ import (
 type MockUser struct {
 	ctrl     *gomock.Controller
 	recorder *MockUserMockRecorder
+	isgomock struct{}
 }
 
 // MockUserMockRecorder is the mock recorder for MockUser.
@@ -46,7 +54,7 @@ func (m *MockUser) CreateUser(arg0 context.Context, arg1 entity.UserEntity) (ent
 }
 
 // CreateUser indicates an expected call of CreateUser.
-func (mr *MockUserMockRecorder) CreateUser(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockUserMockRecorder) CreateUser(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockUser)(nil).CreateUser), arg0, arg1)
 }
@@ -60,7 +68,7 @@ func (m *MockUser) DeleteUser(arg0 context.Context, arg1 entity.UserEntity) erro
 }
 
 // DeleteUser indicates an expected call of DeleteUser.
-func (mr *MockUserMockRecorder) DeleteUser(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockUserMockRecorder) DeleteUser(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockUser)(nil).DeleteUser), arg0, arg1)
 }
@@ -75,11 +83,100 @@ func (m *MockUser) GetUserById(arg0 context.Context, arg1 entity.UserEntity) (en
 }
 
 // GetUserById indicates an expected call of GetUserById.
-func (mr *MockUserMockRecorder) GetUserById(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockUserMockRecorder) GetUserById(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserById", reflect.TypeOf((*MockUser)(nil).GetUserById), arg0, arg1)
 }
 
+// ListDeletedUsers mocks base method.
+func (m *MockUser) ListDeletedUsers(arg0 context.Context, arg1 usecase.Criteria, arg2 usecase.PageRequest) (usecase.Page[entity.UserEntity], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDeletedUsers", arg0, arg1, arg2)
+	ret0, _ := ret[0].(usecase.Page[entity.UserEntity])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDeletedUsers indicates an expected call of ListDeletedUsers.
+func (mr *MockUserMockRecorder) ListDeletedUsers(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDeletedUsers", reflect.TypeOf((*MockUser)(nil).ListDeletedUsers), arg0, arg1, arg2)
+}
+
+// ListUsers mocks base method.
+func (m *MockUser) ListUsers(arg0 context.Context, arg1 usecase.Criteria, arg2 usecase.PageRequest) (usecase.Page[entity.UserEntity], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUsers", arg0, arg1, arg2)
+	ret0, _ := ret[0].(usecase.Page[entity.UserEntity])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUsers indicates an expected call of ListUsers.
+func (mr *MockUserMockRecorder) ListUsers(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsers", reflect.TypeOf((*MockUser)(nil).ListUsers), arg0, arg1, arg2)
+}
+
+// PatchUser mocks base method.
+func (m *MockUser) PatchUser(arg0 context.Context, arg1 entity.UserEntity, arg2 usecase.UserPatch) (entity.UserEntity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PatchUser", arg0, arg1, arg2)
+	ret0, _ := ret[0].(entity.UserEntity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PatchUser indicates an expected call of PatchUser.
+func (mr *MockUserMockRecorder) PatchUser(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PatchUser", reflect.TypeOf((*MockUser)(nil).PatchUser), arg0, arg1, arg2)
+}
+
+// PurgeDeletedUsers mocks base method.
+func (m *MockUser) PurgeDeletedUsers(ctx context.Context, before time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeDeletedUsers", ctx, before)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeDeletedUsers indicates an expected call of PurgeDeletedUsers.
+func (mr *MockUserMockRecorder) PurgeDeletedUsers(ctx, before any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeDeletedUsers", reflect.TypeOf((*MockUser)(nil).PurgeDeletedUsers), ctx, before)
+}
+
+// PurgeUser mocks base method.
+func (m *MockUser) PurgeUser(arg0 context.Context, arg1 entity.UserEntity) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeUser", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PurgeUser indicates an expected call of PurgeUser.
+func (mr *MockUserMockRecorder) PurgeUser(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeUser", reflect.TypeOf((*MockUser)(nil).PurgeUser), arg0, arg1)
+}
+
+// RestoreUser mocks base method.
+func (m *MockUser) RestoreUser(arg0 context.Context, arg1 entity.UserEntity) (entity.UserEntity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreUser", arg0, arg1)
+	ret0, _ := ret[0].(entity.UserEntity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreUser indicates an expected call of RestoreUser.
+func (mr *MockUserMockRecorder) RestoreUser(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreUser", reflect.TypeOf((*MockUser)(nil).RestoreUser), arg0, arg1)
+}
+
 // UpdateUser mocks base method.
 func (m *MockUser) UpdateUser(arg0 context.Context, arg1 entity.UserEntity) error {
 	m.ctrl.T.Helper()
@@ -89,15 +186,31 @@ func (m *MockUser) UpdateUser(arg0 context.Context, arg1 entity.UserEntity) erro
 }
 
 // UpdateUser indicates an expected call of UpdateUser.
-func (mr *MockUserMockRecorder) UpdateUser(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockUserMockRecorder) UpdateUser(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUser", reflect.TypeOf((*MockUser)(nil).UpdateUser), arg0, arg1)
 }
 
+// UserHistory mocks base method.
+func (m *MockUser) UserHistory(arg0 context.Context, arg1 entity.UserEntity, arg2 usecase.PageRequest) (usecase.Page[entity.AuditEvent], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UserHistory", arg0, arg1, arg2)
+	ret0, _ := ret[0].(usecase.Page[entity.AuditEvent])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UserHistory indicates an expected call of UserHistory.
+func (mr *MockUserMockRecorder) UserHistory(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserHistory", reflect.TypeOf((*MockUser)(nil).UserHistory), arg0, arg1, arg2)
+}
+
 // MockUserRepo is a mock of UserRepo interface.
 type MockUserRepo struct {
 	ctrl     *gomock.Controller
 	recorder *MockUserRepoMockRecorder
+	isgomock struct{}
 }
 
 // MockUserRepoMockRecorder is the mock recorder for MockUserRepo.
@@ -127,7 +240,7 @@ func (m *MockUserRepo) Create(arg0 context.Context, arg1 entity.UserEntity) (ent
 }
 
 // Create indicates an expected call of Create.
-func (mr *MockUserRepoMockRecorder) Create(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockUserRepoMockRecorder) Create(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockUserRepo)(nil).Create), arg0, arg1)
 }
@@ -141,7 +254,7 @@ func (m *MockUserRepo) DeleteById(arg0 context.Context, arg1 entity.UserEntity)
 }
 
 // DeleteById indicates an expected call of DeleteById.
-func (mr *MockUserRepoMockRecorder) DeleteById(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockUserRepoMockRecorder) DeleteById(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteById", reflect.TypeOf((*MockUserRepo)(nil).DeleteById), arg0, arg1)
 }
@@ -156,11 +269,84 @@ func (m *MockUserRepo) GetById(arg0 context.Context, arg1 entity.UserEntity) (en
 }
 
 // GetById indicates an expected call of GetById.
-func (mr *MockUserRepoMockRecorder) GetById(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockUserRepoMockRecorder) GetById(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockUserRepo)(nil).GetById), arg0, arg1)
 }
 
+// List mocks base method.
+func (m *MockUserRepo) List(arg0 context.Context, arg1 usecase.Criteria, arg2 usecase.PageRequest) (usecase.Page[entity.UserEntity], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", arg0, arg1, arg2)
+	ret0, _ := ret[0].(usecase.Page[entity.UserEntity])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockUserRepoMockRecorder) List(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockUserRepo)(nil).List), arg0, arg1, arg2)
+}
+
+// ListDeleted mocks base method.
+func (m *MockUserRepo) ListDeleted(arg0 context.Context, arg1 usecase.Criteria, arg2 usecase.PageRequest) (usecase.Page[entity.UserEntity], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDeleted", arg0, arg1, arg2)
+	ret0, _ := ret[0].(usecase.Page[entity.UserEntity])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDeleted indicates an expected call of ListDeleted.
+func (mr *MockUserRepoMockRecorder) ListDeleted(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDeleted", reflect.TypeOf((*MockUserRepo)(nil).ListDeleted), arg0, arg1, arg2)
+}
+
+// Purge mocks base method.
+func (m *MockUserRepo) Purge(arg0 context.Context, arg1 entity.UserEntity) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Purge", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Purge indicates an expected call of Purge.
+func (mr *MockUserRepoMockRecorder) Purge(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Purge", reflect.TypeOf((*MockUserRepo)(nil).Purge), arg0, arg1)
+}
+
+// PurgeDeletedBefore mocks base method.
+func (m *MockUserRepo) PurgeDeletedBefore(arg0 context.Context, arg1 time.Time) ([]uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeDeletedBefore", arg0, arg1)
+	ret0, _ := ret[0].([]uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeDeletedBefore indicates an expected call of PurgeDeletedBefore.
+func (mr *MockUserRepoMockRecorder) PurgeDeletedBefore(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeDeletedBefore", reflect.TypeOf((*MockUserRepo)(nil).PurgeDeletedBefore), arg0, arg1)
+}
+
+// Restore mocks base method.
+func (m *MockUserRepo) Restore(arg0 context.Context, arg1 entity.UserEntity) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockUserRepoMockRecorder) Restore(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockUserRepo)(nil).Restore), arg0, arg1)
+}
+
 // Update mocks base method.
 func (m *MockUserRepo) Update(arg0 context.Context, arg1 entity.UserEntity) error {
 	m.ctrl.T.Helper()
@@ -170,34 +356,968 @@ func (m *MockUserRepo) Update(arg0 context.Context, arg1 entity.UserEntity) erro
 }
 
 // Update indicates an expected call of Update.
-func (mr *MockUserRepoMockRecorder) Update(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockUserRepoMockRecorder) Update(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockUserRepo)(nil).Update), arg0, arg1)
 }
 
-// MockUseCases is a mock of UseCases interface.
-type MockUseCases struct {
+// UpdateFields mocks base method.
+func (m *MockUserRepo) UpdateFields(arg0 context.Context, arg1 entity.UserEntity, arg2 ...string) (entity.UserEntity, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "UpdateFields", varargs...)
+	ret0, _ := ret[0].(entity.UserEntity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateFields indicates an expected call of UpdateFields.
+func (mr *MockUserRepoMockRecorder) UpdateFields(arg0, arg1 any, arg2 ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFields", reflect.TypeOf((*MockUserRepo)(nil).UpdateFields), varargs...)
+}
+
+// MockAuditRepo is a mock of AuditRepo interface.
+type MockAuditRepo struct {
 	ctrl     *gomock.Controller
-	recorder *MockUseCasesMockRecorder
+	recorder *MockAuditRepoMockRecorder
+	isgomock struct{}
 }
 
-// MockUseCasesMockRecorder is the mock recorder for MockUseCases.
-type MockUseCasesMockRecorder struct {
-	mock *MockUseCases
+// MockAuditRepoMockRecorder is the mock recorder for MockAuditRepo.
+type MockAuditRepoMockRecorder struct {
+	mock *MockAuditRepo
 }
 
-// NewMockUseCases creates a new mock instance.
-func NewMockUseCases(ctrl *gomock.Controller) *MockUseCases {
-	mock := &MockUseCases{ctrl: ctrl}
-	mock.recorder = &MockUseCasesMockRecorder{mock}
+// NewMockAuditRepo creates a new mock instance.
+func NewMockAuditRepo(ctrl *gomock.Controller) *MockAuditRepo {
+	mock := &MockAuditRepo{ctrl: ctrl}
+	mock.recorder = &MockAuditRepoMockRecorder{mock}
 	return mock
 }
 
 // EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockUseCases) EXPECT() *MockUseCasesMockRecorder {
+func (m *MockAuditRepo) EXPECT() *MockAuditRepoMockRecorder {
+	return m.recorder
+}
+
+// Append mocks base method.
+func (m *MockAuditRepo) Append(arg0 context.Context, arg1 entity.AuditEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Append", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Append indicates an expected call of Append.
+func (mr *MockAuditRepoMockRecorder) Append(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Append", reflect.TypeOf((*MockAuditRepo)(nil).Append), arg0, arg1)
+}
+
+// List mocks base method.
+func (m *MockAuditRepo) List(ctx context.Context, entityType string, entityID uuid.UUID, req usecase.PageRequest) (usecase.Page[entity.AuditEvent], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, entityType, entityID, req)
+	ret0, _ := ret[0].(usecase.Page[entity.AuditEvent])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockAuditRepoMockRecorder) List(ctx, entityType, entityID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockAuditRepo)(nil).List), ctx, entityType, entityID, req)
+}
+
+// Redact mocks base method.
+func (m *MockAuditRepo) Redact(ctx context.Context, entityType string, entityID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Redact", ctx, entityType, entityID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Redact indicates an expected call of Redact.
+func (mr *MockAuditRepoMockRecorder) Redact(ctx, entityType, entityID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Redact", reflect.TypeOf((*MockAuditRepo)(nil).Redact), ctx, entityType, entityID)
+}
+
+// MockOutboxRepo is a mock of OutboxRepo interface.
+type MockOutboxRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockOutboxRepoMockRecorder
+	isgomock struct{}
+}
+
+// MockOutboxRepoMockRecorder is the mock recorder for MockOutboxRepo.
+type MockOutboxRepoMockRecorder struct {
+	mock *MockOutboxRepo
+}
+
+// NewMockOutboxRepo creates a new mock instance.
+func NewMockOutboxRepo(ctrl *gomock.Controller) *MockOutboxRepo {
+	mock := &MockOutboxRepo{ctrl: ctrl}
+	mock.recorder = &MockOutboxRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOutboxRepo) EXPECT() *MockOutboxRepoMockRecorder {
+	return m.recorder
+}
+
+// Append mocks base method.
+func (m *MockOutboxRepo) Append(arg0 context.Context, arg1 entity.Event) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Append", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Append indicates an expected call of Append.
+func (mr *MockOutboxRepoMockRecorder) Append(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Append", reflect.TypeOf((*MockOutboxRepo)(nil).Append), arg0, arg1)
+}
+
+// Claim mocks base method.
+func (m *MockOutboxRepo) Claim(ctx context.Context, now time.Time, limit int) ([]entity.OutboxMessage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Claim", ctx, now, limit)
+	ret0, _ := ret[0].([]entity.OutboxMessage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Claim indicates an expected call of Claim.
+func (mr *MockOutboxRepoMockRecorder) Claim(ctx, now, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Claim", reflect.TypeOf((*MockOutboxRepo)(nil).Claim), ctx, now, limit)
+}
+
+// DeletePublished mocks base method.
+func (m *MockOutboxRepo) DeletePublished(arg0 context.Context, arg1 time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePublished", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeletePublished indicates an expected call of DeletePublished.
+func (mr *MockOutboxRepoMockRecorder) DeletePublished(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePublished", reflect.TypeOf((*MockOutboxRepo)(nil).DeletePublished), arg0, arg1)
+}
+
+// Redact mocks base method.
+func (m *MockOutboxRepo) Redact(ctx context.Context, aggregateType string, aggregateID uuid.UUID, payload []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Redact", ctx, aggregateType, aggregateID, payload)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Redact indicates an expected call of Redact.
+func (mr *MockOutboxRepoMockRecorder) Redact(ctx, aggregateType, aggregateID, payload any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Redact", reflect.TypeOf((*MockOutboxRepo)(nil).Redact), ctx, aggregateType, aggregateID, payload)
+}
+
+// SaveDelivery mocks base method.
+func (m *MockOutboxRepo) SaveDelivery(arg0 context.Context, arg1 entity.OutboxMessage) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveDelivery", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveDelivery indicates an expected call of SaveDelivery.
+func (mr *MockOutboxRepoMockRecorder) SaveDelivery(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveDelivery", reflect.TypeOf((*MockOutboxRepo)(nil).SaveDelivery), arg0, arg1)
+}
+
+// MockPublisher is a mock of Publisher interface.
+type MockPublisher struct {
+	ctrl     *gomock.Controller
+	recorder *MockPublisherMockRecorder
+	isgomock struct{}
+}
+
+// MockPublisherMockRecorder is the mock recorder for MockPublisher.
+type MockPublisherMockRecorder struct {
+	mock *MockPublisher
+}
+
+// NewMockPublisher creates a new mock instance.
+func NewMockPublisher(ctrl *gomock.Controller) *MockPublisher {
+	mock := &MockPublisher{ctrl: ctrl}
+	mock.recorder = &MockPublisherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPublisher) EXPECT() *MockPublisherMockRecorder {
+	return m.recorder
+}
+
+// Publish mocks base method.
+func (m *MockPublisher) Publish(arg0 context.Context, arg1 entity.Event) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Publish", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Publish indicates an expected call of Publish.
+func (mr *MockPublisherMockRecorder) Publish(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockPublisher)(nil).Publish), arg0, arg1)
+}
+
+// MockOutbox is a mock of Outbox interface.
+type MockOutbox struct {
+	ctrl     *gomock.Controller
+	recorder *MockOutboxMockRecorder
+	isgomock struct{}
+}
+
+// MockOutboxMockRecorder is the mock recorder for MockOutbox.
+type MockOutboxMockRecorder struct {
+	mock *MockOutbox
+}
+
+// NewMockOutbox creates a new mock instance.
+func NewMockOutbox(ctrl *gomock.Controller) *MockOutbox {
+	mock := &MockOutbox{ctrl: ctrl}
+	mock.recorder = &MockOutboxMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOutbox) EXPECT() *MockOutboxMockRecorder {
+	return m.recorder
+}
+
+// PurgePublished mocks base method.
+func (m *MockOutbox) PurgePublished(arg0 context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgePublished", arg0)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgePublished indicates an expected call of PurgePublished.
+func (mr *MockOutboxMockRecorder) PurgePublished(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgePublished", reflect.TypeOf((*MockOutbox)(nil).PurgePublished), arg0)
+}
+
+// Relay mocks base method.
+func (m *MockOutbox) Relay(arg0 context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Relay", arg0)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Relay indicates an expected call of Relay.
+func (mr *MockOutboxMockRecorder) Relay(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Relay", reflect.TypeOf((*MockOutbox)(nil).Relay), arg0)
+}
+
+// MockAPIKey is a mock of APIKey interface.
+type MockAPIKey struct {
+	ctrl     *gomock.Controller
+	recorder *MockAPIKeyMockRecorder
+	isgomock struct{}
+}
+
+// MockAPIKeyMockRecorder is the mock recorder for MockAPIKey.
+type MockAPIKeyMockRecorder struct {
+	mock *MockAPIKey
+}
+
+// NewMockAPIKey creates a new mock instance.
+func NewMockAPIKey(ctrl *gomock.Controller) *MockAPIKey {
+	mock := &MockAPIKey{ctrl: ctrl}
+	mock.recorder = &MockAPIKeyMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAPIKey) EXPECT() *MockAPIKeyMockRecorder {
 	return m.recorder
 }
 
+// AuthenticateAPIKey mocks base method.
+func (m *MockAPIKey) AuthenticateAPIKey(ctx context.Context, key string) (entity.Principal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthenticateAPIKey", ctx, key)
+	ret0, _ := ret[0].(entity.Principal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AuthenticateAPIKey indicates an expected call of AuthenticateAPIKey.
+func (mr *MockAPIKeyMockRecorder) AuthenticateAPIKey(ctx, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthenticateAPIKey", reflect.TypeOf((*MockAPIKey)(nil).AuthenticateAPIKey), ctx, key)
+}
+
+// CreateAPIKey mocks base method.
+func (m *MockAPIKey) CreateAPIKey(arg0 context.Context, arg1 entity.APIKey) (entity.APIKey, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAPIKey", arg0, arg1)
+	ret0, _ := ret[0].(entity.APIKey)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CreateAPIKey indicates an expected call of CreateAPIKey.
+func (mr *MockAPIKeyMockRecorder) CreateAPIKey(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAPIKey", reflect.TypeOf((*MockAPIKey)(nil).CreateAPIKey), arg0, arg1)
+}
+
+// ListAPIKeys mocks base method.
+func (m *MockAPIKey) ListAPIKeys(arg0 context.Context) ([]entity.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAPIKeys", arg0)
+	ret0, _ := ret[0].([]entity.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAPIKeys indicates an expected call of ListAPIKeys.
+func (mr *MockAPIKeyMockRecorder) ListAPIKeys(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAPIKeys", reflect.TypeOf((*MockAPIKey)(nil).ListAPIKeys), arg0)
+}
+
+// RevokeAPIKey mocks base method.
+func (m *MockAPIKey) RevokeAPIKey(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAPIKey", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeAPIKey indicates an expected call of RevokeAPIKey.
+func (mr *MockAPIKeyMockRecorder) RevokeAPIKey(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAPIKey", reflect.TypeOf((*MockAPIKey)(nil).RevokeAPIKey), ctx, id)
+}
+
+// MockAPIKeyRepo is a mock of APIKeyRepo interface.
+type MockAPIKeyRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockAPIKeyRepoMockRecorder
+	isgomock struct{}
+}
+
+// MockAPIKeyRepoMockRecorder is the mock recorder for MockAPIKeyRepo.
+type MockAPIKeyRepoMockRecorder struct {
+	mock *MockAPIKeyRepo
+}
+
+// NewMockAPIKeyRepo creates a new mock instance.
+func NewMockAPIKeyRepo(ctrl *gomock.Controller) *MockAPIKeyRepo {
+	mock := &MockAPIKeyRepo{ctrl: ctrl}
+	mock.recorder = &MockAPIKeyRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAPIKeyRepo) EXPECT() *MockAPIKeyRepoMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockAPIKeyRepo) Create(arg0 context.Context, arg1 entity.APIKey) (entity.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1)
+	ret0, _ := ret[0].(entity.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockAPIKeyRepoMockRecorder) Create(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockAPIKeyRepo)(nil).Create), arg0, arg1)
+}
+
+// GetByPrefix mocks base method.
+func (m *MockAPIKeyRepo) GetByPrefix(ctx context.Context, prefix string) (entity.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByPrefix", ctx, prefix)
+	ret0, _ := ret[0].(entity.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByPrefix indicates an expected call of GetByPrefix.
+func (mr *MockAPIKeyRepoMockRecorder) GetByPrefix(ctx, prefix any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByPrefix", reflect.TypeOf((*MockAPIKeyRepo)(nil).GetByPrefix), ctx, prefix)
+}
+
+// List mocks base method.
+func (m *MockAPIKeyRepo) List(arg0 context.Context) ([]entity.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", arg0)
+	ret0, _ := ret[0].([]entity.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockAPIKeyRepoMockRecorder) List(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockAPIKeyRepo)(nil).List), arg0)
+}
+
+// Revoke mocks base method.
+func (m *MockAPIKeyRepo) Revoke(ctx context.Context, id uuid.UUID, at time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revoke", ctx, id, at)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Revoke indicates an expected call of Revoke.
+func (mr *MockAPIKeyRepoMockRecorder) Revoke(ctx, id, at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revoke", reflect.TypeOf((*MockAPIKeyRepo)(nil).Revoke), ctx, id, at)
+}
+
+// Touch mocks base method.
+func (m *MockAPIKeyRepo) Touch(ctx context.Context, id uuid.UUID, at, since time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Touch", ctx, id, at, since)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Touch indicates an expected call of Touch.
+func (mr *MockAPIKeyRepoMockRecorder) Touch(ctx, id, at, since any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Touch", reflect.TypeOf((*MockAPIKeyRepo)(nil).Touch), ctx, id, at, since)
+}
+
+// MockLogLevel is a mock of LogLevel interface.
+type MockLogLevel struct {
+	ctrl     *gomock.Controller
+	recorder *MockLogLevelMockRecorder
+	isgomock struct{}
+}
+
+// MockLogLevelMockRecorder is the mock recorder for MockLogLevel.
+type MockLogLevelMockRecorder struct {
+	mock *MockLogLevel
+}
+
+// NewMockLogLevel creates a new mock instance.
+func NewMockLogLevel(ctrl *gomock.Controller) *MockLogLevel {
+	mock := &MockLogLevel{ctrl: ctrl}
+	mock.recorder = &MockLogLevelMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLogLevel) EXPECT() *MockLogLevelMockRecorder {
+	return m.recorder
+}
+
+// GetLogLevel mocks base method.
+func (m *MockLogLevel) GetLogLevel(arg0 context.Context) (entity.LogLevel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLogLevel", arg0)
+	ret0, _ := ret[0].(entity.LogLevel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLogLevel indicates an expected call of GetLogLevel.
+func (mr *MockLogLevelMockRecorder) GetLogLevel(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLogLevel", reflect.TypeOf((*MockLogLevel)(nil).GetLogLevel), arg0)
+}
+
+// ResetLogLevel mocks base method.
+func (m *MockLogLevel) ResetLogLevel(arg0 context.Context) (entity.LogLevel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetLogLevel", arg0)
+	ret0, _ := ret[0].(entity.LogLevel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResetLogLevel indicates an expected call of ResetLogLevel.
+func (mr *MockLogLevelMockRecorder) ResetLogLevel(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetLogLevel", reflect.TypeOf((*MockLogLevel)(nil).ResetLogLevel), arg0)
+}
+
+// SetLogLevel mocks base method.
+func (m *MockLogLevel) SetLogLevel(ctx context.Context, level string, ttl time.Duration) (entity.LogLevel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetLogLevel", ctx, level, ttl)
+	ret0, _ := ret[0].(entity.LogLevel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetLogLevel indicates an expected call of SetLogLevel.
+func (mr *MockLogLevelMockRecorder) SetLogLevel(ctx, level, ttl any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLogLevel", reflect.TypeOf((*MockLogLevel)(nil).SetLogLevel), ctx, level, ttl)
+}
+
+// MockLogLevelController is a mock of LogLevelController interface.
+type MockLogLevelController struct {
+	ctrl     *gomock.Controller
+	recorder *MockLogLevelControllerMockRecorder
+	isgomock struct{}
+}
+
+// MockLogLevelControllerMockRecorder is the mock recorder for MockLogLevelController.
+type MockLogLevelControllerMockRecorder struct {
+	mock *MockLogLevelController
+}
+
+// NewMockLogLevelController creates a new mock instance.
+func NewMockLogLevelController(ctrl *gomock.Controller) *MockLogLevelController {
+	mock := &MockLogLevelController{ctrl: ctrl}
+	mock.recorder = &MockLogLevelControllerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLogLevelController) EXPECT() *MockLogLevelControllerMockRecorder {
+	return m.recorder
+}
+
+// LogLevel mocks base method.
+func (m *MockLogLevelController) LogLevel() entity.LogLevel {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LogLevel")
+	ret0, _ := ret[0].(entity.LogLevel)
+	return ret0
+}
+
+// LogLevel indicates an expected call of LogLevel.
+func (mr *MockLogLevelControllerMockRecorder) LogLevel() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LogLevel", reflect.TypeOf((*MockLogLevelController)(nil).LogLevel))
+}
+
+// Revert mocks base method.
+func (m *MockLogLevelController) Revert() entity.LogLevel {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revert")
+	ret0, _ := ret[0].(entity.LogLevel)
+	return ret0
+}
+
+// Revert indicates an expected call of Revert.
+func (mr *MockLogLevelControllerMockRecorder) Revert() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revert", reflect.TypeOf((*MockLogLevelController)(nil).Revert))
+}
+
+// SetTemporary mocks base method.
+func (m *MockLogLevelController) SetTemporary(level string, ttl time.Duration) (entity.LogLevel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetTemporary", level, ttl)
+	ret0, _ := ret[0].(entity.LogLevel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetTemporary indicates an expected call of SetTemporary.
+func (mr *MockLogLevelControllerMockRecorder) SetTemporary(level, ttl any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTemporary", reflect.TypeOf((*MockLogLevelController)(nil).SetTemporary), level, ttl)
+}
+
+// MockAuthorizer is a mock of Authorizer interface.
+type MockAuthorizer struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuthorizerMockRecorder
+	isgomock struct{}
+}
+
+// MockAuthorizerMockRecorder is the mock recorder for MockAuthorizer.
+type MockAuthorizerMockRecorder struct {
+	mock *MockAuthorizer
+}
+
+// NewMockAuthorizer creates a new mock instance.
+func NewMockAuthorizer(ctrl *gomock.Controller) *MockAuthorizer {
+	mock := &MockAuthorizer{ctrl: ctrl}
+	mock.recorder = &MockAuthorizerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuthorizer) EXPECT() *MockAuthorizerMockRecorder {
+	return m.recorder
+}
+
+// Authorize mocks base method.
+func (m *MockAuthorizer) Authorize(ctx context.Context, perm usecase.Permission, owner uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Authorize", ctx, perm, owner)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Authorize indicates an expected call of Authorize.
+func (mr *MockAuthorizerMockRecorder) Authorize(ctx, perm, owner any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Authorize", reflect.TypeOf((*MockAuthorizer)(nil).Authorize), ctx, perm, owner)
+}
+
+// MockTransactor is a mock of Transactor interface.
+type MockTransactor struct {
+	ctrl     *gomock.Controller
+	recorder *MockTransactorMockRecorder
+	isgomock struct{}
+}
+
+// MockTransactorMockRecorder is the mock recorder for MockTransactor.
+type MockTransactorMockRecorder struct {
+	mock *MockTransactor
+}
+
+// NewMockTransactor creates a new mock instance.
+func NewMockTransactor(ctrl *gomock.Controller) *MockTransactor {
+	mock := &MockTransactor{ctrl: ctrl}
+	mock.recorder = &MockTransactorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTransactor) EXPECT() *MockTransactorMockRecorder {
+	return m.recorder
+}
+
+// RunInTx mocks base method.
+func (m *MockTransactor) RunInTx(ctx context.Context, fn func(context.Context) error, opts ...usecase.TxOption) error {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, fn}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RunInTx", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RunInTx indicates an expected call of RunInTx.
+func (mr *MockTransactorMockRecorder) RunInTx(ctx, fn any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, fn}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunInTx", reflect.TypeOf((*MockTransactor)(nil).RunInTx), varargs...)
+}
+
+// MockIdempotency is a mock of Idempotency interface.
+type MockIdempotency struct {
+	ctrl     *gomock.Controller
+	recorder *MockIdempotencyMockRecorder
+	isgomock struct{}
+}
+
+// MockIdempotencyMockRecorder is the mock recorder for MockIdempotency.
+type MockIdempotencyMockRecorder struct {
+	mock *MockIdempotency
+}
+
+// NewMockIdempotency creates a new mock instance.
+func NewMockIdempotency(ctrl *gomock.Controller) *MockIdempotency {
+	mock := &MockIdempotency{ctrl: ctrl}
+	mock.recorder = &MockIdempotencyMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIdempotency) EXPECT() *MockIdempotencyMockRecorder {
+	return m.recorder
+}
+
+// Begin mocks base method.
+func (m *MockIdempotency) Begin(ctx context.Context, key, fingerprint string) (entity.IdempotencyRecord, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Begin", ctx, key, fingerprint)
+	ret0, _ := ret[0].(entity.IdempotencyRecord)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Begin indicates an expected call of Begin.
+func (mr *MockIdempotencyMockRecorder) Begin(ctx, key, fingerprint any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Begin", reflect.TypeOf((*MockIdempotency)(nil).Begin), ctx, key, fingerprint)
+}
+
+// Complete mocks base method.
+func (m *MockIdempotency) Complete(arg0 context.Context, arg1 entity.IdempotencyRecord) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Complete", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Complete indicates an expected call of Complete.
+func (mr *MockIdempotencyMockRecorder) Complete(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Complete", reflect.TypeOf((*MockIdempotency)(nil).Complete), arg0, arg1)
+}
+
+// PurgeExpired mocks base method.
+func (m *MockIdempotency) PurgeExpired(arg0 context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeExpired", arg0)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeExpired indicates an expected call of PurgeExpired.
+func (mr *MockIdempotencyMockRecorder) PurgeExpired(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeExpired", reflect.TypeOf((*MockIdempotency)(nil).PurgeExpired), arg0)
+}
+
+// Release mocks base method.
+func (m *MockIdempotency) Release(ctx context.Context, key string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Release", ctx, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Release indicates an expected call of Release.
+func (mr *MockIdempotencyMockRecorder) Release(ctx, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Release", reflect.TypeOf((*MockIdempotency)(nil).Release), ctx, key)
+}
+
+// MockIdempotencyRepo is a mock of IdempotencyRepo interface.
+type MockIdempotencyRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockIdempotencyRepoMockRecorder
+	isgomock struct{}
+}
+
+// MockIdempotencyRepoMockRecorder is the mock recorder for MockIdempotencyRepo.
+type MockIdempotencyRepoMockRecorder struct {
+	mock *MockIdempotencyRepo
+}
+
+// NewMockIdempotencyRepo creates a new mock instance.
+func NewMockIdempotencyRepo(ctrl *gomock.Controller) *MockIdempotencyRepo {
+	mock := &MockIdempotencyRepo{ctrl: ctrl}
+	mock.recorder = &MockIdempotencyRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIdempotencyRepo) EXPECT() *MockIdempotencyRepoMockRecorder {
+	return m.recorder
+}
+
+// Acquire mocks base method.
+func (m *MockIdempotencyRepo) Acquire(arg0 context.Context, arg1 entity.IdempotencyRecord) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Acquire", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Acquire indicates an expected call of Acquire.
+func (mr *MockIdempotencyRepoMockRecorder) Acquire(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Acquire", reflect.TypeOf((*MockIdempotencyRepo)(nil).Acquire), arg0, arg1)
+}
+
+// Delete mocks base method.
+func (m *MockIdempotencyRepo) Delete(ctx context.Context, actor, key string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, actor, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockIdempotencyRepoMockRecorder) Delete(ctx, actor, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockIdempotencyRepo)(nil).Delete), ctx, actor, key)
+}
+
+// DeleteExpired mocks base method.
+func (m *MockIdempotencyRepo) DeleteExpired(arg0 context.Context, arg1 time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExpired", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteExpired indicates an expected call of DeleteExpired.
+func (mr *MockIdempotencyRepoMockRecorder) DeleteExpired(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpired", reflect.TypeOf((*MockIdempotencyRepo)(nil).DeleteExpired), arg0, arg1)
+}
+
+// Get mocks base method.
+func (m *MockIdempotencyRepo) Get(ctx context.Context, actor, key string) (entity.IdempotencyRecord, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, actor, key)
+	ret0, _ := ret[0].(entity.IdempotencyRecord)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockIdempotencyRepoMockRecorder) Get(ctx, actor, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockIdempotencyRepo)(nil).Get), ctx, actor, key)
+}
+
+// SaveResponse mocks base method.
+func (m *MockIdempotencyRepo) SaveResponse(arg0 context.Context, arg1 entity.IdempotencyRecord) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveResponse", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveResponse indicates an expected call of SaveResponse.
+func (mr *MockIdempotencyRepoMockRecorder) SaveResponse(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveResponse", reflect.TypeOf((*MockIdempotencyRepo)(nil).SaveResponse), arg0, arg1)
+}
+
+// MockObserver is a mock of Observer interface.
+type MockObserver struct {
+	ctrl     *gomock.Controller
+	recorder *MockObserverMockRecorder
+	isgomock struct{}
+}
+
+// MockObserverMockRecorder is the mock recorder for MockObserver.
+type MockObserverMockRecorder struct {
+	mock *MockObserver
+}
+
+// NewMockObserver creates a new mock instance.
+func NewMockObserver(ctrl *gomock.Controller) *MockObserver {
+	mock := &MockObserver{ctrl: ctrl}
+	mock.recorder = &MockObserverMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockObserver) EXPECT() *MockObserverMockRecorder {
+	return m.recorder
+}
+
+// Start mocks base method.
+func (m *MockObserver) Start(ctx context.Context, useCase, method string) (context.Context, func(error)) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Start", ctx, useCase, method)
+	ret0, _ := ret[0].(context.Context)
+	ret1, _ := ret[1].(func(error))
+	return ret0, ret1
+}
+
+// Start indicates an expected call of Start.
+func (mr *MockObserverMockRecorder) Start(ctx, useCase, method any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Start", reflect.TypeOf((*MockObserver)(nil).Start), ctx, useCase, method)
+}
+
+// MockUseCases is a mock of UseCases interface.
+type MockUseCases struct {
+	ctrl     *gomock.Controller
+	recorder *MockUseCasesMockRecorder
+	isgomock struct{}
+}
+
+// MockUseCasesMockRecorder is the mock recorder for MockUseCases.
+type MockUseCasesMockRecorder struct {
+	mock *MockUseCases
+}
+
+// NewMockUseCases creates a new mock instance.
+func NewMockUseCases(ctrl *gomock.Controller) *MockUseCases {
+	mock := &MockUseCases{ctrl: ctrl}
+	mock.recorder = &MockUseCasesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUseCases) EXPECT() *MockUseCasesMockRecorder {
+	return m.recorder
+}
+
+// APIKeyUseCase mocks base method.
+func (m *MockUseCases) APIKeyUseCase() usecase.APIKey {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "APIKeyUseCase")
+	ret0, _ := ret[0].(usecase.APIKey)
+	return ret0
+}
+
+// APIKeyUseCase indicates an expected call of APIKeyUseCase.
+func (mr *MockUseCasesMockRecorder) APIKeyUseCase() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "APIKeyUseCase", reflect.TypeOf((*MockUseCases)(nil).APIKeyUseCase))
+}
+
+// IdempotencyUseCase mocks base method.
+func (m *MockUseCases) IdempotencyUseCase() usecase.Idempotency {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IdempotencyUseCase")
+	ret0, _ := ret[0].(usecase.Idempotency)
+	return ret0
+}
+
+// IdempotencyUseCase indicates an expected call of IdempotencyUseCase.
+func (mr *MockUseCasesMockRecorder) IdempotencyUseCase() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IdempotencyUseCase", reflect.TypeOf((*MockUseCases)(nil).IdempotencyUseCase))
+}
+
+// LogLevelUseCase mocks base method.
+func (m *MockUseCases) LogLevelUseCase() usecase.LogLevel {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LogLevelUseCase")
+	ret0, _ := ret[0].(usecase.LogLevel)
+	return ret0
+}
+
+// LogLevelUseCase indicates an expected call of LogLevelUseCase.
+func (mr *MockUseCasesMockRecorder) LogLevelUseCase() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LogLevelUseCase", reflect.TypeOf((*MockUseCases)(nil).LogLevelUseCase))
+}
+
 // UserUseCase mocks base method.
 func (m *MockUseCases) UserUseCase() usecase.User {
 	m.ctrl.T.Helper()